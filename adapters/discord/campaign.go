@@ -0,0 +1,318 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// CampaignCommand defines the /campaign slash command.
+//
+// Subcommands:
+//   - create name:… scope:… ruleset:…  creates a campaign bound to this channel or server
+//   - show                   shows the campaign of this channel
+//   - ruleset ruleset:…      changes the ruleset the campaign is played under
+//   - bind id:… scope:…      binds this channel or server to a campaign
+//   - unbind scope:…         removes the binding of this channel or server
+//   - delete                 deletes the campaign of this channel
+//   - list                   lists the campaigns of this server
+//
+// Changing campaigns requires the Manage Channels permission.
+var CampaignCommand = &discordgo.ApplicationCommand{
+	Name:        "campaign",
+	Description: "Manage the campaign played in this channel",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "create",
+			Description: "Create a campaign for this channel or server",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "Campaign name",
+					Required:    true,
+				},
+				scopeOption(),
+				rulesetOption("Ruleset the campaign is played under (defaults to classic)", false),
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show the campaign of this channel",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "ruleset",
+			Description: "Change the ruleset of the campaign of this channel",
+			Options: []*discordgo.ApplicationCommandOption{
+				rulesetOption("Ruleset to play under", true),
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "bind",
+			Description: "Bind this channel or server to an existing campaign",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Campaign ID",
+					Required:    true,
+				},
+				scopeOption(),
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "unbind",
+			Description: "Remove the campaign binding of this channel or server",
+			Options:     []*discordgo.ApplicationCommandOption{scopeOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "Delete the campaign of this channel",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List the campaigns of this server",
+		},
+	},
+}
+
+func scopeOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "scope",
+		Description: "Bind this channel only (default) or the whole server",
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{Name: "channel", Value: "channel"},
+			{Name: "server", Value: "server"},
+		},
+	}
+}
+
+func rulesetOption(description string, required bool) *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(ruleset.All))
+	for i, id := range ruleset.All {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(id),
+			Value: string(id),
+		}
+	}
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "ruleset",
+		Description: description,
+		Required:    required,
+		Choices:     choices,
+	}
+}
+
+// interactionUser returns the ID of the user behind an interaction.
+func interactionUser(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// interactionOwner returns the character owner key of the user behind
+// an interaction outside any campaign: "discord:<guild>:<user>" on a
+// server, and "discord:<user>" in direct messages.
+func interactionOwner(i *discordgo.InteractionCreate) string {
+	if i.GuildID != "" {
+		return "discord:" + i.GuildID + ":" + interactionUser(i)
+	}
+	return "discord:" + interactionUser(i)
+}
+
+// scope resolves what an interaction acts on: the campaign bound to its
+// channel or server, if any, and the character owner of the user. In a
+// campaign the character belongs to the campaign; elsewhere it belongs
+// to the user on that server.
+func (h *Handler) scope(i *discordgo.InteractionCreate) (string, *campaign.Campaign) {
+	c, err := h.campaignOf(i)
+	if err != nil {
+		if !errors.Is(err, campaign.ErrNotFound) {
+			slog.Error("discord campaign lookup failed", "channel", i.ChannelID, "error", err)
+		}
+		return interactionOwner(i), nil
+	}
+	return c.CharacterOwner("discord:" + interactionUser(i)), &c
+}
+
+// campaignOf returns the campaign bound to the channel of an
+// interaction, or to its server.
+func (h *Handler) campaignOf(i *discordgo.InteractionCreate) (campaign.Campaign, error) {
+	if i.GuildID == "" {
+		return campaign.Campaign{}, campaign.ErrNotFound
+	}
+	return campaign.Resolve(h.campaigns, campaign.DiscordChannel(i.GuildID, i.ChannelID), campaign.DiscordGuild(i.GuildID))
+}
+
+// ruleset returns the ruleset a campaign is played under, or the
+// default ruleset outside a campaign.
+func (h *Handler) ruleset(c *campaign.Campaign) *ruleset.Ruleset {
+	if c == nil {
+		return h.rulesets.Default()
+	}
+	return h.rulesets.Select(c.Ruleset)
+}
+
+// canManage reports whether the user behind an interaction
+// may change the campaigns of the server.
+func canManage(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionManageChannels != 0
+}
+
+// handleCampaign handles the /campaign command interaction.
+func (h *Handler) handleCampaign(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	sub := data.Options[0]
+	opts := map[string]string{}
+	for _, opt := range sub.Options {
+		opts[opt.Name] = opt.StringValue()
+	}
+
+	respond(s, i, h.campaign(i, sub.Name, opts))
+}
+
+// campaign runs a /campaign subcommand and returns the reply.
+func (h *Handler) campaign(i *discordgo.InteractionCreate, sub string, opts map[string]string) string {
+	if i.GuildID == "" {
+		return "Campaigns are only available on servers."
+	}
+
+	binding := campaign.DiscordChannel(i.GuildID, i.ChannelID)
+	if opts["scope"] == "server" {
+		binding = campaign.DiscordGuild(i.GuildID)
+	}
+
+	switch sub {
+	case "show":
+		c, err := h.campaignOf(i)
+		if err != nil {
+			return h.campaignError(err)
+		}
+		return formatCampaign(c, h.ruleset(&c))
+
+	case "list":
+		return h.listCampaigns(i.GuildID)
+	}
+
+	if !canManage(i) {
+		return "Changing campaigns requires the Manage Channels permission."
+	}
+
+	var c campaign.Campaign
+	switch sub {
+	case "create":
+		var err error
+		if c, err = campaign.New(opts["name"]); err != nil {
+			return "Invalid campaign: " + err.Error() + "."
+		}
+		c.Ruleset = opts["ruleset"]
+		c.Bind(binding)
+
+	case "ruleset":
+		var err error
+		if c, err = h.campaignOf(i); err != nil {
+			return h.campaignError(err)
+		}
+		c.Ruleset = opts["ruleset"]
+
+	case "bind":
+		var err error
+		if c, err = h.campaigns.Get(opts["id"]); err != nil {
+			return h.campaignError(err)
+		}
+		c.Bind(binding)
+
+	case "unbind", "delete":
+		var err error
+		if c, err = h.campaignOf(i); err != nil {
+			return h.campaignError(err)
+		}
+		if sub == "delete" {
+			if err := h.campaigns.Delete(c.ID); err != nil {
+				slog.Error("discord campaign delete failed", "id", c.ID, "error", err)
+				return "The campaign could not be deleted."
+			}
+			return fmt.Sprintf("Deleted the campaign **%s**.", c.Name)
+		}
+		if !c.Bound(binding) {
+			return "This campaign is not bound here with that scope."
+		}
+		c.Unbind(binding)
+
+	default:
+		return ""
+	}
+
+	if err := h.campaigns.Put(c); err != nil {
+		return h.campaignError(err)
+	}
+	return formatCampaign(c, h.ruleset(&c))
+}
+
+// listCampaigns describes the campaigns bound anywhere on a server.
+func (h *Handler) listCampaigns(guildID string) string {
+	campaigns, err := h.campaigns.List()
+	if err != nil {
+		return h.campaignError(err)
+	}
+
+	var lines []string
+	for _, c := range campaigns {
+		if boundInGuild(c, guildID) {
+			lines = append(lines, fmt.Sprintf("**%s** `%s`", c.Name, c.ID))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "No campaigns on this server. Create one with `/campaign create`."
+	}
+	return strings.Join(lines, "\n")
+}
+
+// boundInGuild reports whether a campaign is bound to a server
+// or to one of its channels.
+func boundInGuild(c campaign.Campaign, guildID string) bool {
+	guild := campaign.DiscordGuild(guildID)
+	for _, b := range c.Bindings {
+		if b == guild || strings.HasPrefix(b, guild+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// campaignError describes a campaign store error to the user.
+func (h *Handler) campaignError(err error) string {
+	switch {
+	case errors.Is(err, campaign.ErrNotFound):
+		return "There is no campaign here. Create one with `/campaign create`."
+	case errors.Is(err, campaign.ErrBound):
+		return "This location is already bound to another campaign. Unbind it first."
+	default:
+		slog.Error("discord campaign store failed", "error", err)
+		return "The campaign could not be loaded or saved."
+	}
+}
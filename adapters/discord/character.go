@@ -0,0 +1,546 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// CharacterCommand defines the /character slash command.
+//
+// Subcommands:
+//   - show                 shows your character sheet
+//   - set name:… edge:… …  creates or updates your sheet
+//   - debility name:… marked:…  marks or clears a debility
+//   - asset add|remove name:…   takes or removes an asset
+//   - asset ability name:… number:… marked:…  marks or clears an asset ability
+//   - asset track name:… track:… value:…      sets an asset track
+//   - xp earn:… spend:…    marks or spends experience
+//   - legacy track:… rank:… ticks:…  marks a Starforged legacy track
+//
+// asset add and asset ability take spend_xp:true to pay for the asset
+// (3 XP) or the ability (2 XP) with experience.
+//   - delete               deletes your sheet
+//
+// Sheets are kept per Discord server, so one person may play a
+// different character on every server. In a channel bound to a
+// campaign the sheet is the user's character in that campaign.
+var CharacterCommand = &discordgo.ApplicationCommand{
+	Name:        "character",
+	Description: "Manage your Ironsworn character sheet",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "Show your character sheet",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "set",
+			Description: "Create or update your character sheet",
+			Options:     sheetOptions(),
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "debility",
+			Description: "Mark or clear a debility",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "name",
+					Description: "Debility",
+					Required:    true,
+					Choices:     debilityChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "marked",
+					Description: "Mark (true) or clear (false) the debility",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+			Name:        "asset",
+			Description: "Manage your assets",
+			Options:     assetOptions(),
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "xp",
+			Description: "Mark or spend experience",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "earn",
+					Description: "Experience earned",
+					MinValue:    &minXP,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "spend",
+					Description: "Experience spent",
+					MinValue:    &minXP,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "legacy",
+			Description: "Mark progress on a legacy track and earn experience",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "track",
+					Description: "Legacy track",
+					Required:    true,
+					Choices:     legacyChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "rank",
+					Description: "Mark the reward of a rank, e.g. a fulfilled vow's",
+					Choices:     rankOption().Choices,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "ticks",
+					Description: "Ticks to mark (4 per box)",
+					MinValue:    &minXP,
+					MaxValue:    track.MaxTicks,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "Delete your character sheet",
+		},
+	},
+}
+
+// assetOptions builds the subcommands of /character asset.
+func assetOptions() []*discordgo.ApplicationCommandOption {
+	name := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "name",
+		Description: "Asset name, e.g. Hound",
+		Required:    true,
+	}
+	spendXP := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "spend_xp",
+		Description: "Pay with experience",
+	}
+	minAbility := float64(1)
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Take an asset",
+			Options:     []*discordgo.ApplicationCommandOption{name, spendXP},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Remove an asset",
+			Options:     []*discordgo.ApplicationCommandOption{name},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "ability",
+			Description: "Mark or clear an asset ability",
+			Options: []*discordgo.ApplicationCommandOption{
+				name,
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "number",
+					Description: "Ability number as printed on the card",
+					Required:    true,
+					MinValue:    &minAbility,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "marked",
+					Description: "Mark (true) or clear (false) the ability",
+					Required:    true,
+				},
+				spendXP,
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "track",
+			Description: "Set an asset track, such as companion health",
+			Options: []*discordgo.ApplicationCommandOption{
+				name,
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "track",
+					Description: "Track name, e.g. health",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "value",
+					Description: "New value",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+// sheetOptions builds the options of /character set:
+// the character name, every stat and meter, and momentum.
+func sheetOptions() []*discordgo.ApplicationCommandOption {
+	opts := []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "name",
+			Description: "Character name",
+		},
+	}
+
+	for _, stat := range move.AllStats {
+		lo, hi := character.Range(stat)
+		minValue := float64(lo)
+		opts = append(opts, &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        string(stat),
+			Description: fmt.Sprintf("%s (%d-%d)", stat, lo, hi),
+			MinValue:    &minValue,
+			MaxValue:    float64(hi),
+		})
+	}
+
+	return append(opts, &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        "momentum",
+		Description: "Momentum (-6 to 10, less one per debility)",
+		MinValue:    &minMomentum,
+		MaxValue:    maxMomentum,
+	})
+}
+
+// minXP is the least experience or ticks the xp and legacy
+// subcommands take.
+var minXP = float64(1)
+
+// legacyChoices builds the fixed choice list of the legacy track option.
+func legacyChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(character.AllLegacies))
+	for i, l := range character.AllLegacies {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(l),
+			Value: string(l),
+		}
+	}
+	return choices
+}
+
+// debilityChoices builds the fixed choice list of the debility option.
+func debilityChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(character.AllDebilities))
+	for i, d := range character.AllDebilities {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(d),
+			Value: string(d),
+		}
+	}
+	return choices
+}
+
+// handleCharacter handles the /character command interaction.
+func (h *Handler) handleCharacter(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	sub := data.Options[0]
+	owner, camp := h.scope(i)
+	reply := h.character(owner, sub.Name, sub.Options)
+	if camp != nil {
+		h.updateMembers(camp.ID, owner)
+	}
+	respond(s, i, reply)
+}
+
+// updateMembers keeps the member list of campaign id in step with the
+// character sheets played in it after owner's sheet has changed. The
+// campaign is loaded afresh so that changes made to it since it was
+// looked up are not lost.
+func (h *Handler) updateMembers(id, owner string) {
+	c, err := h.campaigns.Get(id)
+	if err != nil {
+		if !errors.Is(err, campaign.ErrNotFound) {
+			slog.Error("discord campaign lookup failed", "id", id, "error", err)
+		}
+		return
+	}
+
+	_, err = h.characters.Get(owner)
+	switch {
+	case err == nil && !c.HasMember(owner):
+		c.AddMember(owner)
+	case errors.Is(err, character.ErrNotFound) && c.HasMember(owner):
+		c.RemoveMember(owner)
+	default:
+		return
+	}
+
+	if err := h.campaigns.Put(c); err != nil {
+		slog.Error("discord campaign member update failed", "id", c.ID, "error", err)
+	}
+}
+
+// character runs a /character subcommand for owner and returns the reply.
+func (h *Handler) character(owner, sub string, opts []*discordgo.ApplicationCommandInteractionDataOption) string {
+	sheet, err := h.characters.Get(owner)
+	exists := err == nil
+	if err != nil && !errors.Is(err, character.ErrNotFound) {
+		slog.Error("discord character lookup failed", "owner", owner, "error", err)
+		return "Your character sheet could not be loaded."
+	}
+
+	switch sub {
+	case "show":
+		if !exists {
+			return "You have no character sheet yet. Create one with `/character set`."
+		}
+		return formatSheet(sheet, h.rulesets.Asset)
+
+	case "delete":
+		if err := h.characters.Delete(owner); err != nil {
+			slog.Error("discord character delete failed", "owner", owner, "error", err)
+			return "Your character sheet could not be deleted."
+		}
+		return "Your character sheet was deleted."
+
+	case "set":
+		if !exists {
+			sheet = character.New(owner, "")
+		}
+		for _, opt := range opts {
+			if opt.Name == "name" {
+				sheet.Name = opt.StringValue()
+				continue
+			}
+			if err := sheet.Set(opt.Name, int(opt.IntValue())); err != nil {
+				return "Invalid value: " + err.Error() + "."
+			}
+		}
+
+	case "debility":
+		if !exists {
+			return "You have no character sheet yet. Create one with `/character set`."
+		}
+		var d character.Debility
+		marked := false
+		for _, opt := range opts {
+			switch opt.Name {
+			case "name":
+				d = character.Debility(opt.StringValue())
+			case "marked":
+				marked = opt.BoolValue()
+			}
+		}
+		if _, ok := character.ParseDebility(string(d)); !ok {
+			return fmt.Sprintf("Unknown debility %q.", d)
+		}
+		sheet.Mark(d, marked)
+
+	case "asset":
+		if !exists {
+			return "You have no character sheet yet. Create one with `/character set`."
+		}
+		if len(opts) == 0 {
+			return ""
+		}
+		if problem := h.applyAsset(&sheet, opts[0].Name, opts[0].Options); problem != "" {
+			return problem
+		}
+
+	case "xp", "legacy":
+		if !exists {
+			return "You have no character sheet yet. Create one with `/character set`."
+		}
+		if problem := applyXP(&sheet, sub, opts); problem != "" {
+			return problem
+		}
+
+	default:
+		return ""
+	}
+
+	if err := h.characters.Put(sheet); err != nil {
+		slog.Error("discord character save failed", "owner", owner, "error", err)
+		return "Your character sheet could not be saved."
+	}
+	return formatSheet(sheet, h.rulesets.Asset)
+}
+
+// applyAsset applies a /character asset subcommand to a sheet. It
+// returns a message describing why the subcommand could not be
+// applied, or "" when it was.
+func (h *Handler) applyAsset(sheet *character.Sheet, sub string, opts []*discordgo.ApplicationCommandInteractionDataOption) string {
+	var name, trackName string
+	var number, value int
+	marked, spendXP := false, false
+	for _, opt := range opts {
+		switch opt.Name {
+		case "spend_xp":
+			spendXP = opt.BoolValue()
+		case "name":
+			name = opt.StringValue()
+		case "track":
+			trackName = opt.StringValue()
+		case "number":
+			number = int(opt.IntValue())
+		case "value":
+			value = int(opt.IntValue())
+		case "marked":
+			marked = opt.BoolValue()
+		}
+	}
+
+	a, ok := h.rulesets.Asset(name)
+	if !ok {
+		return fmt.Sprintf("Unknown asset `%s`.", name)
+	}
+	if sub == "add" {
+		add := sheet.AddAsset
+		if spendXP {
+			add = sheet.BuyAsset
+		}
+		if err := add(a); err != nil {
+			return err.Error() + "."
+		}
+		return ""
+	}
+
+	c := sheet.Card(a.ID)
+	if c == nil {
+		return fmt.Sprintf("You do not have %s.", a.Name)
+	}
+	var err error
+	switch sub {
+	case "remove":
+		sheet.RemoveAsset(a.ID)
+	case "ability":
+		if spendXP && marked {
+			err = sheet.UpgradeAsset(a, number)
+		} else {
+			err = c.Mark(a, number, marked)
+		}
+	case "track":
+		err = c.SetTrack(a, trackName, value)
+	}
+	if err != nil {
+		return err.Error() + "."
+	}
+	return ""
+}
+
+// applyXP applies a /character xp or legacy subcommand to a sheet. It
+// returns a message describing why the subcommand could not be
+// applied, or "" when it was.
+func applyXP(sheet *character.Sheet, sub string, opts []*discordgo.ApplicationCommandInteractionDataOption) string {
+	values := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, opt := range opts {
+		values[opt.Name] = opt
+	}
+
+	if sub == "xp" {
+		earn, spend := values["earn"], values["spend"]
+		if earn == nil && spend == nil {
+			return "Set earn or spend."
+		}
+		if earn != nil {
+			if err := sheet.EarnXP(int(earn.IntValue())); err != nil {
+				return err.Error() + "."
+			}
+		}
+		if spend != nil {
+			if err := sheet.SpendXP(int(spend.IntValue())); err != nil {
+				return err.Error() + "."
+			}
+		}
+		return ""
+	}
+
+	var l character.Legacy
+	if opt, ok := values["track"]; ok {
+		l, _ = character.ParseLegacy(opt.StringValue())
+	}
+	if l == "" {
+		return "Unknown legacy track."
+	}
+	ticks := 0
+	if opt, ok := values["ticks"]; ok {
+		ticks = int(opt.IntValue())
+	}
+	if opt, ok := values["rank"]; ok {
+		ticks += character.LegacyTicks(track.Rank(opt.StringValue()), roll.Success)
+	}
+	if ticks < 1 {
+		return "Set rank or ticks."
+	}
+	sheet.MarkLegacy(l, ticks)
+	return ""
+}
+
+// actionRoll performs an action roll for owner. When a stat is given
+// and owner has a character sheet, the stat value is added to adds and
+// the sheet's momentum is used unless momentum is set. Without a sheet
+// the stat is only a label and adds, which must then be set, is the
+// whole modifier.
+//
+// When m is not nil the roll also gets the bonuses of the character's
+// assets for the move and stat; the returned note names the assets,
+// and is empty when none applied.
+//
+// It returns a message explaining the problem when no roll was made.
+func (h *Handler) actionRoll(owner string, m *move.Move, stat move.Stat, adds int, addsSet bool, momentum *int) (roll.Result, string, string) {
+	if stat != "" {
+		sheet, err := h.characters.Get(owner)
+		switch {
+		case err == nil:
+			if momentum != nil {
+				sheet.Momentum = *momentum
+			}
+			note := ""
+			if m != nil {
+				bonus, assets := sheet.AssetBonus(h.rulesets.Asset, m.Key, stat)
+				adds += bonus
+				note = formatAssetBonus(bonus, assets)
+			}
+			r, err := sheet.Roll(h.roller, stat, adds)
+			if err != nil {
+				return roll.Result{}, "", fmt.Sprintf("Unknown stat `%s`.", stat)
+			}
+			return r, note, ""
+		case !errors.Is(err, character.ErrNotFound):
+			slog.Error("discord character lookup failed", "owner", owner, "error", err)
+			return roll.Result{}, "", "Your character sheet could not be loaded."
+		case !addsSet:
+			return roll.Result{}, "", "You have no character sheet yet. Create one with `/character set`, or give the modifier."
+		}
+	}
+
+	if momentum != nil {
+		return h.roller.RollWithMomentum(adds, *momentum), "", ""
+	}
+	return h.roller.Roll(adds), "", ""
+}
@@ -1,13 +1,36 @@
 package discord
 
 import (
+	"errors"
 	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
 
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/fair"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
 )
 
+// Commands lists every slash command handled by this adapter.
+// They must all be registered with Discord at startup.
+var Commands = []*discordgo.ApplicationCommand{
+	Command,
+	OracleCommand,
+	CharacterCommand,
+	VowCommand,
+	TrackCommand,
+	CampaignCommand,
+	HistoryCommand,
+	StatsCommand,
+	DiceCommand,
+}
+
 // Command defines the /ironroll slash command.
 var Command = &discordgo.ApplicationCommand{
 	Name:        "ironroll",
@@ -16,34 +39,329 @@ var Command = &discordgo.ApplicationCommand{
 		{
 			Type:        discordgo.ApplicationCommandOptionInteger,
 			Name:        "modifier",
-			Description: "Optional action modifier (Z)",
+			Description: "Optional action modifier (Z); added to the stat when you have a character sheet",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "progress",
+			Description: "Progress score (0-10); performs a progress roll instead",
+			Required:    false,
+			MinValue:    &minProgress,
+			MaxValue:    maxProgress,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "momentum",
+			Description: "Current momentum (-6 to 10); shows cancellation and burn hints. Defaults to your sheet",
+			Required:    false,
+			MinValue:    &minMomentum,
+			MaxValue:    maxMomentum,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "move",
+			Description: "Named move, e.g. face_danger; adds the move text for the outcome",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "stat",
+			Description: "Stat or meter to roll with; its value comes from your character sheet",
+			Required:    false,
+			Choices:     statChoices(),
+		},
+		rulesetOption("Ruleset to roll under; defaults to the campaign's", false),
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "verify",
+			Description: "Roll ID of a verifiable roll to recompute from its revealed secret",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "commitment",
+			Description: "Full commitment shown with the roll to verify",
 			Required:    false,
 		},
 	},
 }
 
-// HandleInteraction handles the /ironroll command interaction.
+// Progress option bounds. MinValue is a pointer in discordgo
+// so that an explicit zero can be distinguished from "unset".
+var (
+	minProgress = float64(roll.MinProgressScore)
+	maxProgress = float64(roll.MaxProgressScore)
+	minMomentum = float64(roll.MinMomentum)
+	maxMomentum = float64(roll.MaxMomentum)
+)
+
+// Config holds the dependencies of the Discord adapter.
+type Config struct {
+	// Roller performs all dice rolls. Defaults to roll.Default().
+	Roller *roll.Roller
+
+	// Prover enables roll verification.
+	// It should be the Prover behind Roller. Optional.
+	Prover *fair.Prover
+
+	// Oracles holds the oracle tables of classic Ironsworn.
+	// Defaults to oracle.Builtin().
+	Oracles *oracle.Registry
+
+	// Moves holds the named moves of classic Ironsworn.
+	// Defaults to move.Builtin().
+	Moves *move.Catalog
+
+	// Rulesets holds the games a campaign can be played under. Defaults
+	// to ruleset.Builtin with Oracles and Moves as the classic content.
+	Rulesets *ruleset.Registry
+
+	// Characters stores character sheets.
+	// Defaults to an in-memory store.
+	Characters character.Store
+
+	// Tracks stores progress tracks.
+	// Defaults to an in-memory store.
+	Tracks track.Store
+
+	// Campaigns stores campaigns and their channel bindings.
+	// Defaults to an in-memory store.
+	Campaigns campaign.Store
+
+	// History records every roll.
+	// Defaults to an in-memory history.Ring.
+	History history.Store
+}
+
+// Handler answers Discord interactions.
 //
-// This handler is stateless and performs a single roll per invocation.
-func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	if i.ApplicationCommandData().Name != "ironroll" {
+// A Handler is safe for concurrent use as long as its dependencies
+// are. Its only state of its own is the set of recent rerolls.
+type Handler struct {
+	roller     *roll.Roller
+	prover     *fair.Prover
+	rulesets   *ruleset.Registry
+	characters character.Store
+	tracks     track.Store
+	campaigns  campaign.Store
+	history    history.Store
+	rerolls    *usedRerolls
+}
+
+// NewHandler creates a Handler from the given dependencies.
+func NewHandler(cfg Config) *Handler {
+	if cfg.Roller == nil {
+		cfg.Roller = roll.Default()
+	}
+	if cfg.Oracles == nil {
+		cfg.Oracles = oracle.Builtin()
+	}
+	if cfg.Moves == nil {
+		cfg.Moves = move.Builtin()
+	}
+	if cfg.Rulesets == nil {
+		cfg.Rulesets = ruleset.Builtin(cfg.Oracles, cfg.Moves)
+	}
+	if cfg.Characters == nil {
+		cfg.Characters = character.NewMemoryStore()
+	}
+	if cfg.Tracks == nil {
+		cfg.Tracks = track.NewMemoryStore()
+	}
+	if cfg.Campaigns == nil {
+		cfg.Campaigns = campaign.NewMemoryStore()
+	}
+	if cfg.History == nil {
+		cfg.History = history.NewRing(history.DefaultCapacity)
+	}
+
+	return &Handler{
+		roller:     cfg.Roller,
+		prover:     cfg.Prover,
+		rulesets:   cfg.Rulesets,
+		characters: cfg.Characters,
+		tracks:     cfg.Tracks,
+		campaigns:  cfg.Campaigns,
+		history:    cfg.History,
+		rerolls:    newUsedRerolls(maxUsedRerolls),
+	}
+}
+
+// HandleInteraction dispatches slash command interactions
+// to the matching command handler, and button presses to
+// handleComponent.
+func (h *Handler) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		h.handleComponent(s, i)
+		return
+	case discordgo.InteractionApplicationCommand:
+	default:
 		return
 	}
 
+	switch i.ApplicationCommandData().Name {
+	case Command.Name:
+		h.handleIronroll(s, i)
+	case OracleCommand.Name:
+		h.handleOracle(s, i)
+	case CharacterCommand.Name:
+		h.handleCharacter(s, i)
+	case VowCommand.Name, TrackCommand.Name:
+		h.handleTrack(s, i)
+	case CampaignCommand.Name:
+		h.handleCampaign(s, i)
+	case HistoryCommand.Name:
+		h.handleHistory(s, i)
+	case StatsCommand.Name:
+		h.handleStats(s, i)
+	case DiceCommand.Name:
+		h.handleDice(s, i)
+	}
+}
+
+// handleIronroll handles the /ironroll command interaction.
+//
+// This handler is stateless and performs a single roll per invocation.
+// When the progress option is present a progress roll is performed
+// and the modifier is ignored. The stat option rolls with a stat from
+// the user's character sheet. The move option names the move being
+// made and adds its outcome text. The verify option performs no roll
+// and instead recomputes an earlier verifiable roll, checked against
+// the commitment option.
+//
+// In a channel bound to a campaign the sheet is the user's character
+// in that campaign, and the roll is played under the campaign's
+// ruleset unless the ruleset option names another. Every roll is
+// recorded in the roll history.
+func (h *Handler) handleIronroll(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	modifier := 0
+	modifierSet := false
+	progress := -1
+	var momentum *int
+	verifyID := ""
+	commitment := ""
+	moveName := ""
+	stat := ""
+	rulesetName := ""
 	for _, opt := range i.ApplicationCommandData().Options {
-		if opt.Name == "modifier" {
+		switch opt.Name {
+		case "verify":
+			verifyID = opt.StringValue()
+		case "commitment":
+			commitment = opt.StringValue()
+		case "move":
+			moveName = opt.StringValue()
+		case "stat":
+			stat = opt.StringValue()
+		case "ruleset":
+			rulesetName = opt.StringValue()
+		case "modifier":
 			modifier = int(opt.IntValue())
+			modifierSet = true
+		case "progress":
+			progress = int(opt.IntValue())
+		case "momentum":
+			m := int(opt.IntValue())
+			momentum = &m
+		}
+	}
+
+	owner, camp := h.scope(i)
+	rec := h.entry(i, camp)
+	rs := h.ruleset(camp)
+	if override, ok := h.rulesets.Lookup(rulesetName); ok {
+		rs = override
+	}
+
+	var content string
+	switch {
+	case verifyID != "":
+		content = h.verify(commitment, verifyID)
+	case moveName != "":
+		content = h.rollMove(&rec, owner, rs, moveName, stat, modifier, modifierSet, progress, momentum)
+	case progress >= 0:
+		r := h.roller.ProgressRoll(progress)
+		rec.SetProgress(r)
+		content = formatProgressResult(r, rs.Terms)
+	case stat != "":
+		r, _, problem := h.actionRoll(owner, nil, move.Stat(stat), modifier, modifierSet, momentum)
+		if problem != "" {
+			content = problem
+		} else {
+			rec.Stat = stat
+			rec.SetAction(r)
+			content = formatStatResult(move.Stat(stat), formatResult(r, rs.Terms))
 		}
+	case momentum != nil:
+		r := h.roller.RollWithMomentum(modifier, *momentum)
+		rec.SetAction(r)
+		content = formatResult(r, rs.Terms)
+	default:
+		r := h.roller.Roll(modifier)
+		rec.SetAction(r)
+		content = formatResult(r, rs.Terms)
+	}
+
+	// Action rolls carry reroll buttons (see handleReroll).
+	respondWithButtons(s, i, content, entryButtons(rs, rec))
+	h.record(rec)
+}
+
+// verify recomputes a verifiable roll made under commitment and
+// describes the result.
+func (h *Handler) verify(commitment, id string) string {
+	if h.prover == nil {
+		return "Verifiable rolls are disabled on this bot."
 	}
 
-	result := roll.Roll(modifier)
-	content := formatResult(result)
+	replay, err := h.prover.Lookup(commitment, id)
+	switch {
+	case errors.Is(err, fair.ErrInvalidID):
+		return "That is not a valid roll ID."
+	case errors.Is(err, fair.ErrInvalidCommitment):
+		return "Give the full commitment shown with the roll, too."
+	case errors.Is(err, fair.ErrCommitmentMismatch):
+		return "That roll was not made under this commitment."
+	case errors.Is(err, fair.ErrNotRevealed):
+		return "The secret for this roll has not been revealed yet. Try again after the next rotation."
+	case err != nil:
+		slog.Error("discord roll verification failed", "id", id, "error", err)
+		return "Verification failed."
+	}
+
+	return formatReplay(replay)
+}
+
+// respond sends content as the public reply to an interaction.
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	respondWithButtons(s, i, content, nil)
+}
+
+// respondWithButtons sends content as the public reply to an
+// interaction, with message components such as buttons.
+func respondWithButtons(s *discordgo.Session, i *discordgo.InteractionCreate, content string, components []discordgo.MessageComponent) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: components,
+		},
+	})
+	if err != nil {
+		slog.Error("discord interaction response failed", "error", err)
+	}
+}
 
+// respondPrivately sends content as a reply only the user behind
+// the interaction can see.
+func respondPrivately(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
 	if err != nil {
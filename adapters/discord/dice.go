@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/dice"
+)
+
+// DiceCommand defines the /roll slash command, which rolls a dice
+// expression such as 2d10+1 or 4d6kh3 outside the Ironsworn mechanic.
+var DiceCommand = &discordgo.ApplicationCommand{
+	Name:        "roll",
+	Description: "Roll a dice expression, e.g. 2d10+1, 4d6kh3 or d66",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "expr",
+			Description: "Dice expression: NdM, d%, d66, +/- constants, kh/kl/dh/dl N, ! to explode",
+			Required:    true,
+		},
+	},
+}
+
+// handleDice handles the /roll command interaction.
+// Dice expressions are not Ironsworn rolls and are not recorded in
+// the roll history.
+func (h *Handler) handleDice(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	raw := ""
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "expr" {
+			raw = opt.StringValue()
+		}
+	}
+
+	e, err := dice.Parse(raw)
+	if err != nil {
+		respond(s, i, "Invalid dice expression: "+strings.TrimPrefix(err.Error(), "dice: ")+".")
+		return
+	}
+	respond(s, i, formatDice(e.Roll(h.roller)))
+}
@@ -2,15 +2,63 @@ package discord
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/dice"
+	"github.com/mtzvd/ironroll/core/fair"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/stats"
+	"github.com/mtzvd/ironroll/core/track"
 )
 
-// formatResult converts a roll.Result into a Discord-friendly message.
+// formatResult converts a roll.Result into a Discord-friendly message,
+// naming outcomes in the ruleset's terms.
 //
 // Markdown is intentionally simple to ensure compatibility
 // across desktop and mobile clients.
-func formatResult(r roll.Result) string {
+func formatResult(r roll.Result, t ruleset.Terms) string {
+	return formatRoll(r, t) + formatMomentum(r.Momentum, t) + formatProof(r.Proof)
+}
+
+// formatProof renders the roll ID and the full commitment of a
+// verifiable roll, both of which are needed to verify it later.
+func formatProof(p *roll.Proof) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n🔏 Roll ID: `%s`\n🧾 Commitment: `%s`", p.Nonce, p.Commitment)
+}
+
+// formatReplay renders the verification of a revealed roll.
+func formatReplay(r fair.Replay) string {
+	dice := fmt.Sprintf("🎯 Challenge Dice: `%d`, `%d`", r.ChallengeDice[0], r.ChallengeDice[1])
+	if r.Kind == roll.KindAction {
+		dice = fmt.Sprintf("🎲 Action Die: `%d`\n", r.ActionDie) + dice
+	}
+
+	return fmt.Sprintf(
+		"**Roll Verification**\n\n"+
+			"🔏 Roll ID: `%s`\n"+
+			"🔑 Secret: `%s`\n"+
+			"🧾 Commitment: `%s`\n\n"+
+			"%s\n\n"+
+			"✅ The secret matches the commitment and reproduces these dice.",
+		r.ID,
+		r.Secret,
+		r.Commitment,
+		dice,
+	)
+}
+
+// formatRoll renders the dice breakdown shared by every action roll.
+func formatRoll(r roll.Result, t ruleset.Terms) string {
 	return fmt.Sprintf(
 		"**Ironsworn Roll**\n\n"+
 			"🎲 Action Die: `%d`\n"+
@@ -23,6 +71,357 @@ func formatResult(r roll.Result) string {
 		r.ChallengeDice[0],
 		r.ChallengeDice[1],
 		r.Total,
-		r.Outcome,
+		t.Outcome(r.Outcome),
+	)
+}
+
+// formatMomentum renders the momentum lines of an action roll.
+// It returns an empty string when the roll was made without momentum.
+func formatMomentum(m *roll.MomentumEffect, t ruleset.Terms) string {
+	if m == nil {
+		return ""
+	}
+
+	s := fmt.Sprintf("\n⚡ Momentum: `%+d`", m.Value)
+	if m.Cancelled {
+		s += "\n❌ Action die cancelled by negative momentum"
+	}
+	if m.CanBurn {
+		s += fmt.Sprintf("\n🔥 Burn momentum → **%s**", t.Outcome(m.BurnOutcome))
+	}
+	return s
+}
+
+// formatProgressResult converts a roll.ProgressResult into a
+// Discord-friendly message.
+func formatProgressResult(r roll.ProgressResult, t ruleset.Terms) string {
+	return fmt.Sprintf(
+		"**Ironsworn Progress Roll**\n\n"+
+			"📈 Progress: `%d`\n"+
+			"🎯 Challenge Dice: `%d`, `%d`\n\n"+
+			"✅ **Outcome**: **%s**",
+		r.ProgressScore,
+		r.ChallengeDice[0],
+		r.ChallengeDice[1],
+		t.Outcome(r.Outcome),
+	) + formatProof(r.Proof)
+}
+
+// formatRerolls renders the reroll audit trail of an action roll,
+// one line per rerolled die. It is empty for a roll never rerolled.
+func formatRerolls(rerolls []roll.Reroll) string {
+	var b strings.Builder
+	for _, rr := range rerolls {
+		fmt.Fprintf(&b, "\n🔁 Rerolled %s: `%d` → `%d`", slotName(rr.Die), rr.Original, rr.Replacement)
+	}
+	return b.String()
+}
+
+// slotName names a die of an action roll, e.g. "challenge die 1".
+func slotName(s roll.DieSlot) string {
+	switch s {
+	case roll.SlotChallenge1:
+		return "challenge die 1"
+	case roll.SlotChallenge2:
+		return "challenge die 2"
+	default:
+		return "action die"
+	}
+}
+
+// formatAssetBonus renders the note naming the assets whose abilities
+// added to a move roll. It is empty when none did.
+func formatAssetBonus(add int, assets []string) string {
+	if len(assets) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n🃏 `%+d` from %s", add, strings.Join(assets, ", "))
+}
+
+// formatMoveResult wraps a rendered roll with the move name,
+// the stat it was rolled with and the move text for the outcome.
+func formatMoveResult(m move.Move, stat move.Stat, body string, o roll.Outcome) string {
+	heading := fmt.Sprintf("**%s**", m.Name)
+	if stat != "" {
+		heading += fmt.Sprintf(" (+%s)", stat)
+	}
+	return heading + "\n\n" + body + "\n\n📜 " + m.OutcomeText(o)
+}
+
+// formatStatResult prefixes a rendered roll with the stat it was made with.
+func formatStatResult(stat move.Stat, body string) string {
+	return fmt.Sprintf("**+%s**\n\n%s", stat, body)
+}
+
+// formatSheet converts a character sheet into a Discord message,
+// finding the character's assets with lookup.
+func formatSheet(s character.Sheet, lookup func(string) (asset.Asset, bool)) string {
+	name := s.Name
+	if name == "" {
+		name = "Unnamed character"
+	}
+
+	text := fmt.Sprintf(
+		"**%s**\n\n"+
+			"⚔️ Edge `%d` · Heart `%d` · Iron `%d` · Shadow `%d` · Wits `%d`\n"+
+			"❤️ Health `%d` · 🧠 Spirit `%d` · 🎒 Supply `%d`\n"+
+			"⚡ Momentum `%+d` (max `%d`, reset `%d`)",
+		name,
+		s.Edge, s.Heart, s.Iron, s.Shadow, s.Wits,
+		s.Health, s.Spirit, s.Supply,
+		s.Momentum, s.MaxMomentum(), s.MomentumReset(),
+	)
+
+	if len(s.Debilities) > 0 {
+		names := make([]string, len(s.Debilities))
+		for i, d := range s.Debilities {
+			names[i] = string(d)
+		}
+		text += "\n🩹 Debilities: " + strings.Join(names, ", ")
+	}
+	for _, c := range s.Assets {
+		text += "\n" + formatCard(c, lookup)
+	}
+	if s.XPEarned > 0 {
+		text += fmt.Sprintf("\n🏅 XP `%d` (earned `%d`, spent `%d`)", s.XP(), s.XPEarned, s.XPSpent)
+	}
+	for _, l := range character.AllLegacies {
+		if t, ok := s.Legacies[l]; ok {
+			text += "\n" + formatLegacy(l, t)
+		}
+	}
+	return text
+}
+
+// formatLegacy renders a legacy track on one line, drawn like a
+// progress track and marked ✅ once it has been filled.
+func formatLegacy(l character.Legacy, t character.LegacyTrack) string {
+	name := strings.ToUpper(string(l)[:1]) + string(l)[1:]
+	text := fmt.Sprintf("📖 %s %s `%d/10`", name, formatBoxes(t.Ticks), t.Score())
+	if t.Completed {
+		text += " ✅"
+	}
+	return text
+}
+
+// formatVowReward describes the experience earned for a fulfilled vow:
+// directly, or through the quests legacy track.
+func formatVowReward(s character.Sheet, xp int, legacies bool) string {
+	if legacies {
+		return fmt.Sprintf("\n\n📖 Quests legacy %s, `+%d` XP", formatBoxes(s.Legacies[character.Quests].Ticks), xp)
+	}
+	return fmt.Sprintf("\n\n🏅 `+%d` XP", xp)
+}
+
+// formatCard renders an asset card on one line: the asset name, its
+// abilities as marked (●) or unmarked (○) and its tracks.
+func formatCard(c asset.Card, lookup func(string) (asset.Asset, bool)) string {
+	a, ok := lookup(c.Asset)
+	if !ok {
+		return fmt.Sprintf("🃏 `%s` (unknown asset)", c.Asset)
+	}
+
+	var b strings.Builder
+	b.WriteString("🃏 **" + a.Name + "** ")
+	for i := range a.Abilities {
+		if c.Marked(i) {
+			b.WriteString("●")
+		} else {
+			b.WriteString("○")
+		}
+	}
+	for _, t := range a.Tracks {
+		fmt.Fprintf(&b, " · %s `%d/%d`", t.Name, c.Track(t), t.Max)
+	}
+	return b.String()
+}
+
+// formatTrack converts a progress track into a Discord message.
+func formatTrack(t track.Track) string {
+	status := ""
+	if t.Completed {
+		status = " ✅"
+	}
+
+	return fmt.Sprintf(
+		"**%s**%s (%s, %s) `%s`\n"+
+			"%s `%d/10` (%d ticks)",
+		t.Name,
+		status,
+		t.Kind,
+		t.Rank,
+		t.ID,
+		formatBoxes(t.Ticks),
+		t.Score(),
+		t.Ticks,
+	)
+}
+
+// formatBoxes draws the ten boxes of a track with the given ticks,
+// each as □ (empty), ◪ (partly filled) or ■ (full).
+func formatBoxes(ticks int) string {
+	var boxes strings.Builder
+	for _, n := range (track.Track{Ticks: ticks}).BoxTicks() {
+		switch n {
+		case 0:
+			boxes.WriteString("□")
+		case track.TicksPerBox:
+			boxes.WriteString("■")
+		default:
+			boxes.WriteString("◪")
+		}
+	}
+	return boxes.String()
+}
+
+// formatCampaign converts a campaign played under a ruleset into
+// a Discord message.
+func formatCampaign(c campaign.Campaign, rs *ruleset.Ruleset) string {
+	text := fmt.Sprintf(
+		"**%s** `%s`\n\n"+
+			"📖 Ruleset: %s\n"+
+			"👥 Characters: `%d`",
+		c.Name,
+		c.ID,
+		rs.Name,
+		len(c.Members),
+	)
+	for _, b := range c.Bindings {
+		text += "\n📌 " + formatBinding(b)
+	}
+	return text
+}
+
+// formatBinding renders a campaign binding as a Discord mention
+// where possible.
+func formatBinding(b string) string {
+	if _, channel, ok := strings.Cut(b, ":channel:"); ok {
+		return "<#" + channel + ">"
+	}
+	if strings.HasPrefix(b, campaign.DiscordGuild("")) {
+		return "this server"
+	}
+	return "`" + b + "`"
+}
+
+// formatEntry renders a roll history entry as a single line, naming
+// the outcome in the ruleset's terms. moveName is the display name of
+// the entry's move, if any.
+//
+// Format:
+// <time> <user> **Move** +stat: `5+2=7` vs `3`, `8` → **Strong Hit**
+func formatEntry(e history.Entry, moveName string, t ruleset.Terms) string {
+	user := e.User
+	if id, ok := strings.CutPrefix(e.User, "discord:"); ok {
+		user = "<@" + id + ">"
+	}
+
+	text := fmt.Sprintf("<t:%d:t> %s", e.Time.Unix(), user)
+	if moveName != "" {
+		text += " **" + moveName + "**"
+	}
+	if e.Stat != "" {
+		text += " +" + e.Stat
+	}
+
+	dice := fmt.Sprintf("📈 `%d`", e.Progress)
+	if e.Kind == roll.KindAction {
+		dice = fmt.Sprintf("`%d%+d=%d`", e.ActionDie, e.Modifier, e.Total)
+	}
+	dice += fmt.Sprintf(" vs `%d`, `%d`", e.ChallengeDice[0], e.ChallengeDice[1])
+
+	return fmt.Sprintf("%s: %s → **%s**", text, dice, t.Outcome(roll.Outcome(e.Outcome)))
+}
+
+// formatStats converts a roll statistics report into a Discord message.
+func formatStats(r stats.Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Roll Statistics**\n\n📊 Rolls: `%d` (%d action, %d progress)\n", r.Rolls, r.Action, r.Progress)
+
+	for _, o := range stats.Outcomes {
+		n := r.Outcomes[o]
+		fmt.Fprintf(&b, "✅ %s: `%d` (%.0f%%)\n", o, n, 100*float64(n)/float64(r.Rolls))
+	}
+	fmt.Fprintf(&b, "🎯 Matches: `%d` (%.0f%%, fair dice 10%%)\n\n", r.Matches, 100*r.MatchRate())
+
+	b.WriteString("🎲 Action die: " + formatFaces(r.ActionDie[:]) + "\n" + formatFit(r.ActionFit) + "\n")
+	b.WriteString("🎯 Challenge dice: " + formatFaces(r.ChallengeDie[:]) + "\n" + formatFit(r.ChallengeFit))
+	return b.String()
+}
+
+// formatFaces renders a die histogram as "1:`n` 2:`n` …".
+func formatFaces(counts []int) string {
+	faces := make([]string, len(counts))
+	for i, n := range counts {
+		faces[i] = fmt.Sprintf("%d:`%d`", i+1, n)
+	}
+	return strings.Join(faces, " ")
+}
+
+// formatFit renders a chi-square test of a die with a plain verdict.
+func formatFit(f stats.Fit) string {
+	verdict := "consistent with fair dice"
+	switch {
+	case !f.Reliable:
+		verdict = "too few rolls to judge"
+	case f.PValue < 0.01:
+		verdict = "⚠️ unusual for fair dice"
+	}
+	return fmt.Sprintf("χ² `%.2f` (df %d), p = `%.3f`: %s", f.Statistic, f.DF, f.PValue, verdict)
+}
+
+// formatDice converts a dice expression result into a Discord message
+// with one line per term. Dropped dice are struck through and exploded
+// dice are marked with "!".
+func formatDice(r dice.Result) string {
+	lines := []string{fmt.Sprintf("**Dice: `%s`**\n", r.Expr)}
+	for _, t := range r.Terms {
+		if t.Term.IsConstant() {
+			sign := "➕"
+			if t.Term.Negative {
+				sign = "➖"
+			}
+			lines = append(lines, fmt.Sprintf("%s `%d`", sign, t.Term.Constant))
+			continue
+		}
+		faces := make([]string, len(t.Dice))
+		for j, d := range t.Dice {
+			faces[j] = "`" + d.String() + "`"
+			if d.Dropped {
+				faces[j] = "~~" + faces[j] + "~~"
+			}
+		}
+		label := t.Term.String()
+		if t.Term.Negative {
+			label = "-" + label
+		}
+		lines = append(lines, fmt.Sprintf("🎲 `%s`: %s", label, strings.Join(faces, ", ")))
+	}
+	lines = append(lines, fmt.Sprintf("\n📊 **Total**: `%d`", r.Total))
+	return strings.Join(lines, "\n")
+}
+
+// formatAnswer converts an "Ask the Oracle" answer into a Discord message.
+func formatAnswer(a oracle.Answer) string {
+	return fmt.Sprintf(
+		"**Ask the Oracle**\n\n"+
+			"⚖️ Odds: `%s`\n"+
+			"🎲 Roll: `%d`\n\n"+
+			"🔮 **Answer**: **%s**",
+		a.Odds,
+		a.Roll,
+		a,
+	)
+}
+
+// formatOracleResult converts an oracle table roll into a Discord message.
+func formatOracleResult(r oracle.Result) string {
+	return fmt.Sprintf(
+		"**Oracle: %s**\n\n"+
+			"🎲 Roll: `%d`\n\n"+
+			"🔮 **%s**",
+		r.Table.Name,
+		r.Roll,
+		r.Result,
 	)
 }
@@ -0,0 +1,122 @@
+package discord
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// HistoryCommand defines the /history slash command, which shows the
+// last rolls made in the campaign of the channel, or in the channel
+// itself outside a campaign.
+var HistoryCommand = &discordgo.ApplicationCommand{
+	Name:        "history",
+	Description: "Show the last rolls made here",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "count",
+			Description: fmt.Sprintf("How many rolls to show (defaults to %d)", defaultHistoryCount),
+			MinValue:    &minHistoryCount,
+			MaxValue:    maxHistoryCount,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Only show the rolls of this user",
+		},
+	},
+}
+
+// History count bounds. The maximum keeps the reply well
+// below Discord's 2000 character message limit.
+const defaultHistoryCount = 10
+
+var (
+	minHistoryCount = 1.0
+	maxHistoryCount = 20.0
+)
+
+// entry starts the history entry of a roll made through an interaction.
+func (h *Handler) entry(i *discordgo.InteractionCreate, c *campaign.Campaign) history.Entry {
+	e := history.Entry{
+		Platform: history.Discord,
+		User:     "discord:" + interactionUser(i),
+		Channel:  i.ChannelID,
+	}
+	if c != nil {
+		e.Campaign = c.ID
+	}
+	return e
+}
+
+// record adds an entry to the roll history if a roll was made.
+// Failures are logged; they never affect the reply.
+func (h *Handler) record(e history.Entry) {
+	if !e.Rolled() {
+		return
+	}
+	e.Time = time.Now().UTC()
+	if err := h.history.Add(e); err != nil {
+		slog.Error("discord history add failed", "error", err)
+	}
+}
+
+// handleHistory handles the /history command interaction.
+func (h *Handler) handleHistory(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	f := history.Filter{Limit: defaultHistoryCount}
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "count":
+			f.Limit = int(opt.IntValue())
+		case "user":
+			f.User = "discord:" + opt.UserValue(nil).ID
+		}
+	}
+
+	camp := h.scopeHistory(i, &f)
+	respond(s, i, h.recentRolls(f, h.ruleset(camp).Terms))
+}
+
+// scopeHistory narrows a history filter to the campaign of the
+// channel of an interaction, or to the channel outside a campaign.
+// It returns the campaign, if any.
+func (h *Handler) scopeHistory(i *discordgo.InteractionCreate, f *history.Filter) *campaign.Campaign {
+	if _, camp := h.scope(i); camp != nil {
+		f.Campaign = camp.ID
+		return camp
+	}
+	f.Platform = history.Discord
+	f.Channel = i.ChannelID
+	return nil
+}
+
+// recentRolls describes the history entries matching f, naming
+// outcomes in the given terms.
+func (h *Handler) recentRolls(f history.Filter, t ruleset.Terms) string {
+	entries, err := h.history.Query(f)
+	if err != nil {
+		slog.Error("discord history query failed", "error", err)
+		return "The roll history could not be loaded."
+	}
+	if len(entries) == 0 {
+		return "No rolls yet."
+	}
+
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		name := e.Move
+		if m, ok := h.rulesets.Move(e.Move); ok {
+			name = m.Name
+		}
+		lines[i] = formatEntry(e, name, t)
+	}
+	return strings.Join(lines, "\n")
+}
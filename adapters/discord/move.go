@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// statChoices builds the fixed choice list of the stat option.
+func statChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(move.AllStats))
+	for i, s := range move.AllStats {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(s),
+			Value: string(s),
+		}
+	}
+	return choices
+}
+
+// rollMove performs a named move of a ruleset for owner. Action moves
+// roll like actionRoll; progress moves need the progress option. The
+// roll is noted in rec.
+func (h *Handler) rollMove(rec *history.Entry, owner string, rs *ruleset.Ruleset, name, stat string, modifier int, modifierSet bool, progress int, momentum *int) string {
+	m, ok := rs.Move(name)
+	if !ok {
+		return fmt.Sprintf("Unknown move `%s`.", name)
+	}
+
+	if m.Progress {
+		if progress < 0 {
+			return fmt.Sprintf("%s is a progress move; set the progress option.", m.Name)
+		}
+		r := h.roller.ProgressRoll(progress)
+		rec.Move = m.ID
+		rec.SetProgress(r)
+		return formatMoveResult(m, "", formatProgressResult(r, rs.Terms), r.Outcome)
+	}
+
+	s := move.Stat(stat)
+	if s != "" {
+		if err := m.CheckStat(s); err != nil {
+			return err.Error() + "."
+		}
+	}
+
+	r, note, problem := h.actionRoll(owner, &m, s, modifier, modifierSet, momentum)
+	if problem != "" {
+		return problem
+	}
+	rec.Move = m.ID
+	rec.Stat = string(s)
+	rec.SetAction(r)
+	return formatMoveResult(m, s, formatResult(r, rs.Terms)+note, r.Outcome)
+}
@@ -0,0 +1,118 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// OracleCommand defines the /oracle slash command.
+//
+// Subcommands:
+//   - ask odds:<odds>   asks a yes/no question ("Ask the Oracle")
+//   - roll table:<key>  rolls on an oracle table
+//
+// Tables come from the ruleset of the channel's campaign.
+var OracleCommand = &discordgo.ApplicationCommand{
+	Name:        "oracle",
+	Description: "Consult the Ironsworn oracles",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "ask",
+			Description: "Ask the Oracle a yes/no question",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "odds",
+					Description: "How likely is a yes? (defaults to 50/50)",
+					Required:    false,
+					Choices:     oddsChoices(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "roll",
+			Description: "Roll on an oracle table",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "table",
+					Description: "Oracle table, e.g. action, theme, region",
+					Required:    true,
+				},
+			},
+		},
+	},
+}
+
+// oddsChoices builds the fixed choice list of the odds option.
+func oddsChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(oracle.AllOdds))
+	for i, o := range oracle.AllOdds {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(o),
+			Value: string(o),
+		}
+	}
+	return choices
+}
+
+// handleOracle handles the /oracle command interaction.
+func (h *Handler) handleOracle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	sub := data.Options[0]
+	opts := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, opt := range sub.Options {
+		opts[opt.Name] = opt
+	}
+
+	var content string
+	switch sub.Name {
+	case "ask":
+		odds := oracle.FiftyFifty
+		if opt, ok := opts["odds"]; ok {
+			odds = oracle.Odds(opt.StringValue())
+		}
+		content = h.ask(odds)
+	case "roll":
+		_, camp := h.scope(i)
+		content = h.rollOracle(h.ruleset(camp), opts["table"].StringValue())
+	default:
+		return
+	}
+
+	respond(s, i, content)
+}
+
+// ask asks the oracle a yes/no question and describes the answer.
+func (h *Handler) ask(odds oracle.Odds) string {
+	a, err := oracle.Ask(h.roller, odds)
+	if err != nil {
+		return fmt.Sprintf("Unknown odds %q.", odds)
+	}
+	return formatAnswer(a)
+}
+
+// rollOracle rolls on the named oracle table of a ruleset and
+// describes the result.
+func (h *Handler) rollOracle(rs *ruleset.Ruleset, name string) string {
+	table, ok := rs.Oracle(name)
+	if !ok {
+		return fmt.Sprintf("Unknown oracle table %q.", name)
+	}
+
+	res, err := oracle.Roll(h.roller, table)
+	if err != nil {
+		return err.Error()
+	}
+	return formatOracleResult(res)
+}
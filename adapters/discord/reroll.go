@@ -0,0 +1,186 @@
+package discord
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// rerollPrefix starts the custom ID of every reroll button.
+//
+// A reroll button carries everything needed to redo the roll, so no
+// state is kept between the roll and the button press:
+//
+//	reroll;<die>;<ruleset>;<move key>;<stat>;<dice>
+//
+// where die is a roll.DieSlot and dice is the roll in roll.EncodeDice
+// form. Discord allows custom IDs of up to 100 characters.
+const rerollPrefix = "reroll"
+
+// maxUsedRerolls bounds the rerolls remembered per message and die.
+// The oldest are forgotten first; by then their messages no longer
+// show the buttons.
+const maxUsedRerolls = 1000
+
+// handleComponent handles a button press on a message sent by the bot.
+func (h *Handler) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	id := i.MessageComponentData().CustomID
+	if strings.HasPrefix(id, rerollPrefix+";") {
+		h.handleReroll(s, i, id)
+	}
+}
+
+// handleReroll rerolls one die of an action roll, for assets that let
+// a die be rerolled, and edits the message with the new dice and the
+// reroll audit trail. Each die can be rerolled once, and only by the
+// player who rolled; a second press of a button that lands before the
+// message is edited is rejected. Rerolls are not added to the roll history, which
+// keeps the dice as first rolled.
+func (h *Handler) handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, id string) {
+	if i.Message != nil && i.Message.Interaction != nil && i.Message.Interaction.User != nil &&
+		i.Message.Interaction.User.ID != interactionUser(i) {
+		respondPrivately(s, i, "Only the player who rolled can reroll.")
+		return
+	}
+
+	messageID := ""
+	if i.Message != nil {
+		messageID = i.Message.ID
+	}
+	content, components, problem := h.reroll(messageID, id)
+	if problem != "" {
+		respondPrivately(s, i, problem)
+		return
+	}
+	if components == nil {
+		// An empty list, unlike a missing one, removes the buttons.
+		components = []discordgo.MessageComponent{}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: components,
+		},
+	})
+	if err != nil {
+		slog.Error("discord reroll response failed", "error", err)
+	}
+}
+
+// reroll performs the reroll requested by a button custom ID on the
+// message with the given ID and returns the new message content and
+// the buttons left, or a notice explaining why no reroll was made.
+func (h *Handler) reroll(messageID, id string) (string, []discordgo.MessageComponent, string) {
+	const stale = "This roll can no longer be rerolled."
+
+	parts := strings.Split(id, ";")
+	if len(parts) != 6 || parts[0] != rerollPrefix {
+		return "", nil, stale
+	}
+	slot := roll.DieSlot(parts[1])
+	r, err := roll.DecodeDice(parts[5])
+	if err != nil {
+		return "", nil, stale
+	}
+	if r.Rerolled(slot) {
+		return "", nil, "That die was already rerolled."
+	}
+	r, err = h.roller.Reroll(r, slot)
+	if err != nil {
+		return "", nil, stale
+	}
+	if !h.rerolls.claim(messageID, slot) {
+		return "", nil, "That die was already rerolled."
+	}
+
+	rs := h.rulesets.Select(parts[2])
+	key, stat := parts[3], move.Stat(parts[4])
+	body := formatResult(r, rs.Terms) + formatRerolls(r.Rerolls)
+	m, ok := rs.Move(key)
+	switch {
+	case ok && key != "" && !m.Progress:
+		body = formatMoveResult(m, stat, body, r.Outcome)
+	case stat != "":
+		body = formatStatResult(stat, body)
+	}
+	return body, rerollButtons(rs.ID, key, stat, r), ""
+}
+
+// entryButtons returns the reroll buttons for the roll recorded in
+// rec under a ruleset, or nil when it is not an action roll.
+func entryButtons(rs *ruleset.Ruleset, rec history.Entry) []discordgo.MessageComponent {
+	if rec.Kind != roll.KindAction {
+		return nil
+	}
+	r := roll.Result{ActionDie: rec.ActionDie, Modifier: rec.Modifier, ChallengeDice: rec.ChallengeDice}
+	if rec.Momentum != nil {
+		r.Momentum = &roll.MomentumEffect{Value: *rec.Momentum}
+	}
+	key := ""
+	if m, ok := rs.Move(rec.Move); ok && rec.Move != "" {
+		key = m.Key
+	}
+	return rerollButtons(rs.ID, key, move.Stat(rec.Stat), r)
+}
+
+// rerollButtons returns a row with one reroll button per die of r not
+// yet rerolled, or nil when there is none.
+func rerollButtons(rs ruleset.ID, moveKey string, stat move.Stat, r roll.Result) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	for _, slot := range roll.AllSlots {
+		if r.Rerolled(slot) {
+			continue
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    "Reroll " + slotName(slot),
+			Style:    discordgo.SecondaryButton,
+			CustomID: strings.Join([]string{rerollPrefix, string(slot), string(rs), moveKey, string(stat), roll.EncodeDice(r)}, ";"),
+		})
+	}
+	if len(buttons) == 0 {
+		return nil
+	}
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// usedRerolls remembers the dice rerolled on each message. A button
+// carries its roll, so two quick presses of the same button would
+// otherwise both reroll the die before the message shows the first.
+type usedRerolls struct {
+	mu    sync.Mutex
+	max   int
+	used  map[string]bool
+	order []string // Message and die keys, oldest first
+}
+
+func newUsedRerolls(max int) *usedRerolls {
+	return &usedRerolls{max: max, used: make(map[string]bool)}
+}
+
+// claim records the reroll of a die on a message and reports whether
+// it was the first, forgetting the oldest when more than max are kept.
+func (u *usedRerolls) claim(messageID string, slot roll.DieSlot) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	key := messageID + ";" + string(slot)
+	if u.used[key] {
+		return false
+	}
+	u.used[key] = true
+	u.order = append(u.order, key)
+	for len(u.order) > u.max {
+		delete(u.used, u.order[0])
+		u.order = u.order[1:]
+	}
+	return true
+}
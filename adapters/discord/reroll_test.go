@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+func TestRerollOncePerMessage(t *testing.T) {
+	h := NewHandler(Config{})
+
+	r := roll.Rescore(roll.Result{ActionDie: 2, Modifier: 1, ChallengeDice: [2]int{6, 7}})
+	row := rerollButtons(ruleset.Classic, "", "", r)[0].(discordgo.ActionsRow)
+	id := row.Components[0].(discordgo.Button).CustomID
+
+	content, buttons, problem := h.reroll("m1", id)
+	if problem != "" {
+		t.Fatalf("reroll: %s", problem)
+	}
+	if !strings.Contains(content, "🔁") || len(buttons[0].(discordgo.ActionsRow).Components) != 2 {
+		t.Fatalf("reroll = %q, %+v; want the audit trail and two buttons left", content, buttons)
+	}
+
+	// A second press of the same button, before the message shows the
+	// first reroll, is rejected.
+	if _, _, problem := h.reroll("m1", id); problem != "That die was already rerolled." {
+		t.Fatalf("second press problem = %q", problem)
+	}
+
+	// The same die of another message can still be rerolled.
+	if _, _, problem := h.reroll("m2", id); problem != "" {
+		t.Fatalf("reroll on another message: %s", problem)
+	}
+
+	if _, _, problem := h.reroll("m3", "reroll;action;classic;;;x"); problem != "This roll can no longer be rerolled." {
+		t.Fatalf("malformed button problem = %q", problem)
+	}
+}
+
+func TestUsedRerollsAreBounded(t *testing.T) {
+	u := newUsedRerolls(2)
+	for _, m := range []string{"a", "b", "c"} {
+		if !u.claim(m, roll.SlotAction) {
+			t.Fatalf("claim(%s) = false on the first press", m)
+		}
+	}
+	if u.claim("c", roll.SlotAction) {
+		t.Fatal("claim(c) = true on the second press")
+	}
+	// The oldest reroll is forgotten once more than two are kept.
+	if len(u.used) != 2 || u.used["a;"+string(roll.SlotAction)] {
+		t.Fatalf("used = %v; want the two newest", u.used)
+	}
+}
@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/stats"
+)
+
+// StatsCommand defines the /stats slash command, which reports the
+// outcome distribution and dice fairness of the rolls made in the
+// campaign of the channel, or in the channel outside a campaign.
+var StatsCommand = &discordgo.ApplicationCommand{
+	Name:        "stats",
+	Description: "Show roll statistics and a dice fairness test",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Only include the rolls of this user",
+		},
+	},
+}
+
+// handleStats handles the /stats command interaction.
+func (h *Handler) handleStats(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var f history.Filter
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "user" {
+			f.User = "discord:" + opt.UserValue(nil).ID
+		}
+	}
+	h.scopeHistory(i, &f)
+
+	entries, err := h.history.Query(f)
+	if err != nil {
+		slog.Error("discord history query failed", "error", err)
+		respond(s, i, "The roll history could not be loaded.")
+		return
+	}
+
+	rep := stats.Compute(entries)
+	if rep.Rolls == 0 {
+		respond(s, i, "No rolls yet.")
+		return
+	}
+	respond(s, i, formatStats(rep))
+}
@@ -0,0 +1,364 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// VowCommand defines the /vow slash command.
+//
+// Subcommands:
+//   - swear name:… rank:…  starts a new vow
+//   - mark name:… times:…  marks progress by the vow's rank
+//   - fulfill name:…       makes the Fulfill Your Vow progress roll and
+//     rewards your character with experience, or quests legacy
+//     progress in Starforged
+//   - forsake name:…       abandons the vow
+//   - list                 lists your vows
+//
+// In a channel bound to a campaign, shared:true swears a vow for the
+// whole party, and the other subcommands also see the party's vows.
+var VowCommand = &discordgo.ApplicationCommand{
+	Name:        "vow",
+	Description: "Swear and track Ironsworn vows",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "swear",
+			Description: "Swear a new iron vow",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption(), rankOption(), sharedOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "mark",
+			Description: "Mark progress on a vow",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption(), timesOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "fulfill",
+			Description: "Roll to fulfill a vow against its progress",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "forsake",
+			Description: "Forsake a vow and remove it",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List your vows",
+		},
+	},
+}
+
+// TrackCommand defines the /track slash command for journeys,
+// combats and other progress tracks.
+//
+// Subcommands:
+//   - new name:… kind:… rank:…  starts a new track
+//   - mark name:… times:…       marks progress by the track's rank
+//   - clear name:…              clears all progress
+//   - roll name:…               makes a progress roll against the track
+//   - delete name:…             removes the track
+//   - list                      lists all your tracks, vows included
+//
+// Shared tracks work as for /vow.
+var TrackCommand = &discordgo.ApplicationCommand{
+	Name:        "track",
+	Description: "Manage Ironsworn progress tracks",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "new",
+			Description: "Start a new progress track",
+			Options: []*discordgo.ApplicationCommandOption{
+				trackNameOption(),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "kind",
+					Description: "What the track measures",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: string(track.Journey), Value: string(track.Journey)},
+						{Name: string(track.Combat), Value: string(track.Combat)},
+						{Name: string(track.Other), Value: string(track.Other)},
+					},
+				},
+				rankOption(),
+				sharedOption(),
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "mark",
+			Description: "Mark progress on a track",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption(), timesOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "clear",
+			Description: "Clear all progress on a track",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "roll",
+			Description: "Make a progress roll against a track",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "delete",
+			Description: "Delete a track",
+			Options:     []*discordgo.ApplicationCommandOption{trackNameOption()},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List your progress tracks",
+		},
+	},
+}
+
+// minTimes is the lower bound of the times option.
+var minTimes = 1.0
+
+func trackNameOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "name",
+		Description: "Name (or ID) of the track",
+		Required:    true,
+	}
+}
+
+func rankOption() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(track.AllRanks))
+	for i, r := range track.AllRanks {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(r),
+			Value: string(r),
+		}
+	}
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "rank",
+		Description: "Rank of the challenge",
+		Required:    true,
+		Choices:     choices,
+	}
+}
+
+func sharedOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "shared",
+		Description: "Share the track with the campaign of this channel",
+	}
+}
+
+func timesOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        "times",
+		Description: "How many times to mark progress (defaults to 1)",
+		MinValue:    &minTimes,
+		MaxValue:    track.MaxTicks,
+	}
+}
+
+// handleTrack handles the /vow and /track command interactions.
+func (h *Handler) handleTrack(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return
+	}
+
+	sub := data.Options[0]
+	opts := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, opt := range sub.Options {
+		opts[opt.Name] = opt
+	}
+
+	owner, camp := h.scope(i)
+	shared := ""
+	if camp != nil {
+		shared = camp.Owner()
+	}
+	rec := h.entry(i, camp)
+	respond(s, i, h.track(&rec, h.ruleset(camp), owner, shared, data.Name, sub.Name, opts))
+	h.record(rec)
+}
+
+// track runs a /vow or /track subcommand for owner and returns the reply.
+// shared is the owner of the campaign's shared tracks, or empty outside
+// a campaign; tracks are looked up among owner's first. Progress rolls
+// are made with the ruleset's move for the track and noted in rec.
+func (h *Handler) track(rec *history.Entry, rs *ruleset.Ruleset, owner, shared, command, sub string, opts map[string]*discordgo.ApplicationCommandInteractionDataOption) string {
+	str := func(name string) string {
+		if opt, ok := opts[name]; ok {
+			return opt.StringValue()
+		}
+		return ""
+	}
+
+	switch sub {
+	case "swear", "new":
+		kind := track.Vow
+		if command == TrackCommand.Name {
+			kind = track.Kind(str("kind"))
+		}
+		trackOwner := owner
+		if opt, ok := opts["shared"]; ok && opt.BoolValue() {
+			if shared == "" {
+				return "Shared tracks need a channel bound to a campaign. See `/campaign create`."
+			}
+			trackOwner = shared
+		}
+		t, err := track.New(trackOwner, kind, str("name"), track.Rank(str("rank")))
+		if err != nil {
+			return "Invalid track: " + err.Error() + "."
+		}
+		if !h.saveTrack(t) {
+			return "The track could not be saved."
+		}
+		return formatTrack(t)
+
+	case "list":
+		return h.listTracks(owner, shared, command == VowCommand.Name)
+	}
+
+	t, err := track.Find(h.tracks, owner, str("name"))
+	if errors.Is(err, track.ErrNotFound) && shared != "" {
+		t, err = track.Find(h.tracks, shared, str("name"))
+	}
+	switch {
+	case errors.Is(err, track.ErrNotFound):
+		return fmt.Sprintf("No track named %q.", str("name"))
+	case err != nil:
+		slog.Error("discord track lookup failed", "owner", owner, "error", err)
+		return "Your tracks could not be loaded."
+	}
+
+	switch sub {
+	case "mark":
+		times := 1
+		if opt, ok := opts["times"]; ok {
+			times = int(opt.IntValue())
+		}
+		t.Mark(times)
+
+	case "clear":
+		t.Clear()
+
+	case "fulfill", "roll":
+		completed := t.Completed
+		res := t.Fulfill(h.roller)
+		rec.SetProgress(res)
+		if !h.saveTrack(t) {
+			return "The track could not be saved."
+		}
+		body := formatProgressResult(res, rs.Terms)
+		if m, ok := rs.ProgressMove(t.Kind); ok {
+			rec.Move = m.ID
+			body = formatMoveResult(m, "", body, res.Outcome)
+		}
+		if t.Kind == track.Vow && t.Completed && !completed {
+			body += h.rewardVow(owner, rs, t.Rank, res.Outcome)
+		}
+		return formatTrack(t) + "\n\n" + body
+
+	case "forsake", "delete":
+		if err := h.tracks.Delete(t.ID); err != nil {
+			slog.Error("discord track delete failed", "id", t.ID, "error", err)
+			return "The track could not be deleted."
+		}
+		if sub == "forsake" {
+			return fmt.Sprintf("You forsake **%s**. Endure Stress.", t.Name)
+		}
+		return fmt.Sprintf("Deleted **%s**.", t.Name)
+
+	default:
+		return ""
+	}
+
+	if !h.saveTrack(t) {
+		return "The track could not be saved."
+	}
+	return formatTrack(t)
+}
+
+// rewardVow rewards owner's character for fulfilling a vow and
+// returns a note of the reward, or "" when owner has no sheet.
+func (h *Handler) rewardVow(owner string, rs *ruleset.Ruleset, rank track.Rank, o roll.Outcome) string {
+	sheet, err := h.characters.Get(owner)
+	if err != nil {
+		if !errors.Is(err, character.ErrNotFound) {
+			slog.Error("discord character lookup failed", "owner", owner, "error", err)
+		}
+		return ""
+	}
+
+	xp := sheet.FulfillVow(rank, o, rs.Legacies)
+	if err := h.characters.Put(sheet); err != nil {
+		slog.Error("discord character save failed", "owner", owner, "error", err)
+		return ""
+	}
+	return formatVowReward(sheet, xp, rs.Legacies)
+}
+
+// saveTrack stores a track, logging any failure.
+func (h *Handler) saveTrack(t track.Track) bool {
+	if err := h.tracks.Put(t); err != nil {
+		slog.Error("discord track save failed", "id", t.ID, "error", err)
+		return false
+	}
+	return true
+}
+
+// listTracks describes the tracks of owner followed by the shared
+// tracks, if any; only vows if vowsOnly.
+func (h *Handler) listTracks(owner, shared string, vowsOnly bool) string {
+	var lines []string
+	for _, o := range []string{owner, shared} {
+		if o == "" {
+			continue
+		}
+		tracks, err := h.tracks.List(o)
+		if err != nil {
+			slog.Error("discord track list failed", "owner", o, "error", err)
+			return "Your tracks could not be loaded."
+		}
+		for _, t := range tracks {
+			if vowsOnly && t.Kind != track.Vow {
+				continue
+			}
+			line := formatTrack(t)
+			if o == shared {
+				line = "👥 " + line
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		if vowsOnly {
+			return "You have no vows. Swear one with `/vow swear`."
+		}
+		return "You have no progress tracks. Start one with `/track new` or `/vow swear`."
+	}
+	return strings.Join(lines, "\n\n")
+}
@@ -0,0 +1,133 @@
+package discord
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// options builds the options of a subcommand from name and value
+// pairs. Values may be strings, ints or bools.
+func options(pairs ...any) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	opts := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		opt := &discordgo.ApplicationCommandInteractionDataOption{Name: pairs[i].(string)}
+		switch v := pairs[i+1].(type) {
+		case string:
+			opt.Type, opt.Value = discordgo.ApplicationCommandOptionString, v
+		case int:
+			opt.Type, opt.Value = discordgo.ApplicationCommandOptionInteger, float64(v)
+		case bool:
+			opt.Type, opt.Value = discordgo.ApplicationCommandOptionBoolean, v
+		}
+		opts[opt.Name] = opt
+	}
+	return opts
+}
+
+// runTrack runs a /vow or /track subcommand for owner in a campaign
+// whose shared tracks belong to shared, or outside one if it is empty.
+func runTrack(h *Handler, owner, shared, command, sub string, opts ...any) string {
+	var rec history.Entry
+	return h.track(&rec, h.rulesets.Default(), owner, shared, command, sub, options(opts...))
+}
+
+func TestVowFulfillRewardsOnce(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+	owner := "discord:g:1"
+	if err := h.characters.Put(character.New(owner, "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	runTrack(h, owner, "", "vow", "swear", "name", "Avenge my kin", "rank", "troublesome")
+	runTrack(h, owner, "", "vow", "mark", "name", "Avenge my kin", "times", 10)
+
+	// A full track misses only when a challenge die shows 10.
+	reply := ""
+	for range 10 {
+		if reply = runTrack(h, owner, "", "vow", "fulfill", "name", "Avenge my kin"); strings.Contains(reply, "✅") {
+			break
+		}
+		if strings.Contains(reply, "🏅") {
+			t.Fatalf("a missed fulfillment earned XP: %q", reply)
+		}
+	}
+	sheet, _ := h.characters.Get(owner)
+	if sheet.XPEarned == 0 || !strings.Contains(reply, fmt.Sprintf("🏅 `+%d` XP", sheet.XPEarned)) {
+		t.Fatalf("fulfilled vow gave %d XP; reply %q", sheet.XPEarned, reply)
+	}
+
+	// Fulfilling a completed vow again rolls but earns nothing.
+	earned := sheet.XPEarned
+	reply = runTrack(h, owner, "", "vow", "fulfill", "name", "Avenge my kin")
+	if strings.Contains(reply, "🏅") {
+		t.Fatalf("second fulfillment rewarded again: %q", reply)
+	}
+	if sheet, _ = h.characters.Get(owner); sheet.XPEarned != earned {
+		t.Fatalf("XP earned = %d after a second fulfillment; want %d", sheet.XPEarned, earned)
+	}
+}
+
+func TestTrackMarkAndClear(t *testing.T) {
+	h := NewHandler(Config{})
+	owner := "discord:g:1"
+
+	cases := []struct {
+		sub  string
+		opts []any
+		want string
+	}{
+		{"new", []any{"kind", "journey", "name", "To the Havens", "rank", "dangerous"}, "(0 ticks)"},
+		{"mark", []any{"name", "to the havens", "times", 2}, "`4/10` (16 ticks)"},
+		{"mark", []any{"name", "To the Havens"}, "`6/10` (24 ticks)"},
+		{"clear", []any{"name", "To the Havens"}, "`0/10` (0 ticks)"},
+		{"mark", []any{"name", "Elsewhere"}, `No track named "Elsewhere".`},
+	}
+
+	for _, c := range cases {
+		if got := runTrack(h, owner, "", "track", c.sub, c.opts...); !strings.Contains(got, c.want) {
+			t.Fatalf("%s %v = %q; want it to contain %q", c.sub, c.opts, got, c.want)
+		}
+	}
+}
+
+func TestSharedTrackLookup(t *testing.T) {
+	h := NewHandler(Config{})
+	owner, shared := "campaign:abc:discord:1", "campaign:abc"
+
+	if got := runTrack(h, owner, "", "vow", "swear", "name", "Guard", "rank", "epic", "shared", true); !strings.HasPrefix(got, "Shared tracks need") {
+		t.Fatalf("shared vow outside a campaign = %q", got)
+	}
+	runTrack(h, owner, shared, "vow", "swear", "name", "Guard the village", "rank", "dangerous", "shared", true)
+	runTrack(h, owner, shared, "vow", "swear", "name", "Find my brother", "rank", "formidable")
+
+	// The party's vow is found from the campaign, but not outside it.
+	if got := runTrack(h, owner, shared, "vow", "mark", "name", "Guard the village"); !strings.Contains(got, "(8 ticks)") {
+		t.Fatalf("mark shared vow = %q", got)
+	}
+	if got := runTrack(h, owner, "", "vow", "mark", "name", "Guard the village"); !strings.HasPrefix(got, "No track named") {
+		t.Fatalf("mark shared vow outside the campaign = %q", got)
+	}
+	if tracks, _ := h.tracks.List(shared); len(tracks) != 1 || tracks[0].Ticks != 8 {
+		t.Fatalf("shared tracks = %+v", tracks)
+	}
+
+	// A personal track of the same name is found first.
+	runTrack(h, owner, shared, "vow", "swear", "name", "Guard the village", "rank", "troublesome")
+	runTrack(h, owner, shared, "vow", "mark", "name", "Guard the village")
+	if tracks, _ := h.tracks.List(shared); tracks[0].Ticks != 8 {
+		t.Fatalf("marking a name shared by two vows changed the party's: %+v", tracks[0])
+	}
+
+	list := runTrack(h, owner, shared, "vow", "list")
+	if strings.Count(list, "👥 ") != 1 || !strings.Contains(list, "👥 **Guard the village**") || !strings.Contains(list, "**Find my brother**") {
+		t.Fatalf("list = %q", list)
+	}
+}
@@ -0,0 +1,142 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/character"
+)
+
+// apiAsset is the JSON shape of an asset.
+type apiAsset struct {
+	ID        string        `json:"id"`
+	Key       string        `json:"key"`
+	Name      string        `json:"name"`
+	Category  string        `json:"category"`
+	Abilities []apiAbility  `json:"abilities"`
+	Tracks    []apiTrackMax `json:"tracks"`
+}
+
+// apiAbility is the JSON shape of an asset ability.
+type apiAbility struct {
+	Text    string     `json:"text"`
+	Enabled bool       `json:"enabled"`
+	Bonuses []apiBonus `json:"bonuses,omitempty"`
+}
+
+// apiBonus is the JSON shape of an ability's move bonus.
+type apiBonus struct {
+	Move  string   `json:"move"`
+	Stats []string `json:"stats,omitempty"`
+	Add   int      `json:"add"`
+}
+
+// apiTrackMax is the JSON shape of an asset track.
+type apiTrackMax struct {
+	Name string `json:"name"`
+	Max  int    `json:"max"`
+}
+
+// apiCard is the JSON shape of an asset card on a character sheet.
+type apiCard struct {
+	Asset     string         `json:"asset"`
+	Abilities []bool         `json:"abilities"`
+	Tracks    map[string]int `json:"tracks,omitempty"`
+}
+
+func formatAsset(a asset.Asset) apiAsset {
+	resp := apiAsset{
+		ID:        a.ID,
+		Key:       a.Key,
+		Name:      a.Name,
+		Category:  string(a.Category),
+		Abilities: []apiAbility{},
+		Tracks:    []apiTrackMax{},
+	}
+	for _, ab := range a.Abilities {
+		out := apiAbility{Text: ab.Text, Enabled: ab.Enabled}
+		for _, b := range ab.Bonuses {
+			ob := apiBonus{Move: b.Move, Add: b.Add}
+			for _, s := range b.Stats {
+				ob.Stats = append(ob.Stats, string(s))
+			}
+			out.Bonuses = append(out.Bonuses, ob)
+		}
+		resp.Abilities = append(resp.Abilities, out)
+	}
+	for _, t := range a.Tracks {
+		resp.Tracks = append(resp.Tracks, apiTrackMax{Name: t.Name, Max: t.Max})
+	}
+	return resp
+}
+
+// ListAssetsHandler handles GET /assets requests.
+//
+// Query parameters:
+//   - ruleset: optional ruleset whose assets are listed, including
+//     those of the ruleset it extends (defaults to classic)
+//   - category: optional category, e.g. Companion
+//
+// Responses:
+//   - 200 OK with a JSON array of assets ordered by ID
+//   - 400 Bad Request if the ruleset is not recognized
+func (a *API) ListAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	rs, ok := a.ruleset(w, r, "")
+	if !ok {
+		return
+	}
+	category := r.URL.Query().Get("category")
+
+	resp := []apiAsset{}
+	for ; rs != nil; rs = rs.Base {
+		for _, as := range rs.Assets.Assets() {
+			if category == "" || string(as.Category) == category {
+				resp = append(resp, formatAsset(as))
+			}
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// GetAssetHandler handles GET /assets/{name} requests.
+//
+// The name is an asset ID, key or name, e.g. "hound". Keys are looked
+// up in the ruleset named by the ruleset query parameter first, then
+// in every ruleset.
+//
+// Responses:
+//   - 200 OK with the JSON asset
+//   - 400 Bad Request if the ruleset is not recognized
+//   - 404 Not Found if the asset does not exist
+func (a *API) GetAssetHandler(w http.ResponseWriter, r *http.Request) {
+	rs, ok := a.ruleset(w, r, "")
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("name")
+	as, ok := rs.Asset(name)
+	if !ok {
+		as, ok = a.rulesets.Asset(name)
+	}
+	if !ok {
+		http.Error(w, "asset not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, formatAsset(as))
+}
+
+// validateAssets checks every asset card of a sheet against its asset.
+func (a *API) validateAssets(s character.Sheet) error {
+	for _, c := range s.Assets {
+		as, ok := a.rulesets.Asset(c.Asset)
+		if !ok {
+			return fmt.Errorf("unknown asset %q", c.Asset)
+		}
+		if err := c.Validate(as); err != nil {
+			return err
+		}
+	}
+	return nil
+}
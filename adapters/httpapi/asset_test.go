@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/asset"
+)
+
+func TestAssetHandlers(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		return rw
+	}
+
+	var list []apiAsset
+	if err := json.NewDecoder(get("/assets?category=Companion").Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list) != 1 || list[0].Key != "hound" {
+		t.Fatalf("unexpected companions: %+v", list)
+	}
+
+	// Delve lists the classic assets it extends.
+	if err := json.NewDecoder(get("/assets?ruleset=delve").Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list) != len(asset.Builtin().Assets()) {
+		t.Fatalf("delve assets = %d, want the classic ones", len(list))
+	}
+
+	for _, target := range []string{"/assets/hound", "/assets/classic/assets/companion/hound"} {
+		var a apiAsset
+		if err := json.NewDecoder(get(target).Body).Decode(&a); err != nil {
+			t.Fatalf("GET %s: failed to decode response: %v", target, err)
+		}
+		if a.Name != "Hound" || len(a.Abilities) != 3 || a.Tracks[0].Max != 4 || a.Abilities[0].Bonuses[0].Move != "gather_information" {
+			t.Fatalf("GET %s = %+v", target, a)
+		}
+	}
+
+	if rw := get("/assets/dragon"); rw.Code != http.StatusNotFound {
+		t.Fatalf("unknown asset: expected 404, got %d", rw.Code)
+	}
+	if rw := get("/assets?ruleset=nope"); rw.Code != http.StatusBadRequest {
+		t.Fatalf("unknown ruleset: expected 400, got %d", rw.Code)
+	}
+}
+
+func TestCharacterAssets(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	put := func(assets string) *httptest.ResponseRecorder {
+		body := `{"name":"Kira","edge":1,"heart":1,"iron":1,"shadow":1,"wits":2,` +
+			`"health":5,"spirit":5,"supply":5,"momentum":2,"assets":` + assets + `}`
+		req := httptest.NewRequest(http.MethodPut, "/characters/telegram:1", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	for _, bad := range []string{
+		`[{"asset":"classic/assets/companion/dragon","abilities":[true]}]`,
+		`[{"asset":"classic/assets/companion/hound","abilities":[true],"tracks":{"health":9}}]`,
+	} {
+		if rw := put(bad); rw.Code != http.StatusBadRequest {
+			t.Fatalf("PUT %s: expected 400, got %d", bad, rw.Code)
+		}
+	}
+
+	rw := put(`[{"asset":"classic/assets/companion/hound","abilities":[true,false,false],"tracks":{"health":3}}]`)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("PUT: expected 200, got %d: %s", rw.Code, rw.Body)
+	}
+	var sheet apiCharacter
+	if err := json.NewDecoder(rw.Body).Decode(&sheet); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(sheet.Assets) != 1 || sheet.Assets[0].Tracks["health"] != 3 {
+		t.Fatalf("unexpected assets: %+v", sheet.Assets)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/roll?character=telegram:1&move=gather_information&stat=wits&m=1", nil))
+	var res apiResponse
+	if err := json.NewDecoder(rw.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// wits 2 + m 1 + Hound 1
+	if res.Modifier != 4 || res.Move == nil || res.Move.AssetBonus != 1 || len(res.Move.Assets) != 1 || res.Move.Assets[0] != "Hound" {
+		t.Fatalf("unexpected roll: %+v, move %+v", res, res.Move)
+	}
+}
@@ -0,0 +1,283 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+)
+
+// apiCampaign is the JSON shape of a campaign.
+type apiCampaign struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Ruleset  string   `json:"ruleset"`  // Ruleset ID; empty on input for the default
+	Owner    string   `json:"owner"`    // Owner of the shared tracks; ignored on input
+	Bindings []string `json:"bindings"` // e.g. discord:guild:1:channel:2, telegram:chat:-100
+	Members  []string `json:"members"`  // Character owners; ignored on input
+}
+
+func (a *API) formatCampaign(c campaign.Campaign) apiCampaign {
+	resp := apiCampaign{
+		ID:       c.ID,
+		Name:     c.Name,
+		Ruleset:  string(a.rulesets.Select(c.Ruleset).ID),
+		Owner:    c.Owner(),
+		Bindings: []string{},
+		Members:  []string{},
+	}
+	resp.Bindings = append(resp.Bindings, c.Bindings...)
+	resp.Members = append(resp.Members, c.Members...)
+	return resp
+}
+
+// ListCampaignsHandler handles GET /campaigns requests.
+//
+// Responses:
+//   - 200 OK with a JSON array of campaigns ordered by name
+func (a *API) ListCampaignsHandler(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := a.campaigns.List()
+	if err != nil {
+		slog.Error("http campaign list failed", "err", err)
+		http.Error(w, "campaigns could not be loaded", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiCampaign, len(campaigns))
+	for i, c := range campaigns {
+		resp[i] = a.formatCampaign(c)
+	}
+	writeJSON(w, resp)
+}
+
+// CreateCampaignHandler handles POST /campaigns requests.
+//
+// The body is a JSON object with a name and optional ruleset and
+// bindings. Requires the API token.
+//
+// Responses:
+//   - 201 Created with the JSON campaign
+//   - 400 Bad Request if the body is malformed, the name is missing
+//     or the ruleset is not recognized
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+//   - 409 Conflict if a binding belongs to another campaign
+func (a *API) CreateCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	var body apiCampaign
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid campaign", http.StatusBadRequest)
+		return
+	}
+
+	c, err := campaign.New(body.Name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rulesetID, ok := a.rulesetID(w, body.Ruleset)
+	if !ok {
+		return
+	}
+	c.Ruleset = rulesetID
+	for _, b := range body.Bindings {
+		c.Bind(b)
+	}
+	if !a.saveCampaign(w, c) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(a.formatCampaign(c))
+}
+
+// GetCampaignHandler handles GET /campaigns/{id} requests.
+//
+// Responses:
+//   - 200 OK with the JSON campaign
+//   - 404 Not Found if there is no such campaign
+func (a *API) GetCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	c, ok := a.findCampaign(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, a.formatCampaign(c))
+}
+
+// PutCampaignHandler handles PUT /campaigns/{id} requests.
+//
+// The body is a JSON object with the new name, ruleset and complete
+// list of bindings; members are kept. Requires the API token.
+//
+// Responses:
+//   - 200 OK with the updated JSON campaign
+//   - 400 Bad Request if the body is malformed, the name is missing
+//     or the ruleset is not recognized
+//   - 409 Conflict if a binding belongs to another campaign
+//   - 401, 403 or 404 as for the other campaign endpoints
+func (a *API) PutCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	var body apiCampaign
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid campaign", http.StatusBadRequest)
+		return
+	}
+
+	c, ok := a.findCampaign(w, r)
+	if !ok {
+		return
+	}
+	c.Name = body.Name
+	rulesetID, ok := a.rulesetID(w, body.Ruleset)
+	if !ok {
+		return
+	}
+	c.Ruleset = rulesetID
+	c.Bindings = nil
+	for _, b := range body.Bindings {
+		c.Bind(b)
+	}
+	if err := c.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !a.saveCampaign(w, c) {
+		return
+	}
+	writeJSON(w, a.formatCampaign(c))
+}
+
+// DeleteCampaignHandler handles DELETE /campaigns/{id} requests.
+// The campaign's characters and shared tracks are kept.
+// Requires the API token.
+//
+// Responses:
+//   - 204 No Content
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+func (a *API) DeleteCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := a.campaigns.Delete(id); err != nil {
+		slog.Error("http campaign delete failed", "id", id, "err", err)
+		http.Error(w, "campaign could not be deleted", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CampaignTracksHandler handles GET /campaigns/{id}/tracks requests.
+//
+// Responses:
+//   - 200 OK with a JSON array of the campaign's shared tracks
+//   - 404 Not Found if there is no such campaign
+func (a *API) CampaignTracksHandler(w http.ResponseWriter, r *http.Request) {
+	c, ok := a.findCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	tracks, err := a.tracks.List(c.Owner())
+	if err != nil {
+		slog.Error("http track list failed", "owner", c.Owner(), "err", err)
+		http.Error(w, "tracks could not be loaded", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiTrack, len(tracks))
+	for i, t := range tracks {
+		resp[i] = formatTrack(t)
+	}
+	writeJSON(w, resp)
+}
+
+// CampaignCharactersHandler handles GET /campaigns/{id}/characters requests.
+//
+// Responses:
+//   - 200 OK with a JSON array of the character sheets of the members
+//   - 404 Not Found if there is no such campaign
+func (a *API) CampaignCharactersHandler(w http.ResponseWriter, r *http.Request) {
+	c, ok := a.findCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	resp := []apiCharacter{}
+	for _, owner := range c.Members {
+		sheet, err := a.characters.Get(owner)
+		switch {
+		case err == nil:
+			resp = append(resp, formatCharacter(sheet))
+		case errors.Is(err, character.ErrNotFound):
+			// The member deleted their sheet without leaving.
+		default:
+			slog.Error("http character lookup failed", "owner", owner, "err", err)
+			http.Error(w, "characters could not be loaded", http.StatusInternalServerError)
+			return
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// rulesetID resolves the ruleset named in a campaign body to its ID,
+// or "" for the default. It writes a 400 response and reports false
+// when the name is not recognized.
+func (a *API) rulesetID(w http.ResponseWriter, raw string) (string, bool) {
+	if raw == "" {
+		return "", true
+	}
+	rs, ok := a.rulesets.Lookup(raw)
+	if !ok {
+		http.Error(w, "unknown ruleset", http.StatusBadRequest)
+		return "", false
+	}
+	return string(rs.ID), true
+}
+
+// findCampaign loads the campaign named by the {id} path value, writing
+// the error response and reporting false when it cannot be loaded.
+func (a *API) findCampaign(w http.ResponseWriter, r *http.Request) (campaign.Campaign, bool) {
+	id := r.PathValue("id")
+
+	c, err := a.campaigns.Get(id)
+	switch {
+	case err == nil:
+		return c, true
+	case errors.Is(err, campaign.ErrNotFound):
+		http.Error(w, "campaign not found", http.StatusNotFound)
+	default:
+		slog.Error("http campaign lookup failed", "id", id, "err", err)
+		http.Error(w, "campaign could not be loaded", http.StatusInternalServerError)
+	}
+	return campaign.Campaign{}, false
+}
+
+// saveCampaign stores a campaign, writing the error response
+// and reporting false when it cannot be stored.
+func (a *API) saveCampaign(w http.ResponseWriter, c campaign.Campaign) bool {
+	err := a.campaigns.Put(c)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, campaign.ErrBound):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.Error("http campaign save failed", "id", c.ID, "err", err)
+		http.Error(w, "campaign could not be saved", http.StatusInternalServerError)
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestCampaignLifecycle(t *testing.T) {
+	api, characters := newCharacterAPI()
+	routes := api.Routes()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		var r io.Reader
+		if body != "" {
+			r = strings.NewReader(body)
+		}
+		req := httptest.NewRequest(method, target, r)
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	rw := do(http.MethodPost, "/campaigns", `{"name":"The Ironlands","bindings":["telegram:chat:-100"]}`)
+	if rw.Code != http.StatusCreated {
+		t.Fatalf("POST /campaigns: expected 201, got %d: %s", rw.Code, rw.Body)
+	}
+	var created apiCampaign
+	if err := json.NewDecoder(rw.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Owner != "campaign:"+created.ID || len(created.Bindings) != 1 {
+		t.Fatalf("unexpected campaign: %+v", created)
+	}
+
+	// A second campaign cannot take the same chat.
+	if rw := do(http.MethodPost, "/campaigns", `{"name":"Other","bindings":["telegram:chat:-100"]}`); rw.Code != http.StatusConflict {
+		t.Fatalf("POST with a taken binding: expected 409, got %d", rw.Code)
+	}
+
+	rw = do(http.MethodPut, "/campaigns/"+created.ID, `{"name":"Ironlands","bindings":["discord:guild:1"]}`)
+	var updated apiCampaign
+	if err := json.NewDecoder(rw.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.Name != "Ironlands" || len(updated.Bindings) != 1 || updated.Bindings[0] != "discord:guild:1" {
+		t.Fatalf("unexpected campaign after PUT: %+v", updated)
+	}
+
+	// Shared tracks and member characters are addressed by campaign.
+	vow, _ := track.New(created.Owner, track.Vow, "Avenge my kin", track.Dangerous)
+	if err := api.tracks.Put(vow); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := api.campaigns.Get(created.ID)
+	member := c.CharacterOwner("discord:7")
+	c.AddMember(member)
+	if err := api.campaigns.Put(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := characters.Put(character.New(member, "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	rw = do(http.MethodGet, "/campaigns/"+created.ID+"/tracks", "")
+	var tracks []apiTrack
+	if err := json.NewDecoder(rw.Body).Decode(&tracks); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(tracks) != 1 || tracks[0].ID != vow.ID {
+		t.Fatalf("unexpected shared tracks: %+v", tracks)
+	}
+
+	rw = do(http.MethodGet, "/campaigns/"+created.ID+"/characters", "")
+	var sheets []apiCharacter
+	if err := json.NewDecoder(rw.Body).Decode(&sheets); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(sheets) != 1 || sheets[0].Name != "Kira" {
+		t.Fatalf("unexpected characters: %+v", sheets)
+	}
+
+	if rw := do(http.MethodDelete, "/campaigns/"+created.ID, ""); rw.Code != http.StatusNoContent {
+		t.Fatalf("DELETE: expected 204, got %d", rw.Code)
+	}
+	if rw := do(http.MethodGet, "/campaigns/"+created.ID, ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("GET after DELETE: expected 404, got %d", rw.Code)
+	}
+}
+
+func TestCampaignErrors(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	cases := []struct {
+		method, target, body, token string
+		want                        int
+	}{
+		{http.MethodPost, "/campaigns", `{"name":"X"}`, "", http.StatusUnauthorized},
+		{http.MethodPost, "/campaigns", `{"name":" "}`, "secret", http.StatusBadRequest},
+		{http.MethodPost, "/campaigns", `not json`, "secret", http.StatusBadRequest},
+		{http.MethodPut, "/campaigns/missing", `{"name":"X"}`, "secret", http.StatusNotFound},
+		{http.MethodGet, "/campaigns/missing/tracks", "", "", http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		if rw.Code != c.want {
+			t.Fatalf("%s %s: expected %d, got %d", c.method, c.target, c.want, rw.Code)
+		}
+	}
+}
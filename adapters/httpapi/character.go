@@ -0,0 +1,233 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/character"
+)
+
+// apiCharacter is the JSON shape of a character sheet.
+type apiCharacter struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+
+	Edge   int `json:"edge"`
+	Heart  int `json:"heart"`
+	Iron   int `json:"iron"`
+	Shadow int `json:"shadow"`
+	Wits   int `json:"wits"`
+
+	Health   int `json:"health"`
+	Spirit   int `json:"spirit"`
+	Supply   int `json:"supply"`
+	Momentum int `json:"momentum"`
+
+	Debilities []string  `json:"debilities"`
+	Assets     []apiCard `json:"assets"`
+
+	XPEarned int                  `json:"xp_earned"`
+	XPSpent  int                  `json:"xp_spent"`
+	Legacies map[string]apiLegacy `json:"legacies"`
+
+	// Derived values; ignored on input.
+	MaxMomentum   int `json:"max_momentum"`
+	MomentumReset int `json:"momentum_reset"`
+	XP            int `json:"xp"` // Experience available to spend
+}
+
+func formatCharacter(s character.Sheet) apiCharacter {
+	resp := apiCharacter{
+		Owner:         s.Owner,
+		Name:          s.Name,
+		Edge:          s.Edge,
+		Heart:         s.Heart,
+		Iron:          s.Iron,
+		Shadow:        s.Shadow,
+		Wits:          s.Wits,
+		Health:        s.Health,
+		Spirit:        s.Spirit,
+		Supply:        s.Supply,
+		Momentum:      s.Momentum,
+		Debilities:    []string{},
+		Assets:        []apiCard{},
+		XPEarned:      s.XPEarned,
+		XPSpent:       s.XPSpent,
+		Legacies:      map[string]apiLegacy{},
+		MaxMomentum:   s.MaxMomentum(),
+		MomentumReset: s.MomentumReset(),
+		XP:            s.XP(),
+	}
+	for _, d := range s.Debilities {
+		resp.Debilities = append(resp.Debilities, string(d))
+	}
+	for _, c := range s.Assets {
+		resp.Assets = append(resp.Assets, apiCard{Asset: c.Asset, Abilities: c.Abilities, Tracks: c.Tracks})
+	}
+	for l, t := range s.Legacies {
+		resp.Legacies[string(l)] = apiLegacy{Ticks: t.Ticks, Completed: t.Completed, Score: t.Score()}
+	}
+	return resp
+}
+
+// sheet converts the request body of PUT /characters/{owner} into a sheet.
+func (c apiCharacter) sheet(owner string) character.Sheet {
+	s := character.Sheet{
+		Owner:    owner,
+		Name:     c.Name,
+		Edge:     c.Edge,
+		Heart:    c.Heart,
+		Iron:     c.Iron,
+		Shadow:   c.Shadow,
+		Wits:     c.Wits,
+		Health:   c.Health,
+		Spirit:   c.Spirit,
+		Supply:   c.Supply,
+		Momentum: c.Momentum,
+		XPEarned: c.XPEarned,
+		XPSpent:  c.XPSpent,
+	}
+	for _, d := range c.Debilities {
+		s.Debilities = append(s.Debilities, character.Debility(strings.ToLower(d)))
+	}
+	for _, c := range c.Assets {
+		s.Assets = append(s.Assets, asset.Card{Asset: c.Asset, Abilities: c.Abilities, Tracks: c.Tracks})
+	}
+	for l, t := range c.Legacies {
+		if s.Legacies == nil {
+			s.Legacies = make(map[character.Legacy]character.LegacyTrack)
+		}
+		s.Legacies[character.Legacy(strings.ToLower(l))] = character.LegacyTrack{Ticks: t.Ticks, Completed: t.Completed}
+	}
+	return s
+}
+
+// GetCharacterHandler handles GET /characters/{owner} requests.
+//
+// The owner is the platform-qualified user ID, e.g. "telegram:1234"
+// or "discord:<guild>:<user>".
+//
+// Responses:
+//   - 200 OK with JSON character sheet
+//   - 404 Not Found if the owner has no sheet
+func (a *API) GetCharacterHandler(w http.ResponseWriter, r *http.Request) {
+	s, ok := a.findCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, formatCharacter(s))
+}
+
+// PutCharacterHandler handles PUT /characters/{owner} requests.
+//
+// The body is a JSON character sheet; owner, max_momentum,
+// momentum_reset, xp and legacy scores are ignored. Each asset card names its asset by ID
+// (see GET /assets) and lists the marked abilities in card order and
+// the track values by track name. Requires the API token.
+//
+// Responses:
+//   - 200 OK with the stored JSON character sheet
+//   - 400 Bad Request if the body is malformed or the sheet is invalid
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+func (a *API) PutCharacterHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	var body apiCharacter
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid character sheet", http.StatusBadRequest)
+		return
+	}
+
+	s := body.sheet(r.PathValue("owner"))
+	if err := s.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := a.validateAssets(s); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.saveCharacter(w, s)
+}
+
+// DeleteCharacterHandler handles DELETE /characters/{owner} requests.
+// Requires the API token.
+//
+// Responses:
+//   - 204 No Content
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+func (a *API) DeleteCharacterHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	owner := r.PathValue("owner")
+	if err := a.characters.Delete(owner); err != nil {
+		slog.Error("http character delete failed", "owner", owner, "err", err)
+		http.Error(w, "character sheet could not be deleted", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findCharacter loads the sheet of the {owner} path parameter,
+// writing the error response and reporting false when it cannot.
+func (a *API) findCharacter(w http.ResponseWriter, r *http.Request) (character.Sheet, bool) {
+	owner := r.PathValue("owner")
+	s, err := a.characters.Get(owner)
+	return s, a.characterFound(w, owner, err)
+}
+
+// saveCharacter stores a sheet changed by a request and writes it as
+// the response.
+func (a *API) saveCharacter(w http.ResponseWriter, s character.Sheet) {
+	if err := a.characters.Put(s); err != nil {
+		slog.Error("http character save failed", "owner", s.Owner, "err", err)
+		http.Error(w, "character sheet could not be saved", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, formatCharacter(s))
+}
+
+// characterFound writes the error response for a failed sheet lookup
+// and reports whether the lookup succeeded.
+func (a *API) characterFound(w http.ResponseWriter, owner string, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, character.ErrNotFound):
+		http.Error(w, "character not found", http.StatusNotFound)
+	default:
+		slog.Error("http character lookup failed", "owner", owner, "err", err)
+		http.Error(w, "character sheet could not be loaded", http.StatusInternalServerError)
+	}
+	return false
+}
+
+// authorize checks the "Authorization: Bearer <token>" header of a
+// request that changes state, writing the error response on failure.
+func (a *API) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if a.token == "" {
+		http.Error(w, "editing is disabled; set an API token", http.StatusForbidden)
+		return false
+	}
+
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(a.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid or missing API token", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
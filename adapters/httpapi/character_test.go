@@ -0,0 +1,132 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// newCharacterAPI returns an API with a character store and token.
+func newCharacterAPI() (*API, *character.MemoryStore) {
+	store := character.NewMemoryStore()
+	api := New(Config{
+		Roller:     roll.NewRoller(rand.New(rand.NewSource(3))),
+		Characters: store,
+		Token:      "secret",
+	})
+	return api, store
+}
+
+func TestCharacterCRUD(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	put := func(body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/characters/telegram:1", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	const sheet = `{"name":"Kira","edge":3,"heart":2,"iron":2,"shadow":1,"wits":1,` +
+		`"health":5,"spirit":4,"supply":3,"momentum":2,"debilities":["Wounded"]}`
+
+	if rw := put(sheet, ""); rw.Code != http.StatusUnauthorized {
+		t.Fatalf("PUT without token: expected 401, got %d", rw.Code)
+	}
+	if rw := put(sheet, "wrong"); rw.Code != http.StatusUnauthorized {
+		t.Fatalf("PUT with wrong token: expected 401, got %d", rw.Code)
+	}
+	if rw := put(`{"name":"Kira","edge":9}`, "secret"); rw.Code != http.StatusBadRequest {
+		t.Fatalf("PUT invalid sheet: expected 400, got %d", rw.Code)
+	}
+	if rw := put(sheet, "secret"); rw.Code != http.StatusOK {
+		t.Fatalf("PUT: expected 200, got %d: %s", rw.Code, rw.Body)
+	}
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/characters/telegram:1", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("GET: expected 200, got %d", rw.Code)
+	}
+	var body apiCharacter
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Owner != "telegram:1" || body.Edge != 3 || body.MaxMomentum != 9 || len(body.Debilities) != 1 {
+		t.Fatalf("unexpected sheet: %+v", body)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/characters/telegram:1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, req)
+	if rw.Code != http.StatusNoContent {
+		t.Fatalf("DELETE: expected 204, got %d", rw.Code)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/characters/telegram:1", nil))
+	if rw.Code != http.StatusNotFound {
+		t.Fatalf("GET after DELETE: expected 404, got %d", rw.Code)
+	}
+}
+
+func TestCharacterEditingDisabledWithoutToken(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	req := httptest.NewRequest(http.MethodPut, "/characters/telegram:1", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer ")
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, req)
+	if rw.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rw.Code)
+	}
+}
+
+func TestRollHandler_CharacterStat(t *testing.T) {
+	api, store := newCharacterAPI()
+	routes := api.Routes()
+
+	sheet := character.New("discord:g:u", "Kira")
+	sheet.Wits = 3
+	sheet.Momentum = 4
+	if err := store.Put(sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/roll?character=discord:g:u&stat=wits&m=1", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiResponse
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Modifier != 4 || body.Stat != "wits" || body.Momentum == nil || body.Momentum.Value != 4 {
+		t.Fatalf("unexpected roll: %+v", body)
+	}
+
+	for target, want := range map[string]int{
+		"/roll?character=discord:g:u":               http.StatusBadRequest,
+		"/roll?character=discord:g:u&stat=wits&p=3": http.StatusBadRequest,
+		"/roll?character=nobody&stat=wits":          http.StatusNotFound,
+	} {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != want {
+			t.Fatalf("%s: expected %d, got %d", target, want, rw.Code)
+		}
+	}
+}
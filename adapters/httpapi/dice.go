@@ -0,0 +1,66 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/dice"
+)
+
+// apiDice is the JSON shape of a dice expression result.
+type apiDice struct {
+	Expr  string        `json:"expr"` // Normalized expression, e.g. 1d100 for d%
+	Terms []apiDiceTerm `json:"terms"`
+	Total int           `json:"total"`
+}
+
+type apiDiceTerm struct {
+	Term  string   `json:"term"`           // Signed term, e.g. "4d6kh3" or "-1"
+	Dice  []apiDie `json:"dice,omitempty"` // In the order rolled; absent for a constant
+	Value int      `json:"value"`          // Signed value the term adds to the total
+}
+
+type apiDie struct {
+	Value    int  `json:"value"`
+	Dropped  bool `json:"dropped,omitempty"`
+	Exploded bool `json:"exploded,omitempty"`
+}
+
+func formatDice(r dice.Result) apiDice {
+	resp := apiDice{
+		Expr:  r.Expr.String(),
+		Terms: make([]apiDiceTerm, len(r.Terms)),
+		Total: r.Total,
+	}
+	for i, t := range r.Terms {
+		term := apiDiceTerm{Term: t.Term.String(), Value: t.Value}
+		if t.Term.Negative {
+			term.Term = "-" + term.Term
+		}
+		for _, d := range t.Dice {
+			term.Dice = append(term.Dice, apiDie(d))
+		}
+		resp.Terms[i] = term
+	}
+	return resp
+}
+
+// DiceHandler handles GET /dice requests.
+//
+// It rolls a dice expression outside the Ironsworn mechanic, such as
+// 2d10+1, 4d6kh3, d66 or d%. The roll is not recorded in the roll
+// history.
+//
+// Query parameters:
+//   - expr: the dice expression (remember to escape "+" as %2B)
+//
+// Responses:
+//   - 200 OK with the JSON result, every die included
+//   - 400 Bad Request if the expression is missing or invalid
+func (a *API) DiceHandler(w http.ResponseWriter, r *http.Request) {
+	e, err := dice.Parse(r.URL.Query().Get("expr"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, formatDice(e.Roll(a.roller)))
+}
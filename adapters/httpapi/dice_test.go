@@ -0,0 +1,50 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDiceHandler(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/dice?expr="+url.QueryEscape("4d6kh3-1"), nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiDice
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Expr != "4d6kh3-1" || len(body.Terms) != 2 || body.Terms[1].Term != "-1" || body.Terms[1].Value != -1 {
+		t.Fatalf("unexpected result: %+v", body)
+	}
+
+	dice, dropped, sum := body.Terms[0].Dice, 0, 0
+	for _, d := range dice {
+		if d.Value < 1 || d.Value > 6 {
+			t.Fatalf("die out of range: %+v", d)
+		}
+		if d.Dropped {
+			dropped++
+		} else {
+			sum += d.Value
+		}
+	}
+	if len(dice) != 4 || dropped != 1 || body.Terms[0].Value != sum || body.Total != sum-1 {
+		t.Fatalf("unexpected dice: %+v", body)
+	}
+
+	for _, target := range []string{"/dice", "/dice?expr=2d", "/dice?expr=4d6kh5", "/dice?expr=101d6"} {
+		rw = httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", target, rw.Code)
+		}
+	}
+}
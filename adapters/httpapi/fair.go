@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mtzvd/ironroll/core/fair"
+)
+
+// apiFairness is the JSON shape of GET /fair.
+type apiFairness struct {
+	Commitment string          `json:"commitment"`
+	Revealed   []apiRevelation `json:"revealed"`
+}
+
+type apiRevelation struct {
+	Commitment string    `json:"commitment"`
+	Secret     string    `json:"secret"`
+	RevealedAt time.Time `json:"revealed_at"`
+}
+
+// apiReplay is the JSON shape of GET /verify.
+type apiReplay struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	Commitment    string `json:"commitment"`
+	Secret        string `json:"secret"`
+	ActionDie     int    `json:"action_die,omitempty"`
+	ChallengeDice [2]int `json:"challenge_dice"`
+}
+
+// FairHandler handles GET /fair requests.
+//
+// It publishes the commitment of the secret currently in force and
+// every secret revealed so far.
+//
+// Responses:
+//   - 200 OK with JSON commitment and revealed secrets
+//   - 404 Not Found if verifiable rolls are disabled
+func (a *API) FairHandler(w http.ResponseWriter, r *http.Request) {
+	if a.prover == nil {
+		http.Error(w, "verifiable rolls are disabled", http.StatusNotFound)
+		return
+	}
+
+	resp := apiFairness{
+		Commitment: a.prover.Commitment(),
+		Revealed:   []apiRevelation{},
+	}
+	for _, rev := range a.prover.Revealed() {
+		resp.Revealed = append(resp.Revealed, apiRevelation(rev))
+	}
+
+	writeJSON(w, resp)
+}
+
+// VerifyHandler handles GET /verify requests.
+//
+// Query parameters:
+//   - id: roll ID of a verifiable roll
+//   - commitment: the full commitment returned with the roll
+//
+// Responses:
+//   - 200 OK with the revealed secret and the recomputed dice
+//   - 400 Bad Request if the roll ID or commitment is malformed, or
+//     the roll was not made under the commitment
+//   - 404 Not Found if verifiable rolls are disabled
+//   - 409 Conflict if the roll's secret has not been revealed yet
+func (a *API) VerifyHandler(w http.ResponseWriter, r *http.Request) {
+	if a.prover == nil {
+		http.Error(w, "verifiable rolls are disabled", http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	replay, err := a.prover.Lookup(q.Get("commitment"), q.Get("id"))
+	switch {
+	case errors.Is(err, fair.ErrInvalidID):
+		http.Error(w, "invalid roll id", http.StatusBadRequest)
+		return
+	case errors.Is(err, fair.ErrInvalidCommitment):
+		http.Error(w, "invalid commitment; give the full commitment returned with the roll", http.StatusBadRequest)
+		return
+	case errors.Is(err, fair.ErrCommitmentMismatch):
+		http.Error(w, "roll id was not made under this commitment", http.StatusBadRequest)
+		return
+	case errors.Is(err, fair.ErrNotRevealed):
+		http.Error(w, "secret not revealed yet; try again after the next rotation", http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, apiReplay(replay))
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/fair"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestVerifyFlow(t *testing.T) {
+	prover, err := fair.NewProver()
+	if err != nil {
+		t.Fatalf("NewProver: %v", err)
+	}
+	routes := New(Config{Roller: roll.NewVerifiableRoller(prover), Prover: prover}).Routes()
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		return rw
+	}
+
+	var rolled apiResponse
+	if err := json.NewDecoder(get("/roll?m=1").Body).Decode(&rolled); err != nil {
+		t.Fatalf("failed to decode roll: %v", err)
+	}
+	if rolled.ID == "" || rolled.Commitment != prover.Commitment() {
+		t.Fatalf("expected roll id and current commitment, got %+v", rolled)
+	}
+
+	verify := "/verify?id=" + rolled.ID + "&commitment=" + rolled.Commitment
+	if rw := get(verify); rw.Code != http.StatusConflict {
+		t.Fatalf("expected 409 before rotation, got %d", rw.Code)
+	}
+
+	if _, err := prover.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+
+	rw := get(verify)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 after rotation, got %d", rw.Code)
+	}
+	var replay apiReplay
+	if err := json.NewDecoder(rw.Body).Decode(&replay); err != nil {
+		t.Fatalf("failed to decode replay: %v", err)
+	}
+	if replay.ActionDie != rolled.ActionDie || replay.ChallengeDice != rolled.ChallengeDice {
+		t.Fatalf("replay mismatch: got %+v, rolled %+v", replay, rolled)
+	}
+
+	var pub apiFairness
+	if err := json.NewDecoder(get("/fair").Body).Decode(&pub); err != nil {
+		t.Fatalf("failed to decode fairness: %v", err)
+	}
+	if len(pub.Revealed) != 1 || pub.Revealed[0].Commitment != rolled.Commitment {
+		t.Fatalf("expected the rotated secret to be published, got %+v", pub)
+	}
+
+	for _, target := range []string{
+		"/verify?id=bogus&commitment=" + rolled.Commitment,
+		"/verify?id=" + rolled.ID,
+		"/verify?id=" + rolled.ID + "&commitment=" + rolled.Commitment[:12],
+		"/verify?id=" + rolled.ID + "&commitment=" + pub.Commitment,
+	} {
+		if rw := get(target); rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", target, rw.Code)
+		}
+	}
+}
+
+func TestVerifyDisabled(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	for _, target := range []string{"/fair", "/verify?id=x"} {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404 when disabled, got %d", target, rw.Code)
+		}
+	}
+}
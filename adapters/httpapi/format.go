@@ -1,6 +1,9 @@
 package httpapi
 
-import "github.com/mtzvd/ironroll/core/roll"
+import (
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
 
 // apiResponse defines the public JSON shape returned by the HTTP API.
 //
@@ -12,14 +15,82 @@ type apiResponse struct {
 	ChallengeDice [2]int `json:"challenge_dice"`
 	Total         int    `json:"total"`
 	Outcome       string `json:"outcome"`
+	Term          string `json:"term"` // Outcome name in the ruleset's terms, e.g. "Weak Hit"
+
+	Stat     string       `json:"stat,omitempty"`
+	Momentum *apiMomentum `json:"momentum,omitempty"`
+	Move     *apiMove     `json:"move,omitempty"`
+
+	// Set for verifiable rolls only.
+	ID         string `json:"id,omitempty"`
+	Commitment string `json:"commitment,omitempty"`
+}
+
+// apiMomentum is the JSON shape of roll.MomentumEffect.
+type apiMomentum struct {
+	Value       int    `json:"value"`
+	Cancelled   bool   `json:"action_die_cancelled"`
+	CanBurn     bool   `json:"can_burn"`
+	BurnOutcome string `json:"burn_outcome,omitempty"`
+	BurnTerm    string `json:"burn_term,omitempty"`
 }
 
-func formatResult(r roll.Result) apiResponse {
-	return apiResponse{
+func formatResult(r roll.Result, t ruleset.Terms) apiResponse {
+	resp := apiResponse{
 		ActionDie:     r.ActionDie,
 		Modifier:      r.Modifier,
 		ChallengeDice: r.ChallengeDice,
 		Total:         r.Total,
 		Outcome:       string(r.Outcome),
+		Term:          t.Outcome(r.Outcome),
+		ID:            r.ID,
+	}
+
+	if r.Proof != nil {
+		resp.Commitment = r.Proof.Commitment
 	}
+
+	if m := r.Momentum; m != nil {
+		resp.Momentum = &apiMomentum{
+			Value:       m.Value,
+			Cancelled:   m.Cancelled,
+			CanBurn:     m.CanBurn,
+			BurnOutcome: string(m.BurnOutcome),
+		}
+		if m.CanBurn {
+			resp.Momentum.BurnTerm = t.Outcome(m.BurnOutcome)
+		}
+	}
+
+	return resp
+}
+
+// apiProgressResponse defines the public JSON shape of a progress roll.
+type apiProgressResponse struct {
+	Progress      int    `json:"progress"`
+	ChallengeDice [2]int `json:"challenge_dice"`
+	Outcome       string `json:"outcome"`
+	Term          string `json:"term"`
+
+	Move *apiMove `json:"move,omitempty"`
+
+	// Set for verifiable rolls only.
+	ID         string `json:"id,omitempty"`
+	Commitment string `json:"commitment,omitempty"`
+}
+
+func formatProgressResult(r roll.ProgressResult, t ruleset.Terms) apiProgressResponse {
+	resp := apiProgressResponse{
+		Progress:      r.ProgressScore,
+		ChallengeDice: r.ChallengeDice,
+		Outcome:       string(r.Outcome),
+		Term:          t.Outcome(r.Outcome),
+		ID:            r.ID,
+	}
+
+	if r.Proof != nil {
+		resp.Commitment = r.Proof.Commitment
+	}
+
+	return resp
 }
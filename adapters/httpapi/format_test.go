@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
 )
 
 func TestFormatResult(t *testing.T) {
@@ -15,11 +16,14 @@ func TestFormatResult(t *testing.T) {
 		Outcome:       roll.PartialSuccess,
 	}
 
-	got := formatResult(r)
+	got := formatResult(r, ruleset.ClassicTerms)
 	if got.ActionDie != r.ActionDie || got.Modifier != r.Modifier || got.Total != r.Total {
 		t.Fatalf("formatResult mismatch: got %+v, want %+v", got, r)
 	}
 	if got.Outcome != string(r.Outcome) {
 		t.Fatalf("formatResult outcome mismatch: got %q want %q", got.Outcome, r.Outcome)
 	}
+	if got.Term != "Weak Hit" {
+		t.Fatalf("formatResult term = %q; want Weak Hit", got.Term)
+	}
 }
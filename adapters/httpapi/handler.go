@@ -5,18 +5,203 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/fair"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
 )
 
+// Config holds the dependencies of the HTTP API.
+type Config struct {
+	// Roller performs all dice rolls. Defaults to roll.Default().
+	Roller *roll.Roller
+
+	// Prover enables the verifiable roll endpoints.
+	// It should be the Prover behind Roller. Optional.
+	Prover *fair.Prover
+
+	// Oracles holds the oracle tables of classic Ironsworn.
+	// Defaults to oracle.Builtin().
+	Oracles *oracle.Registry
+
+	// Moves holds the named moves of classic Ironsworn.
+	// Defaults to move.Builtin().
+	Moves *move.Catalog
+
+	// Rulesets holds the games a roll can be played under. Defaults
+	// to ruleset.Builtin with Oracles and Moves as the classic content.
+	Rulesets *ruleset.Registry
+
+	// Characters stores character sheets.
+	// Defaults to an in-memory store.
+	Characters character.Store
+
+	// Tracks stores progress tracks.
+	// Defaults to an in-memory store.
+	Tracks track.Store
+
+	// Campaigns stores campaigns.
+	// Defaults to an in-memory store.
+	Campaigns campaign.Store
+
+	// History records every roll.
+	// Defaults to an in-memory history.Ring.
+	History history.Store
+
+	// Token is the bearer token required by endpoints that change
+	// state, such as PUT /characters/{owner}. When empty those
+	// endpoints are disabled.
+	Token string
+}
+
+// API serves the ironroll HTTP endpoints.
+//
+// An API holds no mutable state of its own and is safe
+// for concurrent use as long as its dependencies are.
+type API struct {
+	roller     *roll.Roller
+	prover     *fair.Prover
+	rulesets   *ruleset.Registry
+	characters character.Store
+	tracks     track.Store
+	campaigns  campaign.Store
+	history    history.Store
+	token      string
+}
+
+// New creates an API from the given dependencies.
+func New(cfg Config) *API {
+	if cfg.Roller == nil {
+		cfg.Roller = roll.Default()
+	}
+	if cfg.Oracles == nil {
+		cfg.Oracles = oracle.Builtin()
+	}
+	if cfg.Moves == nil {
+		cfg.Moves = move.Builtin()
+	}
+	if cfg.Rulesets == nil {
+		cfg.Rulesets = ruleset.Builtin(cfg.Oracles, cfg.Moves)
+	}
+	if cfg.Characters == nil {
+		cfg.Characters = character.NewMemoryStore()
+	}
+	if cfg.Tracks == nil {
+		cfg.Tracks = track.NewMemoryStore()
+	}
+	if cfg.Campaigns == nil {
+		cfg.Campaigns = campaign.NewMemoryStore()
+	}
+	if cfg.History == nil {
+		cfg.History = history.NewRing(history.DefaultCapacity)
+	}
+
+	return &API{
+		roller:     cfg.Roller,
+		prover:     cfg.Prover,
+		rulesets:   cfg.Rulesets,
+		characters: cfg.Characters,
+		tracks:     cfg.Tracks,
+		campaigns:  cfg.Campaigns,
+		history:    cfg.History,
+		token:      cfg.Token,
+	}
+}
+
+// Routes returns a handler serving every API endpoint.
+func (a *API) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /roll", a.RollHandler)
+	mux.HandleFunc("GET /fair", a.FairHandler)
+	mux.HandleFunc("GET /verify", a.VerifyHandler)
+	mux.HandleFunc("GET /oracle/ask", a.AskHandler)
+	mux.HandleFunc("GET /oracle/roll", a.OracleRollHandler)
+	mux.HandleFunc("GET /characters/{owner}", a.GetCharacterHandler)
+	mux.HandleFunc("PUT /characters/{owner}", a.PutCharacterHandler)
+	mux.HandleFunc("DELETE /characters/{owner}", a.DeleteCharacterHandler)
+	mux.HandleFunc("POST /characters/{owner}/xp", a.XPHandler)
+	mux.HandleFunc("POST /characters/{owner}/legacies/{legacy}", a.LegacyHandler)
+	mux.HandleFunc("POST /characters/{owner}/advance", a.AdvanceHandler)
+	mux.HandleFunc("GET /assets", a.ListAssetsHandler)
+	mux.HandleFunc("GET /assets/{name...}", a.GetAssetHandler)
+	mux.HandleFunc("GET /tracks", a.ListTracksHandler)
+	mux.HandleFunc("POST /tracks", a.CreateTrackHandler)
+	mux.HandleFunc("GET /tracks/{id}", a.GetTrackHandler)
+	mux.HandleFunc("POST /tracks/{id}/mark", a.MarkTrackHandler)
+	mux.HandleFunc("POST /tracks/{id}/clear", a.ClearTrackHandler)
+	mux.HandleFunc("POST /tracks/{id}/fulfill", a.FulfillTrackHandler)
+	mux.HandleFunc("DELETE /tracks/{id}", a.DeleteTrackHandler)
+	mux.HandleFunc("GET /campaigns", a.ListCampaignsHandler)
+	mux.HandleFunc("POST /campaigns", a.CreateCampaignHandler)
+	mux.HandleFunc("GET /campaigns/{id}", a.GetCampaignHandler)
+	mux.HandleFunc("PUT /campaigns/{id}", a.PutCampaignHandler)
+	mux.HandleFunc("DELETE /campaigns/{id}", a.DeleteCampaignHandler)
+	mux.HandleFunc("GET /campaigns/{id}/tracks", a.CampaignTracksHandler)
+	mux.HandleFunc("GET /campaigns/{id}/characters", a.CampaignCharactersHandler)
+	mux.HandleFunc("GET /history", a.HistoryHandler)
+	mux.HandleFunc("GET /stats", a.StatsHandler)
+	mux.HandleFunc("GET /odds", a.OddsHandler)
+	mux.HandleFunc("GET /rulesets", a.ListRulesetsHandler)
+	mux.HandleFunc("GET /dice", a.DiceHandler)
+	return mux
+}
+
+// writeJSON encodes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
 // RollHandler handles GET /roll requests.
 //
 // Query parameters:
 //   - m: optional integer modifier (defaults to 0)
+//   - momentum: optional current momentum (-6 to 10)
+//   - p: optional progress score (0-10); performs a progress roll instead
+//   - move: optional move key, e.g. face_danger; adds the move text
+//     for the outcome. Progress moves require p.
+//   - stat: optional stat to roll with
+//   - character: optional owner of a character sheet, e.g. telegram:1234.
+//     Requires stat; the stat value is added to m, and the sheet's
+//     momentum is used unless momentum is given. With a move, the
+//     bonuses of the character's assets for the move and stat are
+//     added too. Without a character the stat is only a label and m
+//     supplies its value.
+//   - ruleset: optional ruleset (classic, delve or starforged) whose
+//     moves and outcome names are used. Defaults to the ruleset of the
+//     character's campaign, or classic.
+//
+// Every roll is recorded in the roll history (see GET /history).
 //
 // Responses:
 //   - 200 OK with JSON roll result
-//   - 400 Bad Request if modifier is invalid
-func RollHandler(w http.ResponseWriter, r *http.Request) {
+//   - 400 Bad Request if modifier, momentum, progress score, move,
+//     stat or ruleset is invalid, if a progress score is combined with a modifier,
+//     momentum or character, if the move does not match the kind of
+//     roll, or if a character is given without a stat
+//   - 404 Not Found if the character has no sheet
+func (a *API) RollHandler(w http.ResponseWriter, r *http.Request) {
+	rs, ok := a.ruleset(w, r, r.URL.Query().Get("character"))
+	if !ok {
+		return
+	}
+
+	mv, stat, err := moveParams(rs, r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if raw := r.URL.Query().Get("p"); raw != "" || (mv != nil && mv.Progress) {
+		a.progressRoll(w, r, raw, mv, rs)
+		return
+	}
+
 	modifier := 0
 
 	if raw := r.URL.Query().Get("m"); raw != "" {
@@ -28,10 +213,88 @@ func RollHandler(w http.ResponseWriter, r *http.Request) {
 		modifier = m
 	}
 
-	result := roll.Roll(modifier)
+	var momentum *int
+	if raw := r.URL.Query().Get("momentum"); raw != "" {
+		m, err := strconv.Atoi(raw)
+		if err != nil || m < roll.MinMomentum || m > roll.MaxMomentum {
+			http.Error(w, "invalid momentum", http.StatusBadRequest)
+			return
+		}
+		momentum = &m
+	}
 
-	response := formatResult(result)
+	owner := r.URL.Query().Get("character")
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
+	var result roll.Result
+	var bonus int
+	var assets []string
+	switch {
+	case owner != "":
+		if stat == "" {
+			http.Error(w, "character requires a stat", http.StatusBadRequest)
+			return
+		}
+		sheet, err := a.characters.Get(owner)
+		if !a.characterFound(w, owner, err) {
+			return
+		}
+		if momentum != nil {
+			sheet.Momentum = *momentum
+		}
+		if mv != nil {
+			bonus, assets = sheet.AssetBonus(a.rulesets.Asset, mv.Key, stat)
+		}
+		// The stat was validated by moveParams, so Roll cannot fail.
+		result, _ = sheet.Roll(a.roller, stat, modifier+bonus)
+	case momentum != nil:
+		result = a.roller.RollWithMomentum(modifier, *momentum)
+	default:
+		result = a.roller.Roll(modifier)
+	}
+
+	rec := entry(owner)
+	rec.Stat = string(stat)
+	rec.SetAction(result)
+
+	resp := formatResult(result, rs.Terms)
+	resp.Stat = string(stat)
+	if mv != nil {
+		rec.Move = mv.ID
+		resp.Move = formatMove(*mv, stat, result.Outcome)
+		resp.Move.AssetBonus = bonus
+		resp.Move.Assets = assets
+	}
+	a.record(rec)
+	writeJSON(w, resp)
+}
+
+// progressRoll serves the progress roll variant of GET /roll.
+// The move, if any, must be a progress move of the ruleset rs.
+func (a *API) progressRoll(w http.ResponseWriter, r *http.Request, raw string, mv *move.Move, rs *ruleset.Ruleset) {
+	if mv != nil && !mv.Progress {
+		http.Error(w, "move is not a progress move", http.StatusBadRequest)
+		return
+	}
+	if q := r.URL.Query(); q.Get("m") != "" || q.Get("momentum") != "" || q.Get("character") != "" {
+		http.Error(w, "modifier, momentum and character do not apply to progress rolls", http.StatusBadRequest)
+		return
+	}
+
+	score, err := strconv.Atoi(raw)
+	if err != nil || score < roll.MinProgressScore || score > roll.MaxProgressScore {
+		http.Error(w, "invalid progress score", http.StatusBadRequest)
+		return
+	}
+
+	result := a.roller.ProgressRoll(score)
+	rec := entry("")
+	rec.SetProgress(result)
+
+	resp := formatProgressResult(result, rs.Terms)
+	if mv != nil {
+		rec.Move = mv.ID
+		resp.Move = formatMove(*mv, "", result.Outcome)
+	}
+	a.record(rec)
+	writeJSON(w, resp)
 }
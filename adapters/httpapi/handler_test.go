@@ -5,47 +5,51 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/mtzvd/ironroll/core/roll"
 )
 
+// newTestAPI returns an API backed by a deterministic Roller.
+func newTestAPI(seed int64) *API {
+	return New(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(seed)))})
+}
+
 func TestRollHandler_WithModifier(t *testing.T) {
 	// Make roll deterministic
-	roll.SetRand(rand.New(rand.NewSource(42)))
-	defer roll.ResetRand()
+	api := newTestAPI(42)
 
 	req := httptest.NewRequest(http.MethodGet, "/roll?m=2", nil)
 	rw := httptest.NewRecorder()
 
-	RollHandler(rw, req)
+	api.RollHandler(rw, req)
 
 	res := rw.Result()
 	if res.StatusCode != http.StatusOK {
 		t.Fatalf("expected 200 OK, got %d", res.StatusCode)
 	}
 
-	var api apiResponse
-	if err := json.NewDecoder(res.Body).Decode(&api); err != nil {
+	var body apiResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if api.Modifier != 2 {
-		t.Fatalf("modifier mismatch: got %d want %d", api.Modifier, 2)
+	if body.Modifier != 2 {
+		t.Fatalf("modifier mismatch: got %d want %d", body.Modifier, 2)
 	}
-	if api.ActionDie < 1 || api.ActionDie > 6 {
-		t.Fatalf("action die out of range: %d", api.ActionDie)
+	if body.ActionDie < 1 || body.ActionDie > 6 {
+		t.Fatalf("action die out of range: %d", body.ActionDie)
 	}
 }
 
 func TestRollHandler_DefaultAndInvalidModifier(t *testing.T) {
 	// Default modifier (no m param)
-	roll.SetRand(rand.New(rand.NewSource(7)))
-	defer roll.ResetRand()
+	api := newTestAPI(7)
 
 	req := httptest.NewRequest(http.MethodGet, "/roll", nil)
 	rw := httptest.NewRecorder()
-	RollHandler(rw, req)
+	api.RollHandler(rw, req)
 	if rw.Result().StatusCode != http.StatusOK {
 		t.Fatalf("expected 200 OK for default modifier, got %d", rw.Result().StatusCode)
 	}
@@ -53,8 +57,88 @@ func TestRollHandler_DefaultAndInvalidModifier(t *testing.T) {
 	// Invalid modifier should return 400
 	req2 := httptest.NewRequest(http.MethodGet, "/roll?m=bad", nil)
 	rw2 := httptest.NewRecorder()
-	RollHandler(rw2, req2)
+	api.RollHandler(rw2, req2)
 	if rw2.Result().StatusCode != http.StatusBadRequest {
 		t.Fatalf("expected 400 Bad Request for invalid modifier, got %d", rw2.Result().StatusCode)
 	}
 }
+
+func TestRollHandler_Progress(t *testing.T) {
+	api := newTestAPI(3)
+
+	req := httptest.NewRequest(http.MethodGet, "/roll?p=7", nil)
+	rw := httptest.NewRecorder()
+	api.RollHandler(rw, req)
+
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiProgressResponse
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Progress != 7 {
+		t.Fatalf("progress mismatch: got %d want %d", body.Progress, 7)
+	}
+
+	// Out of range, non-numeric and combined with a modifier are all rejected.
+	for _, target := range []string{"/roll?p=11", "/roll?p=bad", "/roll?p=5&m=1"} {
+		rw := httptest.NewRecorder()
+		api.RollHandler(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400 Bad Request, got %d", target, rw.Code)
+		}
+	}
+}
+
+func TestRollHandler_Momentum(t *testing.T) {
+	api := newTestAPI(11)
+
+	req := httptest.NewRequest(http.MethodGet, "/roll?m=1&momentum=10", nil)
+	rw := httptest.NewRecorder()
+	api.RollHandler(rw, req)
+
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiResponse
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Momentum == nil || body.Momentum.Value != 10 {
+		t.Fatalf("expected momentum 10 in response, got %+v", body.Momentum)
+	}
+
+	for _, target := range []string{"/roll?momentum=11", "/roll?momentum=x", "/roll?p=3&momentum=2"} {
+		rw := httptest.NewRecorder()
+		api.RollHandler(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400 Bad Request, got %d", target, rw.Code)
+		}
+	}
+}
+
+func TestRollHandler_ConcurrentRequests(t *testing.T) {
+	// A single API (and Roller) serves concurrent requests, as it does
+	// in production; run with -race to verify there is no data race.
+	api := newTestAPI(5)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				rw := httptest.NewRecorder()
+				api.RollHandler(rw, httptest.NewRequest(http.MethodGet, "/roll?m=1", nil))
+				if rw.Code != http.StatusOK {
+					t.Errorf("expected 200 OK, got %d", rw.Code)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
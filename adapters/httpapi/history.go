@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/history"
+)
+
+// History query bounds.
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 500
+)
+
+// HistoryHandler handles GET /history requests.
+//
+// Query parameters (all optional):
+//   - platform: telegram, discord or http
+//   - user: platform user key, e.g. discord:1234
+//   - channel: channel or chat ID on the platform
+//   - campaign: campaign ID
+//   - move: move key or ID, e.g. face_danger
+//   - since, until: RFC 3339 times bounding the roll time
+//   - limit: number of rolls to return (defaults to 50, at most 500)
+//
+// Responses:
+//   - 200 OK with a JSON array of recorded rolls, newest first
+//   - 400 Bad Request if a time, the limit or the move is invalid
+func (a *API) HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	f, ok := a.historyFilter(w, r)
+	if !ok {
+		return
+	}
+	if f.Limit == 0 {
+		f.Limit = defaultHistoryLimit
+	}
+
+	entries, ok := a.queryHistory(w, f)
+	if !ok {
+		return
+	}
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	writeJSON(w, entries)
+}
+
+// historyFilter reads the filter parameters of GET /history, writing
+// the error response and reporting false when one is invalid. The
+// limit is zero when not given.
+func (a *API) historyFilter(w http.ResponseWriter, r *http.Request) (history.Filter, bool) {
+	q := r.URL.Query()
+	f := history.Filter{
+		Platform: q.Get("platform"),
+		User:     q.Get("user"),
+		Channel:  q.Get("channel"),
+		Campaign: q.Get("campaign"),
+	}
+
+	if raw := q.Get("move"); raw != "" {
+		m, ok := a.rulesets.Move(raw)
+		if !ok {
+			http.Error(w, "unknown move", http.StatusBadRequest)
+			return history.Filter{}, false
+		}
+		f.Move = m.ID
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"since", &f.Since}, {"until", &f.Until}} {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "invalid "+p.name, http.StatusBadRequest)
+			return history.Filter{}, false
+		}
+		*p.dst = t
+	}
+
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxHistoryLimit {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return history.Filter{}, false
+		}
+		f.Limit = n
+	}
+	return f, true
+}
+
+// queryHistory queries the roll history, writing the error response
+// and reporting false when it cannot be loaded.
+func (a *API) queryHistory(w http.ResponseWriter, f history.Filter) ([]history.Entry, bool) {
+	entries, err := a.history.Query(f)
+	if err != nil {
+		slog.Error("http history query failed", "err", err)
+		http.Error(w, "history could not be loaded", http.StatusInternalServerError)
+		return nil, false
+	}
+	return entries, true
+}
+
+// entry starts the history entry of a roll made for a character or
+// track owner, which may be empty. Rolls for campaign characters and
+// shared tracks carry the campaign.
+func entry(owner string) history.Entry {
+	id, user := campaign.SplitOwner(owner)
+	return history.Entry{
+		Platform: history.HTTP,
+		User:     user,
+		Campaign: id,
+	}
+}
+
+// record adds an entry to the roll history.
+// Failures are logged; they never affect the response.
+func (a *API) record(e history.Entry) {
+	e.Time = time.Now().UTC()
+	if err := a.history.Add(e); err != nil {
+		slog.Error("http history add failed", "err", err)
+	}
+}
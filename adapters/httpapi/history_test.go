@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/history"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	api, characters := newCharacterAPI()
+	routes := api.Routes()
+
+	if err := characters.Put(character.New("campaign:c1:discord:7", "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, target := range []string{
+		"/roll?m=1",
+		"/roll?move=face_danger&stat=edge&m=2",
+		"/roll?p=7",
+		"/roll?character=campaign:c1:discord:7&stat=wits",
+		"/roll?m=bad", // not a roll
+	} {
+		routes.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	get := func(target string) []history.Entry {
+		t.Helper()
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d", target, rw.Code)
+		}
+		var entries []history.Entry
+		if err := json.NewDecoder(rw.Body).Decode(&entries); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return entries
+	}
+
+	all := get("/history")
+	if len(all) != 4 || all[0].Campaign != "c1" || all[0].User != "discord:7" || all[0].Stat != "wits" {
+		t.Fatalf("unexpected history: %+v", all)
+	}
+	if all[1].Kind != "progress" || all[1].Progress != 7 {
+		t.Fatalf("unexpected progress entry: %+v", all[1])
+	}
+
+	moves := get("/history?move=face_danger")
+	if len(moves) != 1 || moves[0].Move != "classic/moves/adventure/face_danger" || moves[0].Modifier != 2 {
+		t.Fatalf("unexpected move history: %+v", moves)
+	}
+
+	if got := get("/history?limit=2&platform=http"); len(got) != 2 {
+		t.Fatalf("limit=2 returned %d entries", len(got))
+	}
+	if got := get("/history?user=discord:8"); len(got) != 0 {
+		t.Fatalf("unexpected entries for another user: %+v", got)
+	}
+
+	for _, target := range []string{"/history?limit=0", "/history?since=yesterday", "/history?move=nope"} {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("GET %s: expected 400, got %d", target, rw.Code)
+		}
+	}
+}
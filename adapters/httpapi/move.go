@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// apiMove is the JSON shape of the move a roll was made for.
+type apiMove struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Stat string `json:"stat,omitempty"`
+	Text string `json:"text"`
+
+	// Set when a character's assets added to the roll.
+	AssetBonus int      `json:"asset_bonus,omitempty"`
+	Assets     []string `json:"assets,omitempty"` // Names of the assets
+}
+
+func formatMove(m move.Move, stat move.Stat, o roll.Outcome) *apiMove {
+	return &apiMove{
+		ID:   m.ID,
+		Name: m.Name,
+		Stat: string(stat),
+		Text: m.OutcomeText(o),
+	}
+}
+
+// moveParams reads the optional move and stat query parameters,
+// looking the move up in the ruleset rs.
+// It returns a nil move when none was requested, and an error
+// suitable for a 400 response when the parameters are invalid.
+// A stat without a move is only accepted together with a character.
+func moveParams(rs *ruleset.Ruleset, q url.Values) (*move.Move, move.Stat, error) {
+	name, rawStat := q.Get("move"), q.Get("stat")
+
+	var stat move.Stat
+	if rawStat != "" {
+		var ok bool
+		if stat, ok = move.ParseStat(rawStat); !ok {
+			return nil, "", errors.New("invalid stat")
+		}
+	}
+
+	if name == "" {
+		if stat != "" && q.Get("character") == "" {
+			return nil, "", errors.New("stat requires a move or a character")
+		}
+		return nil, stat, nil
+	}
+
+	m, ok := rs.Move(name)
+	if !ok {
+		return nil, "", errors.New("unknown move")
+	}
+
+	if stat != "" {
+		if err := m.CheckStat(stat); err != nil {
+			return nil, "", err
+		}
+	}
+	return &m, stat, nil
+}
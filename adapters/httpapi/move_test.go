@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRollHandler_Move(t *testing.T) {
+	routes := newTestAPI(5).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/roll?move=face_danger&stat=edge&m=2", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiResponse
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Move == nil || body.Move.Name != "Face Danger" || body.Move.Stat != "edge" || body.Move.Text == "" {
+		t.Fatalf("unexpected move: %+v", body.Move)
+	}
+	if body.Modifier != 2 {
+		t.Fatalf("modifier mismatch: got %d want 2", body.Modifier)
+	}
+}
+
+func TestRollHandler_ProgressMove(t *testing.T) {
+	routes := newTestAPI(5).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/roll?move=fulfill_your_vow&p=8", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiProgressResponse
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Progress != 8 || body.Move == nil || body.Move.Name != "Fulfill Your Vow" {
+		t.Fatalf("unexpected progress move result: %+v", body)
+	}
+}
+
+func TestRollHandler_InvalidMove(t *testing.T) {
+	routes := newTestAPI(5).Routes()
+
+	for _, target := range []string{
+		"/roll?move=dance",
+		"/roll?stat=edge",
+		"/roll?move=face_danger&stat=luck",
+		"/roll?move=strike&stat=heart",
+		"/roll?move=face_danger&p=5",
+		"/roll?move=fulfill_your_vow",
+		"/roll?move=fulfill_your_vow&p=5&stat=heart",
+	} {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", target, rw.Code)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/stats"
+)
+
+// apiOdds is the JSON shape of GET /odds.
+type apiOdds struct {
+	Kind         string             `json:"kind"`
+	Modifier     *int               `json:"modifier,omitempty"`
+	Progress     *int               `json:"progress,omitempty"`
+	Combinations int                `json:"combinations"`
+	Outcomes     map[string]float64 `json:"outcomes"`
+	StrongHit    float64            `json:"strong_hit"`
+	WeakHit      float64            `json:"weak_hit"`
+	Miss         float64            `json:"miss"`
+}
+
+func formatOdds(kind string, o roll.Odds) apiOdds {
+	resp := apiOdds{
+		Kind:         kind,
+		Combinations: o.Combinations,
+		Outcomes:     make(map[string]float64),
+		StrongHit:    o.StrongHit(),
+		WeakHit:      o.WeakHit(),
+		Miss:         o.Miss(),
+	}
+	for _, outcome := range stats.Outcomes {
+		resp.Outcomes[string(outcome)] = o.Probability(outcome)
+	}
+	return resp
+}
+
+// OddsHandler handles GET /odds requests.
+//
+// It returns the exact outcome probabilities of a roll, computed by
+// enumerating every combination of dice. No dice are rolled.
+//
+// Query parameters:
+//   - m: optional integer modifier (defaults to 0)
+//   - p: optional progress score (0-10); gives the odds of a progress
+//     roll instead
+//
+// Responses:
+//   - 200 OK with the JSON odds
+//   - 400 Bad Request if the modifier or progress score is invalid,
+//     or if both are given
+func (a *API) OddsHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if raw := q.Get("p"); raw != "" {
+		if q.Get("m") != "" {
+			http.Error(w, "modifier does not apply to progress rolls", http.StatusBadRequest)
+			return
+		}
+		score, err := strconv.Atoi(raw)
+		if err != nil || score < roll.MinProgressScore || score > roll.MaxProgressScore {
+			http.Error(w, "invalid progress score", http.StatusBadRequest)
+			return
+		}
+
+		resp := formatOdds(roll.KindProgress, roll.ProgressOdds(score))
+		resp.Progress = &score
+		writeJSON(w, resp)
+		return
+	}
+
+	modifier := 0
+	if raw := q.Get("m"); raw != "" {
+		m, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "invalid modifier", http.StatusBadRequest)
+			return
+		}
+		modifier = m
+	}
+
+	resp := formatOdds(roll.KindAction, roll.ActionOdds(modifier))
+	resp.Modifier = &modifier
+	writeJSON(w, resp)
+}
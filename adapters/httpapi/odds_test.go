@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOddsHandler(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/odds?m=3", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiOdds
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Kind != "action" || body.Modifier == nil || *body.Modifier != 3 || body.Combinations != 600 {
+		t.Fatalf("unexpected odds: %+v", body)
+	}
+	if body.Outcomes["Critical Success"] != 33.0/600 || math.Abs(body.StrongHit-199.0/600) > 1e-12 {
+		t.Fatalf("unexpected probabilities: %+v", body)
+	}
+	if math.Abs(body.StrongHit+body.WeakHit+body.Miss-1) > 1e-12 {
+		t.Fatalf("probabilities do not sum to 1: %+v", body)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/odds?p=10", nil))
+	body = apiOdds{}
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Kind != "progress" || body.Progress == nil || *body.Progress != 10 || body.Combinations != 100 || body.Miss != 0.01 {
+		t.Fatalf("unexpected progress odds: %+v", body)
+	}
+
+	for _, target := range []string{"/odds?m=x", "/odds?p=11", "/odds?p=5&m=1"} {
+		rw = httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", target, rw.Code)
+		}
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/history", nil))
+	if got := rw.Body.String(); got != "[]\n" {
+		t.Fatalf("odds should not be recorded as rolls, got %s", got)
+	}
+}
@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/oracle"
+)
+
+// apiAnswer is the JSON shape of an "Ask the Oracle" answer.
+type apiAnswer struct {
+	Odds   string `json:"odds"`
+	Roll   int    `json:"roll"`
+	Answer string `json:"answer"`
+	Yes    bool   `json:"yes"`
+	Match  bool   `json:"match"`
+}
+
+// apiOracleResult is the JSON shape of an oracle table roll.
+type apiOracleResult struct {
+	Table  string `json:"table"`
+	Name   string `json:"name"`
+	Roll   int    `json:"roll"`
+	Result string `json:"result"`
+}
+
+// AskHandler handles GET /oracle/ask requests.
+//
+// Query parameters:
+//   - odds: optional odds (almost_certain, likely, 50/50, unlikely,
+//     small_chance); defaults to 50/50
+//
+// Responses:
+//   - 200 OK with JSON answer
+//   - 400 Bad Request if the odds are not recognized
+func (a *API) AskHandler(w http.ResponseWriter, r *http.Request) {
+	odds := oracle.FiftyFifty
+	if raw := r.URL.Query().Get("odds"); raw != "" {
+		o, ok := oracle.ParseOdds(raw)
+		if !ok {
+			http.Error(w, "invalid odds", http.StatusBadRequest)
+			return
+		}
+		odds = o
+	}
+
+	// Odds are always valid here, so Ask cannot fail.
+	answer, _ := oracle.Ask(a.roller, odds)
+
+	writeJSON(w, apiAnswer{
+		Odds:   string(answer.Odds),
+		Roll:   answer.Roll,
+		Answer: answer.String(),
+		Yes:    answer.Yes,
+		Match:  answer.Match,
+	})
+}
+
+// OracleRollHandler handles GET /oracle/roll requests.
+//
+// Query parameters:
+//   - table: oracle table ID or key (e.g. "action")
+//   - ruleset: optional ruleset whose tables are searched (defaults
+//     to classic)
+//
+// Responses:
+//   - 200 OK with JSON oracle result
+//   - 400 Bad Request if the ruleset is not recognized
+//   - 404 Not Found if the table does not exist
+func (a *API) OracleRollHandler(w http.ResponseWriter, r *http.Request) {
+	rs, ok := a.ruleset(w, r, "")
+	if !ok {
+		return
+	}
+
+	table, ok := rs.Oracle(r.URL.Query().Get("table"))
+	if !ok {
+		http.Error(w, "unknown oracle table", http.StatusNotFound)
+		return
+	}
+
+	res, err := oracle.Roll(a.roller, table)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, apiOracleResult{
+		Table:  res.Table.ID,
+		Name:   res.Table.Name,
+		Roll:   res.Roll,
+		Result: res.Result,
+	})
+}
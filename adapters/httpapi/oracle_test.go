@@ -0,0 +1,56 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAskHandler(t *testing.T) {
+	routes := newTestAPI(4).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/oracle/ask?odds=likely", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiAnswer
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Odds != "Likely" || body.Roll < 1 || body.Roll > 100 || body.Yes != (body.Roll >= 26) {
+		t.Fatalf("unexpected answer: %+v", body)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/oracle/ask?odds=maybe", nil))
+	if rw.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid odds, got %d", rw.Code)
+	}
+}
+
+func TestOracleRollHandler(t *testing.T) {
+	routes := newTestAPI(4).Routes()
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/oracle/roll?table=region", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiOracleResult
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Table != "classic/oracles/place/region" || body.Result == "" {
+		t.Fatalf("unexpected oracle result: %+v", body)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/oracle/roll?table=nope", nil))
+	if rw.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown table, got %d", rw.Code)
+	}
+}
@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/stats"
+)
+
+// apiRuleset is the JSON shape of a ruleset.
+type apiRuleset struct {
+	ID    string            `json:"id"`
+	Name  string            `json:"name"`
+	Terms map[string]string `json:"terms"` // Outcome names keyed like the outcome of a roll
+}
+
+func formatRuleset(rs *ruleset.Ruleset) apiRuleset {
+	terms := make(map[string]string)
+	for _, o := range stats.Outcomes {
+		terms[string(o)] = rs.Terms.Outcome(o)
+	}
+	return apiRuleset{ID: string(rs.ID), Name: rs.Name, Terms: terms}
+}
+
+// ListRulesetsHandler handles GET /rulesets requests.
+//
+// Responses:
+//   - 200 OK with a JSON array of rulesets, the default first
+func (a *API) ListRulesetsHandler(w http.ResponseWriter, r *http.Request) {
+	rulesets := a.rulesets.Rulesets()
+	resp := make([]apiRuleset, len(rulesets))
+	for i, rs := range rulesets {
+		resp[i] = formatRuleset(rs)
+	}
+	writeJSON(w, resp)
+}
+
+// ruleset resolves the ruleset of a request: the one named by the
+// ruleset query parameter, else the ruleset of the campaign owner
+// belongs to, else the default. It writes a 400 response and reports
+// false when the parameter names no ruleset.
+func (a *API) ruleset(w http.ResponseWriter, r *http.Request, owner string) (*ruleset.Ruleset, bool) {
+	if raw := r.URL.Query().Get("ruleset"); raw != "" {
+		rs, ok := a.rulesets.Lookup(raw)
+		if !ok {
+			http.Error(w, "unknown ruleset", http.StatusBadRequest)
+		}
+		return rs, ok
+	}
+	return a.ownerRuleset(owner), true
+}
+
+// ownerRuleset returns the ruleset of the campaign an owner key
+// belongs to, or the default ruleset outside a campaign.
+func (a *API) ownerRuleset(owner string) *ruleset.Ruleset {
+	id, _ := campaign.SplitOwner(owner)
+	if id == "" {
+		return a.rulesets.Default()
+	}
+
+	c, err := a.campaigns.Get(id)
+	if err != nil {
+		if !errors.Is(err, campaign.ErrNotFound) {
+			slog.Error("http campaign lookup failed", "id", id, "err", err)
+		}
+		return a.rulesets.Default()
+	}
+	return a.rulesets.Select(c.Ruleset)
+}
@@ -0,0 +1,150 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestListRulesetsHandler(t *testing.T) {
+	rw := httptest.NewRecorder()
+	newTestAPI(1).Routes().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/rulesets", nil))
+
+	var body []apiRuleset
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body) != 3 || body[0].ID != "classic" || body[2].ID != "starforged" {
+		t.Fatalf("unexpected rulesets: %+v", body)
+	}
+	if got := body[2].Terms["Critical Failure"]; got != "Miss with a Match" {
+		t.Fatalf("starforged Critical Failure term = %q", got)
+	}
+}
+
+func TestRollWithRuleset(t *testing.T) {
+	api, characters := newCharacterAPI()
+	routes := api.Routes()
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		return rw
+	}
+
+	rw := get("/roll?p=7&move=take_decisive_action&ruleset=starforged")
+	var progress apiProgressResponse
+	if err := json.NewDecoder(rw.Body).Decode(&progress); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if progress.Move == nil || progress.Move.ID != "starforged/moves/combat/take_decisive_action" || progress.Term == "" {
+		t.Fatalf("unexpected progress roll: %+v", progress)
+	}
+
+	for _, target := range []string{
+		"/roll?m=1&ruleset=sundered",
+		"/roll?p=7&move=take_decisive_action",
+	} {
+		if rw := get(target); rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", target, rw.Code)
+		}
+	}
+
+	// A character in a Starforged campaign rolls Starforged moves.
+	c, _ := campaign.New("Forge")
+	c.Ruleset = "starforged"
+	if err := api.campaigns.Put(c); err != nil {
+		t.Fatal(err)
+	}
+	owner := c.CharacterOwner("telegram:1")
+	if err := characters.Put(character.New(owner, "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	rw = get("/roll?character=" + owner + "&stat=edge&move=gain_ground")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rw.Code, rw.Body)
+	}
+	var action apiResponse
+	if err := json.NewDecoder(rw.Body).Decode(&action); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if action.Move == nil || !strings.HasPrefix(action.Move.ID, "starforged/") {
+		t.Fatalf("unexpected action roll: %+v", action)
+	}
+
+	// So does a shared combat track of that campaign.
+	fight, _ := track.New(c.Owner(), track.Combat, "Raiders", track.Dangerous)
+	if err := api.tracks.Put(fight); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/tracks/"+fight.ID+"/fulfill", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, req)
+	var fulfilled apiFulfillment
+	if err := json.NewDecoder(rw.Body).Decode(&fulfilled); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if fulfilled.Roll.Move == nil || fulfilled.Roll.Move.Name != "Take Decisive Action" {
+		t.Fatalf("unexpected fulfillment: %+v", fulfilled.Roll)
+	}
+}
+
+func TestCampaignRuleset(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/campaigns", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	rw := post(`{"name":"Forge","ruleset":"SF"}`)
+	var created apiCampaign
+	if err := json.NewDecoder(rw.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Ruleset != "starforged" {
+		t.Fatalf("ruleset = %q; want starforged", created.Ruleset)
+	}
+
+	rw = post(`{"name":"Ironlands"}`)
+	created = apiCampaign{}
+	if err := json.NewDecoder(rw.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Ruleset != "classic" {
+		t.Fatalf("default ruleset = %q; want classic", created.Ruleset)
+	}
+
+	if rw := post(`{"name":"X","ruleset":"sundered"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown ruleset, got %d", rw.Code)
+	}
+}
+
+func TestOracleRollRuleset(t *testing.T) {
+	routes := newTestAPI(1).Routes()
+
+	for target, want := range map[string]int{
+		"/oracle/roll?table=action":                    http.StatusOK,
+		"/oracle/roll?table=action&ruleset=delve":      http.StatusOK,
+		"/oracle/roll?table=action&ruleset=starforged": http.StatusNotFound,
+		"/oracle/roll?table=action&ruleset=sundered":   http.StatusBadRequest,
+	} {
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if rw.Code != want {
+			t.Fatalf("%s: expected %d, got %d", target, want, rw.Code)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/mtzvd/ironroll/core/stats"
+)
+
+// apiStats is the JSON shape of GET /stats.
+type apiStats struct {
+	Rolls     int            `json:"rolls"`
+	Action    int            `json:"action"`
+	Progress  int            `json:"progress"`
+	Outcomes  map[string]int `json:"outcomes"`
+	Matches   int            `json:"matches"`
+	MatchRate float64        `json:"match_rate"`
+
+	// Die histograms, indexed by face - 1.
+	ActionDie    []int `json:"action_die"`
+	ChallengeDie []int `json:"challenge_die"`
+
+	ActionFit    apiFit `json:"action_fit"`
+	ChallengeFit apiFit `json:"challenge_fit"`
+}
+
+// apiFit is the JSON shape of a chi-square goodness-of-fit test.
+type apiFit struct {
+	ChiSquare float64 `json:"chi_square"`
+	DF        int     `json:"df"`
+	PValue    float64 `json:"p_value"`
+	Reliable  bool    `json:"reliable"`
+}
+
+func formatStats(r stats.Report) apiStats {
+	resp := apiStats{
+		Rolls:        r.Rolls,
+		Action:       r.Action,
+		Progress:     r.Progress,
+		Outcomes:     make(map[string]int),
+		Matches:      r.Matches,
+		MatchRate:    r.MatchRate(),
+		ActionDie:    r.ActionDie[:],
+		ChallengeDie: r.ChallengeDie[:],
+		ActionFit:    formatFit(r.ActionFit),
+		ChallengeFit: formatFit(r.ChallengeFit),
+	}
+	for _, o := range stats.Outcomes {
+		resp.Outcomes[string(o)] = r.Outcomes[o]
+	}
+	return resp
+}
+
+func formatFit(f stats.Fit) apiFit {
+	return apiFit{
+		ChiSquare: f.Statistic,
+		DF:        f.DF,
+		PValue:    f.PValue,
+		Reliable:  f.Reliable,
+	}
+}
+
+// StatsHandler handles GET /stats requests.
+//
+// It reports the outcome distribution, challenge die matches and die
+// histograms of the recorded rolls, with a chi-square test of each die
+// against a fair one. The query parameters are those of GET /history;
+// without a limit every matching roll is included.
+//
+// Responses:
+//   - 200 OK with the JSON report
+//   - 400 Bad Request if a filter parameter is invalid
+func (a *API) StatsHandler(w http.ResponseWriter, r *http.Request) {
+	f, ok := a.historyFilter(w, r)
+	if !ok {
+		return
+	}
+
+	entries, ok := a.queryHistory(w, f)
+	if !ok {
+		return
+	}
+	writeJSON(w, formatStats(stats.Compute(entries)))
+}
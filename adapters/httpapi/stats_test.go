@@ -0,0 +1,58 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsHandler(t *testing.T) {
+	routes := newTestAPI(5).Routes()
+
+	for i := 0; i < 20; i++ {
+		routes.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/roll?m=1", nil))
+	}
+	routes.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/roll?p=5", nil))
+
+	rw := httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/stats?platform=http", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rw.Code)
+	}
+
+	var body apiStats
+	if err := json.NewDecoder(rw.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Rolls != 21 || body.Action != 20 || body.Progress != 1 {
+		t.Fatalf("unexpected counts: %+v", body)
+	}
+
+	sum := func(xs []int) int {
+		n := 0
+		for _, x := range xs {
+			n += x
+		}
+		return n
+	}
+	if len(body.ActionDie) != 6 || sum(body.ActionDie) != 20 || len(body.ChallengeDie) != 10 || sum(body.ChallengeDie) != 42 {
+		t.Fatalf("unexpected histograms: %v, %v", body.ActionDie, body.ChallengeDie)
+	}
+	outcomes := 0
+	for _, n := range body.Outcomes {
+		outcomes += n
+	}
+	if len(body.Outcomes) != 5 || outcomes != 21 {
+		t.Fatalf("unexpected outcomes: %v", body.Outcomes)
+	}
+	if body.ActionFit.DF != 5 || body.ChallengeFit.DF != 9 || body.ActionFit.Reliable {
+		t.Fatalf("unexpected fits: %+v, %+v", body.ActionFit, body.ChallengeFit)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/stats?since=soon", nil))
+	if rw.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid time, got %d", rw.Code)
+	}
+}
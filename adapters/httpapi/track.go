@@ -0,0 +1,285 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// apiTrack is the JSON shape of a progress track.
+type apiTrack struct {
+	ID        string `json:"id"`
+	Owner     string `json:"owner"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Rank      string `json:"rank"`
+	Ticks     int    `json:"ticks"`
+	Score     int    `json:"score"`
+	Completed bool   `json:"completed"`
+}
+
+func formatTrack(t track.Track) apiTrack {
+	return apiTrack{
+		ID:        t.ID,
+		Owner:     t.Owner,
+		Kind:      string(t.Kind),
+		Name:      t.Name,
+		Rank:      string(t.Rank),
+		Ticks:     t.Ticks,
+		Score:     t.Score(),
+		Completed: t.Completed,
+	}
+}
+
+// apiFulfillment is the JSON shape of POST /tracks/{id}/fulfill.
+type apiFulfillment struct {
+	Track apiTrack            `json:"track"`
+	Roll  apiProgressResponse `json:"roll"`
+
+	// Experience the owner's character earned for a fulfilled vow.
+	XP *int `json:"xp,omitempty"`
+}
+
+// apiNewTrack is the request body of POST /tracks.
+type apiNewTrack struct {
+	Owner string `json:"owner"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Rank  string `json:"rank"`
+}
+
+// ListTracksHandler handles GET /tracks requests.
+//
+// Query parameters:
+//   - owner: required owner of the tracks, e.g. telegram:1234
+//
+// Responses:
+//   - 200 OK with a JSON array of tracks ordered by name
+//   - 400 Bad Request if owner is missing
+func (a *API) ListTracksHandler(w http.ResponseWriter, r *http.Request) {
+	owner := r.URL.Query().Get("owner")
+	if owner == "" {
+		http.Error(w, "owner is required", http.StatusBadRequest)
+		return
+	}
+
+	tracks, err := a.tracks.List(owner)
+	if err != nil {
+		slog.Error("http track list failed", "owner", owner, "err", err)
+		http.Error(w, "tracks could not be loaded", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiTrack, len(tracks))
+	for i, t := range tracks {
+		resp[i] = formatTrack(t)
+	}
+	writeJSON(w, resp)
+}
+
+// CreateTrackHandler handles POST /tracks requests.
+//
+// The body is a JSON object with owner, kind (vow, journey, combat or
+// other), name and rank (troublesome to epic). Requires the API token.
+//
+// Responses:
+//   - 201 Created with the JSON track
+//   - 400 Bad Request if the body is malformed or the track is invalid
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+func (a *API) CreateTrackHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	var body apiNewTrack
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid track", http.StatusBadRequest)
+		return
+	}
+
+	t, err := track.New(body.Owner, track.Kind(body.Kind), body.Name, track.Rank(body.Rank))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !a.saveTrack(w, t) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(formatTrack(t))
+}
+
+// GetTrackHandler handles GET /tracks/{id} requests.
+//
+// Responses:
+//   - 200 OK with the JSON track
+//   - 404 Not Found if there is no such track
+func (a *API) GetTrackHandler(w http.ResponseWriter, r *http.Request) {
+	t, ok := a.findTrack(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, formatTrack(t))
+}
+
+// MarkTrackHandler handles POST /tracks/{id}/mark requests.
+// Requires the API token.
+//
+// Query parameters:
+//   - times: optional number of times to mark progress (defaults to 1)
+//
+// Responses:
+//   - 200 OK with the updated JSON track
+//   - 400 Bad Request if times is not a positive integer
+//   - 401, 403 or 404 as for the other track endpoints
+func (a *API) MarkTrackHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	times := 1
+	if raw := r.URL.Query().Get("times"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > track.MaxTicks {
+			http.Error(w, "invalid times", http.StatusBadRequest)
+			return
+		}
+		times = n
+	}
+
+	t, ok := a.findTrack(w, r)
+	if !ok {
+		return
+	}
+	t.Mark(times)
+	if !a.saveTrack(w, t) {
+		return
+	}
+	writeJSON(w, formatTrack(t))
+}
+
+// ClearTrackHandler handles POST /tracks/{id}/clear requests.
+// Requires the API token.
+//
+// Responses:
+//   - 200 OK with the updated JSON track
+//   - 401, 403 or 404 as for the other track endpoints
+func (a *API) ClearTrackHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	t, ok := a.findTrack(w, r)
+	if !ok {
+		return
+	}
+	t.Clear()
+	if !a.saveTrack(w, t) {
+		return
+	}
+	writeJSON(w, formatTrack(t))
+}
+
+// FulfillTrackHandler handles POST /tracks/{id}/fulfill requests.
+//
+// It makes a progress roll against the track's score; a hit marks the
+// track completed. The roll carries the text of the matching progress
+// move (Fulfill Your Vow, Reach Your Destination or End the Fight, or
+// their counterparts in the ruleset of the track's campaign).
+// Fulfilling a vow rewards the owner's character, if any, with
+// experience: directly in classic Ironsworn, or through the quests
+// legacy track in Starforged. Requires the API token.
+//
+// Responses:
+//   - 200 OK with the JSON track and progress roll
+//   - 401, 403 or 404 as for the other track endpoints
+func (a *API) FulfillTrackHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	t, ok := a.findTrack(w, r)
+	if !ok {
+		return
+	}
+	completed := t.Completed
+	res := t.Fulfill(a.roller)
+	if !a.saveTrack(w, t) {
+		return
+	}
+
+	rec := entry(t.Owner)
+	rec.SetProgress(res)
+
+	rs := a.ownerRuleset(t.Owner)
+	progress := formatProgressResult(res, rs.Terms)
+	if m, ok := rs.ProgressMove(t.Kind); ok {
+		rec.Move = m.ID
+		progress.Move = formatMove(m, "", res.Outcome)
+	}
+	a.record(rec)
+
+	resp := apiFulfillment{Track: formatTrack(t), Roll: progress}
+	if t.Kind == track.Vow && t.Completed && !completed {
+		resp.XP = a.rewardVow(t, rs, res.Outcome)
+	}
+	writeJSON(w, resp)
+}
+
+// DeleteTrackHandler handles DELETE /tracks/{id} requests.
+// Requires the API token.
+//
+// Responses:
+//   - 204 No Content
+//   - 401 Unauthorized without a valid token
+//   - 403 Forbidden if no API token is configured
+func (a *API) DeleteTrackHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := a.tracks.Delete(id); err != nil {
+		slog.Error("http track delete failed", "id", id, "err", err)
+		http.Error(w, "track could not be deleted", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findTrack loads the track named by the {id} path value, writing the
+// error response and reporting false when it cannot be loaded.
+func (a *API) findTrack(w http.ResponseWriter, r *http.Request) (track.Track, bool) {
+	id := r.PathValue("id")
+
+	t, err := a.tracks.Get(id)
+	switch {
+	case err == nil:
+		return t, true
+	case errors.Is(err, track.ErrNotFound):
+		http.Error(w, "track not found", http.StatusNotFound)
+	default:
+		slog.Error("http track lookup failed", "id", id, "err", err)
+		http.Error(w, "track could not be loaded", http.StatusInternalServerError)
+	}
+	return track.Track{}, false
+}
+
+// saveTrack stores a track, writing the error response
+// and reporting false when it cannot be stored.
+func (a *API) saveTrack(w http.ResponseWriter, t track.Track) bool {
+	if err := a.tracks.Put(t); err != nil {
+		slog.Error("http track save failed", "id", t.ID, "err", err)
+		http.Error(w, "track could not be saved", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
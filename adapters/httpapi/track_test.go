@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTrackLifecycle(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		var r io.Reader
+		if body != "" {
+			r = strings.NewReader(body)
+		}
+		req := httptest.NewRequest(method, target, r)
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	rw := do(http.MethodPost, "/tracks", `{"owner":"telegram:1","kind":"vow","name":"Avenge my kin","rank":"dangerous"}`)
+	if rw.Code != http.StatusCreated {
+		t.Fatalf("POST /tracks: expected 201, got %d: %s", rw.Code, rw.Body)
+	}
+	var created apiTrack
+	if err := json.NewDecoder(rw.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rw = do(http.MethodPost, "/tracks/"+created.ID+"/mark?times=2", "")
+	var marked apiTrack
+	if err := json.NewDecoder(rw.Body).Decode(&marked); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if marked.Ticks != 16 || marked.Score != 4 {
+		t.Fatalf("unexpected track after marking: %+v", marked)
+	}
+
+	rw = do(http.MethodPost, "/tracks/"+created.ID+"/fulfill", "")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("fulfill: expected 200, got %d", rw.Code)
+	}
+	var fulfilled apiFulfillment
+	if err := json.NewDecoder(rw.Body).Decode(&fulfilled); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if fulfilled.Roll.Progress != 4 || fulfilled.Roll.Move == nil || fulfilled.Roll.Move.Name != "Fulfill Your Vow" {
+		t.Fatalf("unexpected fulfillment: %+v", fulfilled)
+	}
+	hit := fulfilled.Roll.Outcome != "Failure" && fulfilled.Roll.Outcome != "Critical Failure"
+	if fulfilled.Track.Completed != hit {
+		t.Fatalf("completed = %v for outcome %s", fulfilled.Track.Completed, fulfilled.Roll.Outcome)
+	}
+
+	rw = do(http.MethodPost, "/tracks/"+created.ID+"/clear", "")
+	var cleared apiTrack
+	if err := json.NewDecoder(rw.Body).Decode(&cleared); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if cleared.Ticks != 0 || cleared.Completed {
+		t.Fatalf("unexpected track after clearing: %+v", cleared)
+	}
+
+	rw = httptest.NewRecorder()
+	routes.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/tracks?owner=telegram:1", nil))
+	var list []apiTrack
+	if err := json.NewDecoder(rw.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if rw := do(http.MethodDelete, "/tracks/"+created.ID, ""); rw.Code != http.StatusNoContent {
+		t.Fatalf("DELETE: expected 204, got %d", rw.Code)
+	}
+	if rw := do(http.MethodGet, "/tracks/"+created.ID, ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("GET after DELETE: expected 404, got %d", rw.Code)
+	}
+}
+
+func TestTrackErrors(t *testing.T) {
+	api, _ := newCharacterAPI()
+	routes := api.Routes()
+
+	cases := []struct {
+		method, target, body, token string
+		want                        int
+	}{
+		{http.MethodGet, "/tracks", "", "", http.StatusBadRequest},
+		{http.MethodPost, "/tracks", `{"owner":"telegram:1","kind":"vow","name":"X","rank":"epic"}`, "", http.StatusUnauthorized},
+		{http.MethodPost, "/tracks", `{"owner":"telegram:1","kind":"vow","name":"X","rank":"easy"}`, "secret", http.StatusBadRequest},
+		{http.MethodPost, "/tracks", `not json`, "secret", http.StatusBadRequest},
+		{http.MethodPost, "/tracks/missing/mark", "", "secret", http.StatusNotFound},
+		{http.MethodPost, "/tracks/missing/mark?times=0", "", "secret", http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		if rw.Code != c.want {
+			t.Fatalf("%s %s: expected %d, got %d", c.method, c.target, c.want, rw.Code)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// apiLegacy is the JSON shape of a legacy track.
+type apiLegacy struct {
+	Ticks     int  `json:"ticks"`
+	Completed bool `json:"completed"`
+
+	// Derived; ignored on input.
+	Score int `json:"score"`
+}
+
+// XPHandler handles POST /characters/{owner}/xp requests, which mark
+// or spend experience. Requires the API token.
+//
+// Query parameters:
+//   - earn: optional experience earned
+//   - spend: optional experience spent
+//
+// Responses:
+//   - 200 OK with the updated JSON character sheet
+//   - 400 Bad Request if neither is given, either is not a positive
+//     integer, or more experience is spent than is available
+//   - 401, 403 or 404 as for the other character endpoints
+func (a *API) XPHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+	s, ok := a.findCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("earn") == "" && q.Get("spend") == "" {
+		http.Error(w, "earn or spend is required", http.StatusBadRequest)
+		return
+	}
+	for _, p := range []struct {
+		name  string
+		apply func(int) error
+	}{{"earn", s.EarnXP}, {"spend", s.SpendXP}} {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "invalid "+p.name, http.StatusBadRequest)
+			return
+		}
+		if err := p.apply(n); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	a.saveCharacter(w, s)
+}
+
+// LegacyHandler handles POST /characters/{owner}/legacies/{legacy}
+// requests, which mark progress on a Starforged legacy track (quests,
+// bonds or discoveries) and earn experience for every box filled.
+// Requires the API token.
+//
+// Query parameters:
+//   - rank: optional rank whose reward is marked, e.g. dangerous for
+//     2 ticks
+//   - ticks: optional number of ticks to mark
+//
+// Responses:
+//   - 200 OK with the updated JSON character sheet
+//   - 400 Bad Request if the legacy track, rank or ticks is invalid,
+//     or neither rank nor ticks is given
+//   - 401, 403 or 404 as for the other character endpoints
+func (a *API) LegacyHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+	l, ok := character.ParseLegacy(r.PathValue("legacy"))
+	if !ok {
+		http.Error(w, "unknown legacy track", http.StatusBadRequest)
+		return
+	}
+
+	ticks := 0
+	q := r.URL.Query()
+	if raw := q.Get("ticks"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid ticks", http.StatusBadRequest)
+			return
+		}
+		ticks = n
+	}
+	if raw := q.Get("rank"); raw != "" {
+		rank, ok := track.ParseRank(raw)
+		if !ok {
+			http.Error(w, "invalid rank", http.StatusBadRequest)
+			return
+		}
+		ticks += character.LegacyTicks(rank, roll.Success)
+	}
+	if ticks == 0 {
+		http.Error(w, "rank or ticks is required", http.StatusBadRequest)
+		return
+	}
+
+	s, ok := a.findCharacter(w, r)
+	if !ok {
+		return
+	}
+	s.MarkLegacy(l, ticks)
+	a.saveCharacter(w, s)
+}
+
+// AdvanceHandler handles POST /characters/{owner}/advance requests,
+// which spend experience on an asset: 3 XP for a new asset, or 2 XP to
+// mark an ability of an asset the character has. Requires the API
+// token.
+//
+// Query parameters:
+//   - asset: asset ID, key or name, e.g. hound
+//   - ability: optional ability number (from 1) to mark; without it
+//     the asset is taken
+//
+// Responses:
+//   - 200 OK with the updated JSON character sheet
+//   - 400 Bad Request if the asset or ability is invalid, or there is
+//     not enough experience
+//   - 401, 403 or 404 as for the other character endpoints
+func (a *API) AdvanceHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.authorize(w, r) {
+		return
+	}
+	q := r.URL.Query()
+	as, ok := a.rulesets.Asset(q.Get("asset"))
+	if !ok {
+		http.Error(w, "unknown asset", http.StatusBadRequest)
+		return
+	}
+	s, ok := a.findCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+	if raw := q.Get("ability"); raw != "" {
+		n, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			http.Error(w, "invalid ability", http.StatusBadRequest)
+			return
+		}
+		err = s.UpgradeAsset(as, n)
+	} else {
+		err = s.BuyAsset(as)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.saveCharacter(w, s)
+}
+
+// rewardVow rewards the character owning a fulfilled vow and returns
+// the experience earned, or nil when the owner has no sheet, as for a
+// campaign's shared vows.
+func (a *API) rewardVow(t track.Track, rs *ruleset.Ruleset, o roll.Outcome) *int {
+	s, err := a.characters.Get(t.Owner)
+	if err != nil {
+		if !errors.Is(err, character.ErrNotFound) {
+			slog.Error("http character lookup failed", "owner", t.Owner, "err", err)
+		}
+		return nil
+	}
+
+	xp := s.FulfillVow(t.Rank, o, rs.Legacies)
+	if err := a.characters.Put(s); err != nil {
+		slog.Error("http character save failed", "owner", s.Owner, "err", err)
+		return nil
+	}
+	return &xp
+}
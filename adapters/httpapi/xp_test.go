@@ -0,0 +1,121 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestExperienceEndpoints(t *testing.T) {
+	api, characters := newCharacterAPI()
+	routes := api.Routes()
+	if err := characters.Put(character.New("telegram:1", "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(target string) (*httptest.ResponseRecorder, apiCharacter) {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		var sheet apiCharacter
+		if rw.Code == http.StatusOK {
+			if err := json.NewDecoder(rw.Body).Decode(&sheet); err != nil {
+				t.Fatalf("POST %s: failed to decode response: %v", target, err)
+			}
+		}
+		return rw, sheet
+	}
+
+	steps := []struct {
+		target string
+		code   int
+		check  func(apiCharacter) bool
+	}{
+		{"/characters/telegram:1/xp?earn=4", http.StatusOK, func(s apiCharacter) bool { return s.XP == 4 }},
+		{"/characters/telegram:1/xp?spend=5", http.StatusBadRequest, nil},
+		{"/characters/telegram:1/xp", http.StatusBadRequest, nil},
+		{"/characters/telegram:2/xp?earn=1", http.StatusNotFound, nil},
+		{"/characters/telegram:1/advance?asset=hound", http.StatusOK, func(s apiCharacter) bool {
+			return s.XP == 1 && s.XPSpent == 3 && len(s.Assets) == 1
+		}},
+		{"/characters/telegram:1/advance?asset=hound&ability=2", http.StatusBadRequest, nil},
+		{"/characters/telegram:1/advance?asset=dragon", http.StatusBadRequest, nil},
+		{"/characters/telegram:1/legacies/bonds?rank=formidable", http.StatusOK, func(s apiCharacter) bool {
+			return s.Legacies["bonds"].Score == 1 && s.XP == 3
+		}},
+		{"/characters/telegram:1/legacies/bonds?ticks=2", http.StatusOK, func(s apiCharacter) bool {
+			return s.Legacies["bonds"].Ticks == 6 && s.XP == 3
+		}},
+		{"/characters/telegram:1/legacies/fame?ticks=2", http.StatusBadRequest, nil},
+		{"/characters/telegram:1/legacies/bonds", http.StatusBadRequest, nil},
+		{"/characters/telegram:1/advance?asset=hound&ability=2", http.StatusOK, func(s apiCharacter) bool {
+			return s.XP == 1 && s.Assets[0].Abilities[1]
+		}},
+	}
+
+	for _, step := range steps {
+		rw, sheet := post(step.target)
+		if rw.Code != step.code {
+			t.Fatalf("POST %s: expected %d, got %d: %s", step.target, step.code, rw.Code, rw.Body)
+		}
+		if step.check != nil && !step.check(sheet) {
+			t.Fatalf("POST %s: unexpected sheet %+v", step.target, sheet)
+		}
+	}
+}
+
+func TestFulfillVowEarnsXP(t *testing.T) {
+	api, characters := newCharacterAPI()
+	routes := api.Routes()
+	if err := characters.Put(character.New("telegram:1", "Kira")); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rw := httptest.NewRecorder()
+		routes.ServeHTTP(rw, req)
+		return rw
+	}
+
+	var created apiTrack
+	rw := do("/tracks", `{"owner":"telegram:1","kind":"vow","name":"Avenge my kin","rank":"formidable"}`)
+	if err := json.NewDecoder(rw.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	do("/tracks/"+created.ID+"/mark?times=10", "")
+
+	var res apiFulfillment
+	if err := json.NewDecoder(do("/tracks/"+created.ID+"/fulfill", "").Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !res.Track.Completed {
+		t.Skipf("the seeded roll missed: %+v", res.Roll)
+	}
+	want := character.VowXP(track.Formidable, roll.Outcome(res.Roll.Outcome))
+	if res.XP == nil || *res.XP != want {
+		t.Fatalf("xp = %v; want %d", res.XP, want)
+	}
+
+	sheet, _ := characters.Get("telegram:1")
+	if sheet.XPEarned != want {
+		t.Fatalf("sheet xp = %d; want %d", sheet.XPEarned, want)
+	}
+
+	// Fulfilling a completed vow again earns nothing more.
+	res = apiFulfillment{}
+	if err := json.NewDecoder(do("/tracks/"+created.ID+"/fulfill", "").Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if res.XP != nil {
+		t.Fatalf("xp = %d on a completed vow; want none", *res.XP)
+	}
+}
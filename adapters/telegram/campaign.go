@@ -0,0 +1,129 @@
+package telegram
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+)
+
+// campaignHelp explains the /campaign command.
+const campaignHelp = `Usage:
+/campaign — show the campaign of this chat
+/campaign new Name — start a campaign played in this chat
+/campaign ruleset starforged — play it under classic, delve or starforged
+
+In a campaign chat /character manages your character in that campaign.
+Inline rolls always use your personal sheet.`
+
+// scope resolves the campaign bound to a chat, if any, and the
+// character owner of the user there. Outside a campaign the user's
+// personal sheet is used.
+func (h *Handler) scope(chatID int64, u *tgbotapi.User) (string, *campaign.Campaign) {
+	c, err := h.campaignOf(chatID)
+	if err != nil {
+		if !errors.Is(err, campaign.ErrNotFound) {
+			slog.Error("telegram campaign lookup failed", "chat_id", chatID, "err", err)
+		}
+		return userOwner(u), nil
+	}
+	return c.CharacterOwner(userOwner(u)), &c
+}
+
+// campaignOf returns the campaign bound to a chat.
+func (h *Handler) campaignOf(chatID int64) (campaign.Campaign, error) {
+	return campaign.Resolve(h.campaigns, campaign.TelegramChat(strconv.FormatInt(chatID, 10)))
+}
+
+// updateMembers keeps the member list of campaign id in step with the
+// character sheets played in it after owner's sheet has changed. The
+// campaign is loaded afresh so that changes made to it since it was
+// looked up are not lost.
+func (h *Handler) updateMembers(id, owner string) {
+	c, err := h.campaigns.Get(id)
+	if err != nil {
+		if !errors.Is(err, campaign.ErrNotFound) {
+			slog.Error("telegram campaign lookup failed", "id", id, "err", err)
+		}
+		return
+	}
+
+	_, err = h.characters.Get(owner)
+	switch {
+	case err == nil && !c.HasMember(owner):
+		c.AddMember(owner)
+	case errors.Is(err, character.ErrNotFound) && c.HasMember(owner):
+		c.RemoveMember(owner)
+	default:
+		return
+	}
+
+	if err := h.campaigns.Put(c); err != nil {
+		slog.Error("telegram campaign member update failed", "id", c.ID, "err", err)
+	}
+}
+
+// campaign runs a /campaign command in a chat and returns the reply.
+//
+// Campaigns can only be created here; binding other chats, unbinding
+// and deleting are done through Discord or the HTTP API, which check
+// permissions.
+func (h *Handler) campaign(chatID int64, args string) string {
+	verb, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+
+	switch strings.ToLower(verb) {
+	case "", "show":
+		c, err := h.campaignOf(chatID)
+		if err != nil {
+			return h.campaignError(err)
+		}
+		return formatCampaign(c, h.rulesets.Select(c.Ruleset))
+
+	case "new":
+		c, err := campaign.New(rest)
+		if err != nil {
+			return "Give the campaign a name, e.g. /campaign new The Ironlands."
+		}
+		c.Bind(campaign.TelegramChat(strconv.FormatInt(chatID, 10)))
+		if err := h.campaigns.Put(c); err != nil {
+			return h.campaignError(err)
+		}
+		return formatCampaign(c, h.rulesets.Select(c.Ruleset))
+
+	case "ruleset":
+		rs, ok := h.rulesets.Lookup(rest)
+		if !ok {
+			return "Choose a ruleset: classic, delve or starforged."
+		}
+		c, err := h.campaignOf(chatID)
+		if err != nil {
+			return h.campaignError(err)
+		}
+		c.Ruleset = string(rs.ID)
+		if err := h.campaigns.Put(c); err != nil {
+			return h.campaignError(err)
+		}
+		return formatCampaign(c, rs)
+
+	default:
+		return campaignHelp
+	}
+}
+
+// campaignError describes a campaign store error to the user.
+func (h *Handler) campaignError(err error) string {
+	switch {
+	case errors.Is(err, campaign.ErrNotFound):
+		return "This chat has no campaign.\n\n" + campaignHelp
+	case errors.Is(err, campaign.ErrBound):
+		return "This chat already has a campaign."
+	default:
+		slog.Error("telegram campaign store failed", "err", err)
+		return "The campaign could not be loaded or saved."
+	}
+}
@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+)
+
+func TestCampaignCommand(t *testing.T) {
+	store := campaign.NewMemoryStore()
+	h := NewHandler(Config{Campaigns: store})
+	const chat = -100
+
+	steps := []struct {
+		args string
+		want string
+	}{
+		{"", "This chat has no campaign."},
+		{"new", "Give the campaign a name"},
+		{"new The Ironlands", "The Ironlands (id "},
+		{"new Again", "This chat already has a campaign."},
+		{"show", "ruleset: Ironsworn\ncharacters: 0"},
+		{"ruleset sundered isles", "Choose a ruleset"},
+		{"ruleset sf", "ruleset: Ironsworn: Starforged"},
+		{"show", "ruleset: Ironsworn: Starforged"},
+		{"bogus", "Usage:"},
+	}
+
+	for _, s := range steps {
+		got := h.campaign(chat, s.args)
+		if !strings.Contains(got, s.want) {
+			t.Fatalf("/campaign %s = %q; want it to contain %q", s.args, got, s.want)
+		}
+	}
+}
+
+func TestCharacterInCampaign(t *testing.T) {
+	h := NewHandler(Config{})
+	user := &tgbotapi.User{ID: 7}
+
+	if owner, c := h.scope(-100, user); owner != "telegram:7" || c != nil {
+		t.Fatalf("scope outside a campaign = %q, %v", owner, c)
+	}
+
+	h.campaign(-100, "new Party")
+	owner, c := h.scope(-100, user)
+	if c == nil || owner != c.CharacterOwner("telegram:7") {
+		t.Fatalf("scope in a campaign = %q, %v", owner, c)
+	}
+
+	// Changes made since c was looked up are kept.
+	h.campaign(-100, "ruleset starforged")
+	h.character(owner, "new Kira")
+	h.updateMembers(c.ID, owner)
+	if got, _ := h.campaignOf(-100); !got.HasMember(owner) || got.Ruleset != "starforged" {
+		t.Fatalf("campaign = %+v; want %q as a member and the starforged ruleset", got, owner)
+	}
+
+	h.character(owner, "delete")
+	_, c = h.scope(-100, user)
+	h.updateMembers(c.ID, owner)
+	if got, _ := h.campaignOf(-100); got.HasMember(owner) {
+		t.Fatalf("members = %v; want %q removed", got.Members, owner)
+	}
+
+	// The personal sheet is separate from the campaign character.
+	if _, err := h.characters.Get("telegram:7"); err == nil {
+		t.Fatal("expected no personal sheet")
+	}
+}
@@ -0,0 +1,316 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// characterHelp explains the /character command.
+const characterHelp = `Usage:
+/character — show your sheet
+/character new Name — start a new sheet
+/character edge 3 heart 2 wits 1 — set stats, meters or momentum
+/character name Name — rename your character
+/character mark wounded — mark a debility
+/character clear wounded — clear a debility
+/character asset add Hound — take an asset
+/character asset buy Hound — take an asset for 3 XP
+/character asset mark Hound 2 — mark an asset ability
+/character asset upgrade Hound 2 — mark an asset ability for 2 XP
+/character asset clear Hound 2 — clear an asset ability
+/character asset Hound health 3 — set an asset track
+/character asset remove Hound — remove an asset
+/character xp earn 2 — mark experience
+/character xp spend 3 — spend experience
+/character legacy bonds dangerous — mark a legacy track by rank
+/character legacy quests 4 — mark ticks on a legacy track
+/character delete — delete your sheet`
+
+// userOwner returns the character owner key of a Telegram user.
+// It returns "" for a nil user.
+func userOwner(u *tgbotapi.User) string {
+	if u == nil {
+		return ""
+	}
+	return "telegram:" + strconv.FormatInt(u.ID, 10)
+}
+
+// character runs a /character command for owner and returns the reply.
+func (h *Handler) character(owner, args string) string {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		fields = []string{"show"}
+	}
+	verb, rest := strings.ToLower(fields[0]), strings.Join(fields[1:], " ")
+
+	sheet, err := h.characters.Get(owner)
+	exists := err == nil
+	if err != nil && !errors.Is(err, character.ErrNotFound) {
+		slog.Error("telegram character lookup failed", "owner", owner, "err", err)
+		return "Your character sheet could not be loaded."
+	}
+
+	switch verb {
+	case "show":
+		if !exists {
+			return "You have no character sheet yet.\n\n" + characterHelp
+		}
+		return formatSheet(sheet, h.rulesets.Asset)
+
+	case "help":
+		return characterHelp
+
+	case "new":
+		if exists {
+			return "You already have a character sheet. Delete it first with /character delete."
+		}
+		sheet = character.New(owner, rest)
+
+	case "delete":
+		if err := h.characters.Delete(owner); err != nil {
+			slog.Error("telegram character delete failed", "owner", owner, "err", err)
+			return "Your character sheet could not be deleted."
+		}
+		return "Your character sheet was deleted."
+
+	case "name":
+		if !exists {
+			sheet = character.New(owner, "")
+		}
+		sheet.Name = rest
+
+	case "mark", "clear":
+		if !exists {
+			return "You have no character sheet yet.\n\n" + characterHelp
+		}
+		d, ok := character.ParseDebility(rest)
+		if !ok {
+			return fmt.Sprintf("Unknown debility %q.", rest)
+		}
+		sheet.Mark(d, verb == "mark")
+
+	case "asset", "assets":
+		if !exists {
+			return "You have no character sheet yet.\n\n" + characterHelp
+		}
+		if problem := applyAsset(&sheet, h.rulesets.Asset, rest); problem != "" {
+			return problem
+		}
+
+	case "xp":
+		if !exists {
+			return "You have no character sheet yet.\n\n" + characterHelp
+		}
+		if problem := applyXP(&sheet, rest); problem != "" {
+			return problem
+		}
+
+	case "legacy":
+		if !exists {
+			return "You have no character sheet yet.\n\n" + characterHelp
+		}
+		if problem := applyLegacy(&sheet, rest); problem != "" {
+			return problem
+		}
+
+	default:
+		if !exists {
+			sheet = character.New(owner, "")
+		}
+		if problem := applyAssignments(&sheet, args); problem != "" {
+			return problem
+		}
+	}
+
+	if err := h.characters.Put(sheet); err != nil {
+		slog.Error("telegram character save failed", "owner", owner, "err", err)
+		return "Your character sheet could not be saved."
+	}
+	return formatSheet(sheet, h.rulesets.Asset)
+}
+
+// applyAssignments applies "name value" pairs such as "edge 3 wits 1"
+// or "edge=3" to a sheet. It returns a message describing the first
+// invalid pair, or "" when every pair was applied.
+func applyAssignments(s *character.Sheet, raw string) string {
+	fields := strings.Fields(strings.ReplaceAll(raw, "=", " "))
+	if len(fields)%2 != 0 {
+		return "Give each stat a value, e.g. /character edge 3 heart 2."
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		value, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return fmt.Sprintf("%q is not a number.", fields[i+1])
+		}
+		if err := s.Set(fields[i], value); err != nil {
+			return sentence(err)
+		}
+	}
+	return ""
+}
+
+// applyAsset applies a /character asset command such as "add Hound",
+// "mark Hound 2" or "Hound health 3" to a sheet, finding assets with
+// lookup. It returns a message describing why the command could not be
+// applied, or "" when it was.
+func applyAsset(s *character.Sheet, lookup func(string) (asset.Asset, bool), raw string) string {
+	const usage = "Use e.g. /character asset add Hound, /character asset mark Hound 2 or /character asset Hound health 3."
+
+	fields := strings.Fields(raw)
+	if len(fields) < 2 {
+		return usage
+	}
+	verb := strings.ToLower(fields[0])
+
+	// find returns the asset named by words and the sheet's card for it.
+	find := func(words []string) (asset.Asset, *asset.Card, string) {
+		name := strings.Join(words, " ")
+		a, ok := lookup(name)
+		if !ok {
+			return asset.Asset{}, nil, fmt.Sprintf("Unknown asset %q.", name)
+		}
+		c := s.Card(a.ID)
+		if c == nil && verb != "add" && verb != "buy" {
+			return asset.Asset{}, nil, fmt.Sprintf("You do not have %s.", a.Name)
+		}
+		return a, c, ""
+	}
+
+	switch verb {
+	case "add":
+		a, _, problem := find(fields[1:])
+		if problem != "" {
+			return problem
+		}
+		if err := s.AddAsset(a); err != nil {
+			return err.Error() + "."
+		}
+
+	case "buy":
+		a, _, problem := find(fields[1:])
+		if problem != "" {
+			return problem
+		}
+		if err := s.BuyAsset(a); err != nil {
+			return sentence(err)
+		}
+
+	case "remove":
+		a, _, problem := find(fields[1:])
+		if problem != "" {
+			return problem
+		}
+		s.RemoveAsset(a.ID)
+
+	case "mark", "clear", "upgrade":
+		n, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil || len(fields) < 3 {
+			return usage
+		}
+		a, c, problem := find(fields[1 : len(fields)-1])
+		if problem != "" {
+			return problem
+		}
+		if verb == "upgrade" {
+			err = s.UpgradeAsset(a, n)
+		} else {
+			err = c.Mark(a, n, verb == "mark")
+		}
+		if err != nil {
+			return sentence(err)
+		}
+
+	default:
+		if len(fields) < 3 {
+			return usage
+		}
+		v, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return usage
+		}
+		a, c, problem := find(fields[:len(fields)-2])
+		if problem != "" {
+			return problem
+		}
+		if err := c.SetTrack(a, fields[len(fields)-2], v); err != nil {
+			return err.Error() + "."
+		}
+	}
+	return ""
+}
+
+// applyXP applies a /character xp command such as "earn 2" or
+// "spend 3" to a sheet. It returns a message describing why the command
+// could not be applied, or "" when it was.
+func applyXP(s *character.Sheet, raw string) string {
+	fields := strings.Fields(raw)
+	if len(fields) != 2 {
+		return "Use e.g. /character xp earn 2 or /character xp spend 3."
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Sprintf("%q is not a number.", fields[1])
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "earn":
+		err = s.EarnXP(n)
+	case "spend":
+		err = s.SpendXP(n)
+	default:
+		return "Use e.g. /character xp earn 2 or /character xp spend 3."
+	}
+	if err != nil {
+		return sentence(err)
+	}
+	return ""
+}
+
+// applyLegacy applies a /character legacy command such as "bonds
+// dangerous", which marks the reward of a rank, or "quests 4", which
+// marks ticks, to a sheet. Experience for filled boxes is earned. It
+// returns a message describing why the command could not be applied,
+// or "" when it was.
+func applyLegacy(s *character.Sheet, raw string) string {
+	const usage = "Use e.g. /character legacy bonds dangerous or /character legacy quests 4."
+
+	fields := strings.Fields(raw)
+	if len(fields) != 2 {
+		return usage
+	}
+	l, ok := character.ParseLegacy(fields[0])
+	if !ok {
+		return fmt.Sprintf("Unknown legacy track %q; use quests, bonds or discoveries.", fields[0])
+	}
+
+	ticks, err := strconv.Atoi(fields[1])
+	if err != nil {
+		rank, ok := track.ParseRank(fields[1])
+		if !ok {
+			return usage
+		}
+		ticks = character.LegacyTicks(rank, roll.Success)
+	}
+	if ticks < 1 {
+		return "Mark at least one tick."
+	}
+	s.MarkLegacy(l, ticks)
+	return ""
+}
+
+// sentence turns an error into a sentence for a reply.
+func sentence(err error) string {
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/character"
+)
+
+func TestCharacterCommand(t *testing.T) {
+	store := character.NewMemoryStore()
+	h := NewHandler(Config{Characters: store})
+	const owner = "telegram:42"
+
+	steps := []struct {
+		args string
+		want string
+	}{
+		{"", "You have no character sheet yet."},
+		{"new Kira", "Kira\n"},
+		{"new Other", "You already have a character sheet."},
+		{"edge 3 heart=2 momentum 5", "edge 3 · heart 2"},
+		{"wits 9", "Wits must be between 1 and 3."},
+		{"wits", "Give each stat a value"},
+		{"mark wounded", "debilities: wounded"},
+		{"mark sleepy", "Unknown debility"},
+		{"name Kira the Bold", "Kira the Bold\n"},
+		{"asset add Hound", "🃏 Hound ●○○ · health 4/4"},
+		{"asset add hound", "Kira the Bold already has Hound."},
+		{"asset add Dragon", "Unknown asset \"Dragon\"."},
+		{"asset mark hound 3", "🃏 Hound ●○●"},
+		{"asset clear hound 1", "🃏 Hound ○○●"},
+		{"asset mark hound 4", "Hound has abilities 1 to 3."},
+		{"asset hound health 2", "health 2/4"},
+		{"asset hound health 7", "Hound health must be between 0 and 4."},
+		{"asset mark slayer 1", "You do not have Slayer."},
+		{"asset remove hound", "Kira the Bold\n"},
+		{"asset buy hound", "3 experience needed, 0 available."},
+		{"xp earn 5", "xp 5 (earned 5, spent 0)"},
+		{"xp spend 9", "9 experience needed, 5 available."},
+		{"xp gain 1", "Use e.g. /character xp earn 2"},
+		{"asset buy hound", "xp 2 (earned 5, spent 3)"},
+		{"asset upgrade hound 2", "🃏 Hound ●●○"},
+		{"asset upgrade hound 3", "2 experience needed, 0 available."},
+		{"legacy bonds dangerous", "bonds 0/10 (+2/4)"},
+		{"legacy quests epic", "xp 6 (earned 11, spent 5)"},
+		{"legacy fame 1", "Unknown legacy track \"fame\""},
+		{"legacy quests soon", "Use e.g. /character legacy"},
+		{"show", "momentum +5 (max 9, reset 1)"},
+		{"clear wounded", "momentum +5 (max 10, reset 2)"},
+		{"delete", "Your character sheet was deleted."},
+	}
+
+	for _, s := range steps {
+		got := h.character(owner, s.args)
+		if !strings.Contains(got, s.want) {
+			t.Fatalf("/character %s = %q; want it to contain %q", s.args, got, s.want)
+		}
+	}
+
+	if _, err := store.Get(owner); err == nil {
+		t.Fatal("expected the sheet to be deleted")
+	}
+}
@@ -0,0 +1,210 @@
+package telegram
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// commandHelp lists the chat commands.
+const commandHelp = `Commands:
+/roll +2 — action roll; also /roll face danger +edge m3 or /roll 2d10
+/progress 7 — progress roll
+/oracle action — roll on an oracle table
+/ask likely — ask the oracle a yes/no question
+/odds +3 — chance of each outcome
+/character — manage your character sheet
+/campaign — show or start the campaign of this chat
+
+Rolls also work inline in any chat: type the bot's name, then e.g. +2.`
+
+// Commands lists the chat commands for the command menu of Telegram
+// clients (setMyCommands).
+var Commands = []tgbotapi.BotCommand{
+	{Command: "roll", Description: "Action roll, e.g. +2 or face danger +edge"},
+	{Command: "progress", Description: "Progress roll, e.g. 7"},
+	{Command: "oracle", Description: "Roll on an oracle table, e.g. action"},
+	{Command: "ask", Description: "Ask the oracle a yes/no question, e.g. likely"},
+	{Command: "odds", Description: "Chance of each outcome, e.g. +3"},
+	{Command: "character", Description: "Manage your character sheet"},
+	{Command: "campaign", Description: "Show or start the campaign of this chat"},
+	{Command: "help", Description: "List the commands"},
+}
+
+// Chat Commands
+//
+// Besides inline mode the bot answers commands sent in private and
+// group chats, e.g. "/roll +2" or "/progress 7". The arguments of
+// /roll take the same form as an inline query.
+//
+// In a group a command may name the bot it is meant for, e.g.
+// "/roll@ironrollbot +2"; commands naming another bot are ignored, so
+// several bots can share a group. Unknown commands are ignored too.
+//
+// In a chat bound to a campaign rolls use the sender's character in
+// that campaign and the campaign's ruleset, unless the query names
+// another. Unlike inline rolls, which are recorded once chosen, chat
+// rolls are recorded as soon as they are answered, with the chat and
+// campaign.
+
+// commandReply is the answer to a chat command.
+type commandReply struct {
+	text     string
+	keyboard *tgbotapi.InlineKeyboardMarkup // Reroll buttons, if any
+	rec      history.Entry                  // The roll made, if any
+}
+
+// HandleMessage answers the chat commands listed in commandHelp,
+// including /character, which manages the sender's character sheet,
+// and /campaign. Other messages are ignored.
+func (h *Handler) HandleMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+	if msg == nil || msg.From == nil || msg.Chat == nil || !msg.IsCommand() {
+		return
+	}
+
+	botName := ""
+	if bot != nil {
+		botName = bot.Self.UserName
+	}
+	reply, ok := h.command(msg, botName)
+	if !ok {
+		return
+	}
+
+	slog.Info(
+		"telegram command",
+		"command", msg.Command(),
+		"chat_id", msg.Chat.ID,
+	)
+
+	// Safety check for nil bot (can happen in tests).
+	if bot == nil {
+		slog.Warn("telegram bot is nil, skipping response")
+		return
+	}
+
+	out := tgbotapi.NewMessage(msg.Chat.ID, reply.text)
+	out.ReplyToMessageID = msg.MessageID
+	if reply.keyboard != nil {
+		out.ReplyMarkup = reply.keyboard
+	}
+	if _, err := bot.Send(out); err != nil {
+		slog.Error("telegram message send failed", "err", err)
+		return
+	}
+	h.record(reply.rec)
+}
+
+// command runs the command of a message and returns the reply. It
+// reports false for unknown commands and commands meant for another
+// bot than the one named botName.
+func (h *Handler) command(msg *tgbotapi.Message, botName string) (commandReply, bool) {
+	if !addressed(msg.CommandWithAt(), botName) {
+		return commandReply{}, false
+	}
+	args := strings.TrimSpace(msg.CommandArguments())
+
+	switch strings.ToLower(msg.Command()) {
+	case "character":
+		owner, camp := h.scope(msg.Chat.ID, msg.From)
+		text := h.character(owner, args)
+		if camp != nil {
+			h.updateMembers(camp.ID, owner)
+		}
+		return commandReply{text: text}, true
+	case "campaign":
+		return commandReply{text: h.campaign(msg.Chat.ID, args)}, true
+	case "start", "help":
+		return commandReply{text: commandHelp}, true
+	case "roll", "r":
+		return h.chatRoll(msg, args, ""), true
+	case "progress", "p":
+		if _, ok := parseScore(args); !ok {
+			return commandReply{text: fmt.Sprintf("Give a progress score from %d to %d, e.g. /progress 7.",
+				roll.MinProgressScore, roll.MaxProgressScore)}, true
+		}
+		return h.chatRoll(msg, args, "progress "), true
+	case "odds":
+		return h.chatRoll(msg, args, "odds "), true
+	case "ask":
+		if _, _, err := parseAsk("? " + args); err != nil {
+			return commandReply{text: fmt.Sprintf("Unknown odds %q; try likely, 50/50 or small chance.", args)}, true
+		}
+		return h.chatRoll(msg, args, "? "), true
+	case "oracle":
+		return commandReply{text: h.chatOracle(msg, args)}, true
+	default:
+		return commandReply{}, false
+	}
+}
+
+// addressed reports whether a command such as "roll" or
+// "roll@ironrollbot" is meant for the bot named botName: a command
+// naming no bot is meant for every bot. Any command is accepted when
+// the bot's name is unknown.
+func addressed(command, botName string) bool {
+	_, name, ok := strings.Cut(command, "@")
+	return !ok || botName == "" || strings.EqualFold(name, botName)
+}
+
+// chatRoll answers a roll command whose arguments, after prefix,
+// form an inline query. A bare /roll rolls nothing, as a bare inline
+// query does.
+func (h *Handler) chatRoll(msg *tgbotapi.Message, args, prefix string) commandReply {
+	if args == "" && prefix == "" {
+		return commandReply{text: "Give a modifier, e.g. /roll +2, or a move, e.g. /roll face danger +edge."}
+	}
+
+	owner, camp := h.scope(msg.Chat.ID, msg.From)
+	def := h.rulesets.Default()
+	if camp != nil {
+		def = h.rulesets.Select(camp.Ruleset)
+	}
+
+	q := prefix + args
+	_, text, rec := h.answerUnder(owner, def, q)
+	rec.Channel = strconv.FormatInt(msg.Chat.ID, 10)
+	if camp != nil {
+		rec.Campaign = camp.ID
+	}
+	rs, _ := h.ruleset(def, q)
+	return commandReply{text: text, keyboard: h.entryKeyboard(rs, msg.From.ID, rec), rec: rec}
+}
+
+// chatOracle rolls on the oracle table named by the arguments of an
+// /oracle command, using the tables of the chat's ruleset, and
+// returns the reply.
+func (h *Handler) chatOracle(msg *tgbotapi.Message, name string) string {
+	if name == "" {
+		return "Name an oracle table, e.g. /oracle action."
+	}
+	_, camp := h.scope(msg.Chat.ID, msg.From)
+	rs := h.rulesets.Default()
+	if camp != nil {
+		rs = h.rulesets.Select(camp.Ruleset)
+	}
+	return h.rollOracle(rs, name)
+}
+
+// rollOracle rolls on the named oracle table of a ruleset and
+// describes the result.
+func (h *Handler) rollOracle(rs *ruleset.Ruleset, name string) string {
+	table, ok := rs.Oracle(name)
+	if !ok {
+		return fmt.Sprintf("Unknown oracle table %q.", name)
+	}
+
+	res, err := oracle.Roll(h.roller, table)
+	if err != nil {
+		return err.Error()
+	}
+	return formatOracleResult(res)
+}
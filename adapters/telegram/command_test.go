@@ -0,0 +1,154 @@
+package telegram
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// loadUpdates reads the updates of a recorded getUpdates response.
+func loadUpdates(t *testing.T, path string) []tgbotapi.Update {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Result []tgbotapi.Update `json:"result"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+	return resp.Result
+}
+
+func TestCommandRouter(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+	updates := loadUpdates(t, "testdata/updates.json")
+
+	// Expected replies by message text, in the order of the updates.
+	// An empty want means the command is ignored.
+	want := map[string]string{
+		"/start":                           "Commands:\n/roll +2",
+		"/roll +2":                         "🎲 (",
+		"/roll":                            "Give a modifier, e.g. /roll +2",
+		"/progress 7":                      "📈 7",
+		"/progress 12":                     "Give a progress score from 0 to 10",
+		"/oracle action":                   "🔮 Action (",
+		"/oracle weather":                  "Unknown oracle table \"weather\".",
+		"/ask likely":                      "🔮 Likely (",
+		"/ask maybe":                       "Unknown odds \"maybe\"",
+		"/odds +3":                         "Strong Hit 33.2%",
+		"/weather":                         "",
+		"/roll@ironrollbot face danger +2": "Face Danger\n🎲",
+		"/roll@otherbot +2":                "",
+		"/campaign@IronRollBot new The Ironlands": "The Ironlands",
+		"/campaign ruleset starforged":            "Starforged",
+		"/roll face danger +2":                    "Face Danger\n🎲",
+		"/character@ironrollbot new Ulf":          "Ulf\n",
+		// The built-in Starforged ruleset has no oracle tables.
+		"/oracle@ironrollbot action": "Unknown oracle table \"action\".",
+	}
+
+	if len(updates) != len(want) {
+		t.Fatalf("loaded %d updates; want %d", len(updates), len(want))
+	}
+	for _, u := range updates {
+		msg := u.Message
+		w, known := want[msg.Text]
+		if !known {
+			t.Fatalf("update %d: no expectation for %q", u.UpdateID, msg.Text)
+		}
+
+		reply, ok := h.command(msg, "ironrollbot")
+		if ok != (w != "") {
+			t.Fatalf("%s: handled = %v; want %v", msg.Text, ok, w != "")
+		}
+		if !strings.Contains(reply.text, w) {
+			t.Fatalf("%s = %q; want it to contain %q", msg.Text, reply.text, w)
+		}
+	}
+}
+
+func TestCommandRollEntry(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+	updates := loadUpdates(t, "testdata/updates.json")
+
+	find := func(text string) *tgbotapi.Message {
+		for _, u := range updates {
+			if u.Message.Text == text {
+				return u.Message
+			}
+		}
+		t.Fatalf("no recorded update %q", text)
+		return nil
+	}
+
+	reply, _ := h.command(find("/roll +2"), "ironrollbot")
+	if rec := reply.rec; rec.Kind != roll.KindAction || rec.Modifier != 2 || rec.User != "telegram:42" || rec.Channel != "42" {
+		t.Fatalf("unexpected entry %+v", rec)
+	}
+	if reply.keyboard == nil {
+		t.Fatal("expected reroll buttons on an action roll")
+	}
+
+	reply, _ = h.command(find("/progress 7"), "ironrollbot")
+	if rec := reply.rec; rec.Kind != roll.KindProgress || rec.Progress != 7 || reply.keyboard != nil {
+		t.Fatalf("unexpected progress reply %+v", reply)
+	}
+
+	h.command(find("/campaign@IronRollBot new The Ironlands"), "ironrollbot")
+	h.command(find("/campaign ruleset starforged"), "ironrollbot")
+	reply, _ = h.command(find("/roll face danger +2"), "ironrollbot")
+	if rec := reply.rec; rec.Move != "starforged/moves/adventure/face_danger" || rec.Campaign == "" || rec.Channel != "-1001234" {
+		t.Fatalf("unexpected campaign entry %+v", rec)
+	}
+
+	// The bot's name is unknown without a connection; every command is
+	// then taken to be meant for it.
+	if _, ok := h.command(find("/roll@otherbot +2"), ""); !ok {
+		t.Fatal("expected a command to be handled when the bot's name is unknown")
+	}
+}
+
+func TestAddressed(t *testing.T) {
+	cases := []struct {
+		command string
+		bot     string
+		want    bool
+	}{
+		{"roll", "ironrollbot", true},
+		{"roll@ironrollbot", "ironrollbot", true},
+		{"roll@IronRollBot", "ironrollbot", true},
+		{"roll@otherbot", "ironrollbot", false},
+		{"roll@otherbot", "", true},
+	}
+
+	for _, c := range cases {
+		if got := addressed(c.command, c.bot); got != c.want {
+			t.Fatalf("addressed(%q, %q) = %v; want %v", c.command, c.bot, got, c.want)
+		}
+	}
+}
+
+func TestCommandsAreRouted(t *testing.T) {
+	h := NewHandler(Config{})
+	for _, c := range Commands {
+		msg := &tgbotapi.Message{
+			Text:     "/" + c.Command,
+			Chat:     &tgbotapi.Chat{ID: 1, Type: "private"},
+			From:     &tgbotapi.User{ID: 1},
+			Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: len(c.Command) + 1}},
+		}
+		if _, ok := h.command(msg, "ironrollbot"); !ok {
+			t.Fatalf("/%s is listed but not routed", c.Command)
+		}
+	}
+}
@@ -2,40 +2,294 @@ package telegram
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/dice"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
 )
 
-// formatResult renders a single-line, minimal Ironsworn roll result.
+// formatResult renders a single-line, minimal Ironsworn roll result,
+// naming the outcome in the ruleset's terms.
 //
 // Format:
 // 🎲 (action + modifier) vs (challenge1 & challenge2) → Ironsworn result
-func formatResult(r roll.Result) string {
+//
+// When the roll carries momentum, a short note is appended:
+// "· action die cancelled (momentum -3)" or "· burn → Strong Hit".
+// Verifiable rolls end with their roll ID.
+func formatResult(r roll.Result, t ruleset.Terms) string {
 	return fmt.Sprintf(
 		"🎲 (%d %+d) vs (%d & %d) → %s",
 		r.ActionDie,
 		r.Modifier,
 		r.ChallengeDice[0],
 		r.ChallengeDice[1],
-		ironswornOutcome(r),
-	)
+		t.Outcome(r.Outcome),
+	) + formatMomentum(r.Momentum, t) + formatID(r.ID)
+}
+
+// formatID renders the roll ID of a verifiable roll.
+func formatID(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " · 🔏 " + id
+}
+
+// formatMomentum renders the momentum note appended to a roll result.
+// It returns an empty string when there is nothing worth mentioning.
+func formatMomentum(m *roll.MomentumEffect, t ruleset.Terms) string {
+	switch {
+	case m == nil:
+		return ""
+	case m.Burned:
+		return fmt.Sprintf(" · momentum %+d burned", m.Value)
+	case m.Cancelled:
+		return fmt.Sprintf(" · action die cancelled (momentum %+d)", m.Value)
+	case m.CanBurn:
+		return " · burn → " + t.Outcome(m.BurnOutcome)
+	default:
+		return ""
+	}
+}
+
+// formatProgressResult renders a single-line, minimal progress roll result.
+//
+// Format:
+// 📈 progress vs (challenge1 & challenge2) → Ironsworn result
+func formatProgressResult(r roll.ProgressResult, t ruleset.Terms) string {
+	return fmt.Sprintf(
+		"📈 %d vs (%d & %d) → %s",
+		r.ProgressScore,
+		r.ChallengeDice[0],
+		r.ChallengeDice[1],
+		t.Outcome(r.Outcome),
+	) + formatID(r.ID)
+}
+
+// formatRerolls renders the reroll audit trail of an action roll,
+// one line per rerolled die. It is empty for a roll never rerolled.
+//
+// Format:
+// 🔁 action die 2 → 6
+func formatRerolls(rerolls []roll.Reroll) string {
+	var b strings.Builder
+	for _, rr := range rerolls {
+		fmt.Fprintf(&b, "\n🔁 %s %d → %d", slotName(rr.Die), rr.Original, rr.Replacement)
+	}
+	return b.String()
 }
 
-// ironswornOutcome maps internal outcome categories
-// to canonical Ironsworn terminology.
-func ironswornOutcome(r roll.Result) string {
-	switch r.Outcome {
-	case roll.CriticalSuccess:
-		return "Strong Hit (Match)"
-	case roll.Success:
-		return "Strong Hit"
-	case roll.PartialSuccess:
-		return "Weak Hit"
-	case roll.Failure:
-		return "Miss"
-	case roll.CriticalFailure:
-		return "Miss (Match)"
+// slotName names a die of an action roll, e.g. "challenge die 1".
+func slotName(s roll.DieSlot) string {
+	switch s {
+	case roll.SlotChallenge1:
+		return "challenge die 1"
+	case roll.SlotChallenge2:
+		return "challenge die 2"
 	default:
-		return string(r.Outcome)
+		return "action die"
+	}
+}
+
+// formatAssetBonus renders the note naming the assets whose abilities
+// added to a move roll. It is empty when none did.
+//
+// Format:
+// 🃏 +1 from Hound
+func formatAssetBonus(add int, assets []string) string {
+	if len(assets) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n🃏 %+d from %s", add, strings.Join(assets, ", "))
+}
+
+// formatMoveResult renders a named move: a heading with the move name
+// and stat, the rendered roll line, and the move text for the outcome.
+//
+// Format:
+// Move Name +stat
+// 🎲 ... → Ironsworn result
+// Outcome text
+func formatMoveResult(m move.Move, stat move.Stat, line string, o roll.Outcome) string {
+	heading := m.Name
+	if stat != "" {
+		heading += " +" + string(stat)
+	}
+	return heading + "\n" + line + "\n" + m.OutcomeText(o)
+}
+
+// formatStatResult renders an action roll made with a stat.
+//
+// Format:
+// +stat: 🎲 ... → Ironsworn result
+func formatStatResult(stat move.Stat, r roll.Result, t ruleset.Terms) string {
+	return "+" + string(stat) + ": " + formatResult(r, t)
+}
+
+// formatSheet renders a character sheet as plain text, finding the
+// character's assets with lookup.
+func formatSheet(s character.Sheet, lookup func(string) (asset.Asset, bool)) string {
+	name := s.Name
+	if name == "" {
+		name = "Unnamed character"
+	}
+
+	text := fmt.Sprintf(
+		"%s\n"+
+			"edge %d · heart %d · iron %d · shadow %d · wits %d\n"+
+			"health %d · spirit %d · supply %d\n"+
+			"momentum %+d (max %d, reset %d)",
+		name,
+		s.Edge, s.Heart, s.Iron, s.Shadow, s.Wits,
+		s.Health, s.Spirit, s.Supply,
+		s.Momentum, s.MaxMomentum(), s.MomentumReset(),
+	)
+
+	if len(s.Debilities) > 0 {
+		names := make([]string, len(s.Debilities))
+		for i, d := range s.Debilities {
+			names[i] = string(d)
+		}
+		text += "\ndebilities: " + strings.Join(names, ", ")
+	}
+	for _, c := range s.Assets {
+		text += "\n" + formatCard(c, lookup)
+	}
+	if s.XPEarned > 0 {
+		text += fmt.Sprintf("\nxp %d (earned %d, spent %d)", s.XP(), s.XPEarned, s.XPSpent)
 	}
+	for _, l := range character.AllLegacies {
+		if t, ok := s.Legacies[l]; ok {
+			text += "\n" + formatLegacy(l, t)
+		}
+	}
+	return text
+}
+
+// formatLegacy renders a legacy track on one line, with ✓ once the
+// track has been filled.
+//
+// Format:
+// quests 3/10 (+2/4) ✓
+func formatLegacy(l character.Legacy, t character.LegacyTrack) string {
+	text := fmt.Sprintf("%s %d/%d", l, t.Score(), track.Boxes)
+	if ticks := t.Ticks % track.TicksPerBox; ticks > 0 {
+		text += fmt.Sprintf(" (+%d/%d)", ticks, track.TicksPerBox)
+	}
+	if t.Completed {
+		text += " ✓"
+	}
+	return text
+}
+
+// formatCard renders an asset card on one line: the asset name, its
+// abilities as marked (●) or unmarked (○) and its tracks.
+//
+// Format:
+// 🃏 Hound ●○○ · health 4/4
+func formatCard(c asset.Card, lookup func(string) (asset.Asset, bool)) string {
+	a, ok := lookup(c.Asset)
+	if !ok {
+		return "🃏 " + c.Asset + " (unknown asset)"
+	}
+
+	var b strings.Builder
+	b.WriteString("🃏 " + a.Name + " ")
+	for i := range a.Abilities {
+		if c.Marked(i) {
+			b.WriteString("●")
+		} else {
+			b.WriteString("○")
+		}
+	}
+	for _, t := range a.Tracks {
+		fmt.Fprintf(&b, " · %s %d/%d", t.Name, c.Track(t), t.Max)
+	}
+	return b.String()
+}
+
+// formatCampaign renders a campaign played under a ruleset as plain text.
+func formatCampaign(c campaign.Campaign, rs *ruleset.Ruleset) string {
+	return fmt.Sprintf("%s (id %s)\nruleset: %s\ncharacters: %d", c.Name, c.ID, rs.Name, len(c.Members))
+}
+
+// formatOdds renders the exact outcome odds of a roll in the
+// ruleset's terms, one outcome per line, strong hits first.
+//
+// Format:
+// Strong Hit 33.2% (Match 5.5%)
+// Weak Hit 43.7%
+// Miss 23.2% (Match 4.5%)
+func formatOdds(o roll.Odds, t ruleset.Terms) string {
+	return fmt.Sprintf(
+		"%s %s (Match %s)\n%s %s\n%s %s (Match %s)",
+		t.StrongHit,
+		percent(o.StrongHit()),
+		percent(o.Probability(roll.CriticalSuccess)),
+		t.WeakHit,
+		percent(o.WeakHit()),
+		t.Miss,
+		percent(o.Miss()),
+		percent(o.Probability(roll.CriticalFailure)),
+	)
+}
+
+// percent renders a probability as a percentage with one decimal.
+func percent(p float64) string {
+	return fmt.Sprintf("%.1f%%", p*100)
+}
+
+// formatDice renders a single-line dice expression result. Dropped
+// dice are shown in parentheses and exploded dice are marked with "!".
+//
+// Format:
+// 🎲 expression: [die, die, (dropped)] + constant = total
+func formatDice(r dice.Result) string {
+	var b strings.Builder
+	for i, t := range r.Terms {
+		switch {
+		case t.Term.Negative:
+			b.WriteString(" - ")
+		case i > 0:
+			b.WriteString(" + ")
+		}
+		if t.Term.IsConstant() {
+			b.WriteString(strconv.Itoa(t.Term.Constant))
+			continue
+		}
+		faces := make([]string, len(t.Dice))
+		for j, d := range t.Dice {
+			faces[j] = d.String()
+			if d.Dropped {
+				faces[j] = "(" + faces[j] + ")"
+			}
+		}
+		b.WriteString("[" + strings.Join(faces, ", ") + "]")
+	}
+	return fmt.Sprintf("🎲 %s: %s = %d", r.Expr, b.String(), r.Total)
+}
+
+// formatAnswer renders a single-line "Ask the Oracle" result.
+//
+// Format:
+// 🔮 odds (roll) → answer
+func formatAnswer(a oracle.Answer) string {
+	return fmt.Sprintf("🔮 %s (%d) → %s", a.Odds, a.Roll, a)
+}
+
+// formatOracleResult renders a single-line oracle table result.
+//
+// Format:
+// 🔮 table (roll) → result
+func formatOracleResult(r oracle.Result) string {
+	return fmt.Sprintf("🔮 %s (%d) → %s", r.Table.Name, r.Roll, r.Result)
 }
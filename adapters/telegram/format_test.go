@@ -4,7 +4,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/mtzvd/ironroll/core/dice"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
 )
 
 func TestFormatResultContainsFields(t *testing.T) {
@@ -16,7 +20,7 @@ func TestFormatResultContainsFields(t *testing.T) {
 		Outcome:       roll.Failure,
 	}
 
-	s := formatResult(r)
+	s := formatResult(r, ruleset.ClassicTerms)
 
 	// formatResult returns compact format: 🎲 (action +mod) vs (c1 & c2) → Outcome
 	checks := []string{
@@ -35,3 +39,98 @@ func TestFormatResultContainsFields(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatProgressResultContainsFields(t *testing.T) {
+	r := roll.ProgressResult{
+		ProgressScore: 7,
+		ChallengeDice: [2]int{4, 4},
+		Outcome:       roll.CriticalSuccess,
+	}
+
+	s := formatProgressResult(r, ruleset.ClassicTerms)
+
+	checks := []string{
+		"📈",
+		"7", // progress score
+		"4", // challenge dice
+		"vs",
+		"Strong Hit (Match)",
+	}
+
+	for _, c := range checks {
+		if !strings.Contains(s, c) {
+			t.Fatalf("formatted progress result missing %q in %q", c, s)
+		}
+	}
+}
+
+func TestFormatResultMomentumNotes(t *testing.T) {
+	base := roll.Result{
+		ActionDie:     3,
+		Modifier:      1,
+		ChallengeDice: [2]int{5, 8},
+		Total:         4,
+		Outcome:       roll.Failure,
+	}
+
+	burn := base
+	burn.Momentum = &roll.MomentumEffect{Value: 9, CanBurn: true, BurnOutcome: roll.Success}
+	if s := formatResult(burn, ruleset.ClassicTerms); !strings.HasSuffix(s, "· burn → Strong Hit") {
+		t.Fatalf("expected burn hint in %q", s)
+	}
+
+	cancelled := base
+	cancelled.Momentum = &roll.MomentumEffect{Value: -3, Cancelled: true}
+	if s := formatResult(cancelled, ruleset.ClassicTerms); !strings.Contains(s, "action die cancelled (momentum -3)") {
+		t.Fatalf("expected cancellation note in %q", s)
+	}
+
+	quiet := base
+	quiet.Momentum = &roll.MomentumEffect{Value: 2}
+	if s := formatResult(quiet, ruleset.ClassicTerms); strings.Contains(s, "·") {
+		t.Fatalf("expected no momentum note in %q", s)
+	}
+}
+
+func TestFormatAnswer(t *testing.T) {
+	a := oracle.Answer{Odds: oracle.Likely, Roll: 22, Yes: false, Match: true}
+
+	if got, want := formatAnswer(a), "🔮 Likely (22) → Extreme No"; got != want {
+		t.Fatalf("formatAnswer = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDice(t *testing.T) {
+	e, err := dice.Parse("4d6kh3!-d4+1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := dice.Result{
+		Expr: e,
+		Terms: []dice.TermResult{
+			{Term: e.Terms[0], Dice: []dice.Die{{Value: 6, Exploded: true}, {Value: 2, Dropped: true}, {Value: 5}, {Value: 3}, {Value: 1, Dropped: true}}, Value: 14},
+			{Term: e.Terms[1], Dice: []dice.Die{{Value: 3}}, Value: -3},
+			{Term: e.Terms[2], Value: 1},
+		},
+		Total: 12,
+	}
+
+	want := "🎲 4d6!kh3-1d4+1: [6!, (2), 5, 3, (1)] - [3] + 1 = 12"
+	if got := formatDice(r); got != want {
+		t.Fatalf("formatDice = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMoveResult(t *testing.T) {
+	m := move.Move{Name: "Face Danger", StrongHit: "strong", WeakHit: "weak", Miss: "miss"}
+
+	got := formatMoveResult(m, move.Edge, "🎲 line", roll.PartialSuccess)
+	if want := "Face Danger +edge\n🎲 line\nweak"; got != want {
+		t.Fatalf("formatMoveResult = %q; want %q", got, want)
+	}
+
+	got = formatMoveResult(m, "", "📈 line", roll.CriticalFailure)
+	if want := "Face Danger\n📈 line\nmiss"; got != want {
+		t.Fatalf("formatMoveResult = %q; want %q", got, want)
+	}
+}
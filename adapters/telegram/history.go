@@ -0,0 +1,134 @@
+package telegram
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// Inline roll records
+//
+// An inline roll is added to the roll history only once the user
+// sends it, which Telegram reports with the ID of the chosen result.
+// The report may reach another instance than the inline query did, so
+// the result ID carries the roll itself rather than a key to state
+// kept by the instance that rolled it:
+//
+//	<unique>;<kind>;<ruleset>;<move key>;<stat>;<dice>;<roll id>
+//
+// where unique makes the ID unique (see inlineArticles), kind is a
+// for an action roll or p for a progress roll, and dice is the roll in
+// roll.EncodeDice or roll.EncodeProgress form. Results that are not
+// rolls have only the unique part. Telegram reports only results the
+// bot offered, so the IDs need no signature.
+//
+// Telegram allows at most 64 bytes of result ID; when a long move key
+// does not fit it is left out, then the roll ID, and the history keeps
+// the roll without them.
+
+// Result ID limit and roll kinds.
+const (
+	maxResultID = 64
+
+	actionKind   = "a"
+	progressKind = "p"
+)
+
+// HandleChosenInlineResult records the roll of an inline result the
+// user sent in the roll history.
+//
+// Telegram only reports chosen results when inline feedback is
+// enabled for the bot (/setinlinefeedback in @BotFather).
+func (h *Handler) HandleChosenInlineResult(result *tgbotapi.ChosenInlineResult) {
+	if result == nil {
+		return
+	}
+	if rec, ok := h.decodeResultID(result.ResultID); ok {
+		rec.Platform = history.Telegram
+		rec.User = userOwner(result.From)
+		h.record(rec)
+	}
+}
+
+// record adds an entry to the roll history if a roll was made.
+// Failures are logged; they never affect the reply.
+func (h *Handler) record(e history.Entry) {
+	if !e.Rolled() {
+		return
+	}
+	e.Time = time.Now().UTC()
+	if err := h.history.Add(e); err != nil {
+		slog.Error("telegram history add failed", "err", err)
+	}
+}
+
+// resultID returns the ID of an inline result: unique, followed by
+// the roll recorded in rec, made under the ruleset rs, if any.
+func (h *Handler) resultID(unique string, rs ruleset.ID, rec history.Entry) string {
+	var kind, dice string
+	switch rec.Kind {
+	case roll.KindAction:
+		kind, dice = actionKind, roll.EncodeDice(entryResult(rec))
+	case roll.KindProgress:
+		kind, dice = progressKind, roll.EncodeProgress(roll.ProgressResult{ProgressScore: rec.Progress, ChallengeDice: rec.ChallengeDice})
+	default:
+		return unique
+	}
+
+	key := ""
+	if m, ok := h.rulesets.Select(string(rs)).Move(rec.Move); ok && rec.Move != "" {
+		key = m.Key
+	}
+	encode := func(key, rollID string) string {
+		return strings.Join([]string{unique, kind, string(rs), key, rec.Stat, dice, rollID}, ";")
+	}
+
+	id := encode(key, rec.RollID)
+	if len(id) > maxResultID {
+		id = encode("", rec.RollID)
+	}
+	if len(id) > maxResultID {
+		id = encode("", "")
+	}
+	return id
+}
+
+// decodeResultID restores the roll carried by the ID of an inline
+// result. It reports false for results that are not rolls.
+func (h *Handler) decodeResultID(id string) (history.Entry, bool) {
+	parts := strings.Split(id, ";")
+	if len(parts) != 7 {
+		return history.Entry{}, false
+	}
+	rs := h.rulesets.Select(parts[2])
+	rec := history.Entry{Stat: parts[4]}
+
+	switch parts[1] {
+	case actionKind:
+		r, err := roll.DecodeDice(parts[5])
+		if err != nil {
+			return history.Entry{}, false
+		}
+		rec.SetAction(r)
+	case progressKind:
+		r, err := roll.DecodeProgress(parts[5])
+		if err != nil {
+			return history.Entry{}, false
+		}
+		rec.SetProgress(r)
+	default:
+		return history.Entry{}, false
+	}
+	rec.RollID = parts[6]
+
+	if m, ok := rs.Move(parts[3]); ok && parts[3] != "" {
+		rec.Move = m.ID
+	}
+	return rec, true
+}
@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestChosenInlineResultIsRecorded(t *testing.T) {
+	ring := history.NewRing(10)
+	h := NewHandler(Config{History: ring})
+
+	_, _, rec := h.answer("telegram:1", "strike iron +2 m3")
+	if rec.Move != "classic/moves/combat/strike" || rec.Stat != "iron" || rec.Modifier != 2 {
+		t.Fatalf("unexpected entry %+v", rec)
+	}
+	id := h.resultID("x-0", "classic", rec)
+
+	// Another instance with the same rulesets records the roll.
+	other := NewHandler(Config{History: ring})
+	other.HandleChosenInlineResult(&tgbotapi.ChosenInlineResult{ResultID: id, From: &tgbotapi.User{ID: 1}})
+	other.HandleChosenInlineResult(&tgbotapi.ChosenInlineResult{ResultID: "x-1", From: &tgbotapi.User{ID: 1}})
+	other.HandleChosenInlineResult(&tgbotapi.ChosenInlineResult{ResultID: "x-2;a;classic;;;9,9,9,9;", From: &tgbotapi.User{ID: 1}})
+
+	got, _ := ring.Query(history.Filter{})
+	if len(got) != 1 || got[0].Time.IsZero() {
+		t.Fatalf("history = %+v; want one recorded roll", got)
+	}
+	got[0].Time = rec.Time
+	if !reflect.DeepEqual(got[0], rec) {
+		t.Fatalf("recorded %+v; want %+v", got[0], rec)
+	}
+
+	// Oracle questions are not rolls.
+	if _, _, rec := h.answer("telegram:1", "? likely"); rec.Rolled() {
+		t.Fatalf("oracle answer produced entry %+v", rec)
+	}
+}
+
+func TestResultIDFitsTelegram(t *testing.T) {
+	h := NewHandler(Config{})
+
+	progress := history.Entry{Platform: history.Telegram, User: "telegram:1", Move: "classic/moves/quest/fulfill_your_vow"}
+	progress.SetProgress(roll.ProgressResult{ProgressScore: 7, ChallengeDice: [2]int{3, 9}, Outcome: roll.PartialSuccess})
+	if rec, ok := h.decodeResultID(h.resultID("x-0", "classic", progress)); !ok || !reflect.DeepEqual(rec, history.Entry{
+		Move: progress.Move, Kind: progress.Kind, Progress: 7, ChallengeDice: [2]int{3, 9}, Total: 7, Outcome: roll.PartialSuccess,
+	}) {
+		t.Fatalf("progress roll = %+v, %v", rec, ok)
+	}
+
+	// A verifiable roll of a long move name drops the move, then the
+	// roll ID.
+	_, _, rec := h.answer("telegram:1", "starforged undertake an expedition +2")
+	if rec.Move == "" {
+		t.Fatalf("unexpected entry %+v", rec)
+	}
+	rec.RollID = strings.Repeat("f", 12) + "-action-1"
+	id := h.resultID(strings.Repeat("z", 15), "starforged", rec)
+	got, ok := h.decodeResultID(id)
+	if len(id) > maxResultID || !ok || got.Move != "" || got.RollID != rec.RollID || got.Total != rec.Total {
+		t.Fatalf("resultID = %q (%d bytes); decoded %+v, %v", id, len(id), got, ok)
+	}
+	rec.RollID += strings.Repeat("0", 20)
+	if id := h.resultID(strings.Repeat("z", 15), "starforged", rec); len(id) > maxResultID {
+		t.Fatalf("resultID = %q (%d bytes)", id, len(id))
+	}
+}
@@ -1,6 +1,9 @@
 package telegram
 
 import (
+	"crypto/rand"
+	"errors"
+	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
@@ -8,9 +11,100 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
 )
 
+// Config holds the dependencies of the Telegram adapter.
+type Config struct {
+	// Roller performs all dice rolls. Defaults to roll.Default().
+	Roller *roll.Roller
+
+	// Moves holds the named moves of classic Ironsworn.
+	// Defaults to move.Builtin().
+	Moves *move.Catalog
+
+	// Rulesets holds the games a roll can be played under.
+	// Defaults to ruleset.Builtin with Moves as the classic moves.
+	Rulesets *ruleset.Registry
+
+	// Characters stores character sheets.
+	// Defaults to an in-memory store.
+	Characters character.Store
+
+	// Campaigns stores campaigns and their chat bindings.
+	// Defaults to an in-memory store.
+	Campaigns campaign.Store
+
+	// History records every roll.
+	// Defaults to an in-memory history.Ring.
+	History history.Store
+
+	// CallbackKey signs the data of roll buttons (see
+	// HandleCallbackQuery). Instances serving the same bot must share
+	// it. Defaults to a random key, so the buttons of messages sent
+	// before a restart stop working.
+	CallbackKey []byte
+}
+
+// Handler answers Telegram updates.
+//
+// A Handler is safe for concurrent use as long as its dependencies are.
+// Its only state of its own are the roll buttons already pressed.
+type Handler struct {
+	roller     *roll.Roller
+	rulesets   *ruleset.Registry
+	characters character.Store
+	campaigns  campaign.Store
+	history    history.Store
+	used       *usedButtons
+
+	callbackKey []byte
+}
+
+// NewHandler creates a Handler from the given dependencies.
+func NewHandler(cfg Config) *Handler {
+	if cfg.Roller == nil {
+		cfg.Roller = roll.Default()
+	}
+	if cfg.Moves == nil {
+		cfg.Moves = move.Builtin()
+	}
+	if cfg.Rulesets == nil {
+		cfg.Rulesets = ruleset.Builtin(oracle.Builtin(), cfg.Moves)
+	}
+	if cfg.Characters == nil {
+		cfg.Characters = character.NewMemoryStore()
+	}
+	if cfg.Campaigns == nil {
+		cfg.Campaigns = campaign.NewMemoryStore()
+	}
+	if cfg.History == nil {
+		cfg.History = history.NewRing(history.DefaultCapacity)
+	}
+	if len(cfg.CallbackKey) == 0 {
+		cfg.CallbackKey = make([]byte, 32)
+		// crypto/rand.Read never fails.
+		rand.Read(cfg.CallbackKey)
+	}
+
+	return &Handler{
+		roller:     cfg.Roller,
+		rulesets:   cfg.Rulesets,
+		characters: cfg.Characters,
+		campaigns:  cfg.Campaigns,
+		history:    cfg.History,
+		used:       newUsedButtons(maxUsedButtons),
+
+		callbackKey: cfg.CallbackKey,
+	}
+}
+
 // Telegram Inline Behavior
 //
 // The bot responds only to explicit inline queries.
@@ -19,6 +113,58 @@ import (
 // A roll is performed only when a modifier is explicitly provided.
 // To roll with no modifier, the user must specify 0 or +0.
 //
+// The current momentum may be added with an "m" token, e.g. "+2 m5"
+// or "+1 m-3". The result then shows the negative momentum
+// cancellation and a "burn → Strong Hit" hint when burning would help.
+//
+// A progress roll is requested with a "p" or "progress" prefix
+// followed by the progress score, e.g. "p7" or "progress 7".
+//
+// A stat may take the place of the modifier, e.g. "+wits" or
+// "wits +1 m3". The stat value and momentum then come from the
+// user's character sheet (see HandleMessage); a number after the stat
+// is added to it, and an "m" token overrides the sheet's momentum.
+// Without a sheet the stat is only a label and the number is the
+// whole modifier. Inline queries carry no chat, so they always use
+// the user's personal sheet, never a campaign character.
+//
+// A query may start with a move name, e.g. "face danger +2" or
+// "strike +iron m4". An optional stat follows the name and is
+// checked against the move. Progress moves take the progress score,
+// e.g. "fulfill your vow 7". The result includes the move's text for
+// the outcome.
+//
+// A query may start with the ruleset to roll under, e.g.
+// "starforged face danger +2" or "sf +2", which selects the ruleset's
+// moves and outcome names. Classic Ironsworn is the default.
+//
+// A yes/no question for the oracle starts with "?", optionally
+// followed by the odds, e.g. "? likely" or "? small chance".
+// A bare "?" asks with 50/50 odds.
+//
+// "odds" followed by a modifier, e.g. "odds +3", or a progress score,
+// e.g. "odds p7", shows the exact chance of each outcome and rolls
+// nothing.
+//
+// A query is parsed strictly (see parseRollArgs for the grammar). One
+// that cannot be understood, such as "face dangr +2", "+2 mx" or
+// "p12", is answered with a single "Couldn't understand" article
+// naming the problem and showing queries that work; nothing is rolled.
+//
+// A query starting with a die, e.g. "2d10+1", "4d6kh3", "d66" or
+// "d%", is rolled as a dice expression (see core/dice). Such rolls
+// are not Ironsworn rolls and are not added to the roll history.
+//
+// Besides the answer to the query, the inline menu offers a few more
+// results rolled at the same time (see answers): a named move rolled
+// as a plain roll of the same dice, a progress roll when the query is
+// a bare score from 0 to 10 such as "7", and an "Ask the Oracle"
+// question with 50/50 odds whenever the query rolls.
+//
+// Telegram sends a new inline query on every keystroke, and each one
+// rolls. A roll is therefore added to the roll history only when the
+// user sends it (see HandleChosenInlineResult).
+//
 // RANDOM INLINE RESULTS (IMPORTANT)
 //
 // This bot produces non-deterministic (random) inline results.
@@ -27,11 +173,12 @@ import (
 //
 //  1. cache_time = 0        → disables server-side caching
 //  2. IsPersonal = true     → marks results as user-specific
-//  3. unique result_id      → prevents client-side reuse
+//  3. unique result_id      → prevents client-side reuse, for
+//     every result of every answer
 //
 // Failing to satisfy ALL THREE will cause Telegram clients to
 // reinsert the same inline result repeatedly.
-func HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
+func (h *Handler) HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
 	slog.Info(
 		"telegram inline query",
 		"query_id", query.ID,
@@ -49,27 +196,13 @@ func HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
 		return
 	}
 
-	modifier := parseModifier(query.Query)
-
-	// Perform the roll during InlineQuery handling
+	// Perform the rolls during InlineQuery handling
 	// (same model as rollrobot).
-	result := roll.Roll(modifier)
-
-	text := formatResult(result)
-
-	// Generate a unique result ID for every response.
-	// Time-based uniqueness is sufficient and avoids extra dependencies.
-	resultID := strconv.FormatInt(time.Now().UnixNano(), 10)
-
-	article := tgbotapi.NewInlineQueryResultArticle(
-		resultID,
-		"Ironsworn Roll",
-		text,
-	)
+	results := h.answers(query.From, query.Query)
 
 	cfg := tgbotapi.InlineConfig{
 		InlineQueryID: query.ID,
-		Results:       []interface{}{article},
+		Results:       h.inlineArticles(results, time.Now()),
 		CacheTime:     0,    // disable server-side caching
 		IsPersonal:    true, // CRITICAL for random inline bots
 	}
@@ -79,16 +212,261 @@ func HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
 	}
 }
 
-func parseModifier(raw string) int {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return 0
+// inlineResult is one result offered for an inline query.
+type inlineResult struct {
+	title    string
+	text     string
+	rec      history.Entry                  // The roll made, if any
+	ruleset  ruleset.ID                     // The ruleset rec was rolled under
+	keyboard *tgbotapi.InlineKeyboardMarkup // Reroll buttons, if any
+}
+
+// inlineArticles turns results into inline articles whose result IDs
+// carry their rolls (see HandleChosenInlineResult).
+//
+// Every article gets a unique result ID, derived from now and its
+// position. Time-based uniqueness is sufficient and avoids extra
+// dependencies.
+func (h *Handler) inlineArticles(results []inlineResult, now time.Time) []interface{} {
+	base := strconv.FormatInt(now.UnixNano(), 36)
+
+	articles := make([]interface{}, len(results))
+	for i, res := range results {
+		// The roll is recorded only once the user sends it.
+		resultID := h.resultID(base+"-"+strconv.Itoa(i), res.ruleset, res.rec)
+
+		article := tgbotapi.NewInlineQueryResultArticle(resultID, res.title, res.text)
+		// Action rolls carry reroll buttons (see HandleCallbackQuery).
+		article.ReplyMarkup = res.keyboard
+		articles[i] = article
 	}
+	return articles
+}
 
-	m, err := strconv.Atoi(raw)
+// answers performs the rolls offered for an inline query from the
+// user: the answer to the query first, then the alternatives described
+// at HandleInlineQuery.
+func (h *Handler) answers(from *tgbotapi.User, q string) []inlineResult {
+	var user int64
+	if from != nil {
+		user = from.ID
+	}
+	owner := userOwner(from)
+	rs, rest := h.ruleset(h.rulesets.Default(), q)
+	title, text, rec := h.answer(owner, q)
+	results := []inlineResult{{title, text, rec, rs.ID, h.entryKeyboard(rs, user, rec)}}
+
+	// A named move is also offered as a plain roll of the same dice.
+	if rec.Kind == roll.KindAction && rec.Move != "" {
+		plain := rec
+		plain.Move = ""
+		r := entryResult(rec)
+		title, text := "Ironsworn Roll", formatResult(r, rs.Terms)
+		if rec.Stat != "" {
+			title += " +" + rec.Stat
+			text = formatStatResult(move.Stat(rec.Stat), r, rs.Terms)
+		}
+		results = append(results, inlineResult{title, text, plain, rs.ID, h.entryKeyboard(rs, user, plain)})
+	}
+
+	// A bare score such as "7" may be meant as progress.
+	if score, ok := parseBareScore(rest); ok {
+		r := h.roller.ProgressRoll(score)
+		progress := history.Entry{Platform: history.Telegram, User: owner}
+		progress.SetProgress(r)
+		results = append(results, inlineResult{"Ironsworn Progress Roll", formatProgressResult(r, rs.Terms), progress, rs.ID, nil})
+	}
+
+	if rec.Rolled() {
+		// Odds are always valid here, so Ask cannot fail.
+		a, _ := oracle.Ask(h.roller, oracle.FiftyFifty)
+		results = append(results, inlineResult{title: "Ask the Oracle 50/50", text: formatAnswer(a)})
+	}
+	return results
+}
+
+// answer performs the roll requested by an inline query of owner
+// and returns the result title and message text, and the history
+// entry of the roll, if one was made.
+func (h *Handler) answer(owner, q string) (string, string, history.Entry) {
+	return h.answerUnder(owner, h.rulesets.Default(), q)
+}
+
+// answerUnder is answer with def as the ruleset of a query that
+// names none.
+func (h *Handler) answerUnder(owner string, def *ruleset.Ruleset, q string) (string, string, history.Entry) {
+	rec := history.Entry{Platform: history.Telegram, User: owner}
+	rs, q := h.ruleset(def, q)
+
+	if n, progress, ok, err := parseOdds(q); ok {
+		switch {
+		case err != nil:
+			title, text := misunderstood(q, err)
+			return title, text, rec
+		case progress:
+			return fmt.Sprintf("Odds: progress %d", n), formatOdds(roll.ProgressOdds(n), rs.Terms), rec
+		}
+		return fmt.Sprintf("Odds: %+d", n), formatOdds(roll.ActionOdds(n), rs.Terms), rec
+	}
+
+	if odds, ok, err := parseAsk(q); ok {
+		if err != nil {
+			title, text := misunderstood(q, err)
+			return title, text, rec
+		}
+		// The odds were parsed by parseAsk, so Ask cannot fail.
+		a, _ := oracle.Ask(h.roller, odds)
+		return "Ask the Oracle", formatAnswer(a), rec
+	}
+
+	if e, ok, err := parseDice(q); ok {
+		if err != nil {
+			title, text := misunderstood(q, errors.New(strings.TrimPrefix(err.Error(), "dice: ")))
+			return title, text, rec
+		}
+		return "Dice: " + e.String(), formatDice(e.Roll(h.roller)), rec
+	}
+
+	if score, ok, err := parseProgress(q); ok {
+		if err != nil {
+			title, text := misunderstood(q, err)
+			return title, text, rec
+		}
+		r := h.roller.ProgressRoll(score)
+		rec.SetProgress(r)
+		return "Ironsworn Progress Roll", formatProgressResult(r, rs.Terms), rec
+	}
+
+	if m, rest, ok := rs.MatchMove(q); ok {
+		text, err := h.rollMove(&rec, owner, rs, m, rest)
+		if err != nil {
+			title, text := misunderstood(q, err)
+			return title, text, rec
+		}
+		return m.Name, text, rec
+	}
+
+	args, err := parseRollArgs(q)
+	if err != nil {
+		title, text := misunderstood(q, err)
+		return title, text, rec
+	}
+	r, stat, _, problem := h.actionRoll(owner, nil, args)
+	if problem != "" {
+		return "Ironsworn Roll", problem, rec
+	}
+	rec.Stat = string(stat)
+	rec.SetAction(r)
+	if stat != "" {
+		return "Ironsworn Roll +" + string(stat), formatStatResult(stat, r, rs.Terms), rec
+	}
+	return "Ironsworn Roll", formatResult(r, rs.Terms), rec
+}
+
+// misunderstood returns the title and text of the answer to a query
+// that could not be parsed: what went wrong and queries that work.
+// Nothing is rolled for such a query.
+func misunderstood(q string, err error) (string, string) {
+	q = strings.TrimSpace(q)
+	return fmt.Sprintf("Couldn't understand %q", q),
+		fmt.Sprintf("Couldn't understand %q: %s. Try +2, +wits m3, face danger +2, p7, ? likely or 2d6.", q, err)
+}
+
+// ruleset returns the ruleset named at the start of an inline query,
+// or def, and the rest of the query. A leading word that begins a
+// move name of the ruleset, as in "delve the depths", is kept.
+func (h *Handler) ruleset(def *ruleset.Ruleset, q string) (*ruleset.Ruleset, string) {
+	id, rest, ok := splitRuleset(q)
+	if !ok {
+		return def, q
+	}
+	rs, ok := h.rulesets.Get(id)
+	if !ok {
+		return def, q
+	}
+	if _, _, named := rs.MatchMove(q); named {
+		return rs, q
+	}
+	return rs, rest
+}
+
+// rollMove performs a named move with the arguments that followed
+// its name under a ruleset and returns the message text. The roll is
+// noted in rec. It fails when the arguments of an action move cannot
+// be parsed (see parseRollArgs).
+func (h *Handler) rollMove(rec *history.Entry, owner string, rs *ruleset.Ruleset, m move.Move, raw string) (string, error) {
+	if m.Progress {
+		score, ok := parseScore(raw)
+		if !ok {
+			return fmt.Sprintf("%s needs a progress score from %d to %d.",
+				m.Name, roll.MinProgressScore, roll.MaxProgressScore), nil
+		}
+		r := h.roller.ProgressRoll(score)
+		rec.Move = m.ID
+		rec.SetProgress(r)
+		return formatMoveResult(m, "", formatProgressResult(r, rs.Terms), r.Outcome), nil
+	}
+
+	args, err := parseRollArgs(raw)
 	if err != nil {
-		return 0
+		return "", err
+	}
+	r, stat, note, problem := h.actionRoll(owner, &m, args)
+	if problem != "" {
+		return problem, nil
 	}
+	rec.Move = m.ID
+	rec.Stat = string(stat)
+	rec.SetAction(r)
+	return formatMoveResult(m, stat, formatResult(r, rs.Terms)+note, r.Outcome), nil
+}
+
+// actionRoll performs an action roll from parsed query arguments such
+// as "+2", "+2 m5", "+wits" or "wits +1 m3", resolving a stat from the
+// character sheet of owner. When m is not nil the stat is checked
+// against the move.
+//
+// A move rolled with a stat from the sheet also gets the bonuses of
+// the character's assets for that move and stat; the returned note
+// names the assets, and is empty when none applied.
+//
+// It returns the roll and the stat used, or a message explaining
+// why no roll was made.
+func (h *Handler) actionRoll(owner string, m *move.Move, args rollArgs) (roll.Result, move.Stat, string, string) {
+	stat, adds := args.stat, args.modifier
 
-	return m
+	if stat != "" && m != nil {
+		if err := m.CheckStat(stat); err != nil {
+			return roll.Result{}, "", "", err.Error() + "."
+		}
+	}
+
+	if stat != "" {
+		sheet, err := h.characters.Get(owner)
+		switch {
+		case err == nil:
+			if args.hasMomentum {
+				sheet.Momentum = args.momentum
+			}
+			note := ""
+			if m != nil {
+				bonus, assets := sheet.AssetBonus(h.rulesets.Asset, m.Key, stat)
+				adds += bonus
+				note = formatAssetBonus(bonus, assets)
+			}
+			// The stat was parsed by parseRollArgs, so Roll cannot fail.
+			r, _ := sheet.Roll(h.roller, stat, adds)
+			return r, stat, note, ""
+		case !errors.Is(err, character.ErrNotFound):
+			slog.Error("telegram character lookup failed", "owner", owner, "err", err)
+			return roll.Result{}, "", "", "Your character sheet could not be loaded."
+		case !args.hasModifier:
+			return roll.Result{}, "", "", "You have no character sheet yet. Send /character to the bot to create one, or give the modifier, e.g. +" + string(stat) + " 2."
+		}
+	}
+
+	if args.hasMomentum {
+		return h.roller.RollWithMomentum(adds, args.momentum), stat, "", ""
+	}
+	return h.roller.Roll(adds), stat, "", ""
 }
@@ -1,9 +1,16 @@
 package telegram
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/roll"
 )
 
 // fakeBot is a minimal stub for testing.
@@ -24,7 +31,7 @@ func TestHandleInlineQuery_EmptyQueryDoesNothing(t *testing.T) {
 		Query: "",
 	}
 
-	HandleInlineQuery((*tgbotapi.BotAPI)(nil), query)
+	NewHandler(Config{}).HandleInlineQuery((*tgbotapi.BotAPI)(nil), query)
 
 	if bot.called {
 		t.Fatal("expected no request for empty inline query")
@@ -39,5 +46,282 @@ func TestHandleInlineQuery_WithModifierResponds(t *testing.T) {
 		Query: "+1",
 	}
 
-	HandleInlineQuery((*tgbotapi.BotAPI)(nil), query)
+	NewHandler(Config{}).HandleInlineQuery((*tgbotapi.BotAPI)(nil), query)
+}
+
+func TestAnswerNamedMove(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	cases := []struct {
+		query string
+		title string
+		want  string
+	}{
+		{"face danger +2", "Face Danger", "Face Danger\n🎲"},
+		{"Strike iron +3 m4", "Strike", "Strike +iron\n🎲"},
+		{"strike heart +3", "Strike", "Strike is rolled with iron or edge, not heart."},
+		{"fulfill your vow 7", "Fulfill Your Vow", "Fulfill Your Vow\n📈 7"},
+		{"fulfill your vow", "Fulfill Your Vow", "Fulfill Your Vow needs a progress score"},
+	}
+
+	for _, c := range cases {
+		title, text, _ := h.answer("telegram:1", c.query)
+		if title != c.title || !strings.HasPrefix(text, c.want) {
+			t.Fatalf("answer(%q) = %q, %q; want %q, prefix %q", c.query, title, text, c.title, c.want)
+		}
+	}
+}
+
+func TestAnswerOdds(t *testing.T) {
+	h := NewHandler(Config{})
+
+	title, text, rec := h.answer("telegram:1", "odds +3")
+	want := "Strong Hit 33.2% (Match 5.5%)\nWeak Hit 43.7%\nMiss 23.2% (Match 4.5%)"
+	if title != "Odds: +3" || text != want {
+		t.Fatalf("answer(odds +3) = %q, %q; want %q", title, text, want)
+	}
+	if rec.Rolled() {
+		t.Fatal("odds should not be recorded as a roll")
+	}
+
+	title, text, _ = h.answer("telegram:1", "odds p10")
+	if title != "Odds: progress 10" || !strings.HasSuffix(text, "Miss 1.0% (Match 1.0%)") {
+		t.Fatalf("answer(odds p10) = %q, %q", title, text)
+	}
+}
+
+func TestAnswerDice(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	title, text, rec := h.answer("telegram:1", "2d10+1")
+	if title != "Dice: 2d10+1" || !strings.HasPrefix(text, "🎲 2d10+1: [") {
+		t.Fatalf("answer(2d10+1) = %q, %q", title, text)
+	}
+	if rec.Rolled() {
+		t.Fatal("dice expressions should not be recorded as a roll")
+	}
+
+	title, text, _ = h.answer("telegram:1", "4d6kh9")
+	if title != `Couldn't understand "4d6kh9"` || !strings.HasPrefix(text, `Couldn't understand "4d6kh9": can keep 1 to 4`) {
+		t.Fatalf("answer(4d6kh9) = %q, %q", title, text)
+	}
+}
+
+func TestAnswerRuleset(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	cases := []struct {
+		query string
+		title string
+		want  string
+		move  string
+	}{
+		{"starforged take decisive action 7", "Take Decisive Action", "Take Decisive Action\n📈 7", "starforged/moves/combat/take_decisive_action"},
+		{"sf face danger +2", "Face Danger", "Face Danger\n🎲", "starforged/moves/adventure/face_danger"},
+		{"delve the depths +wits 2", "Delve the Depths", "Delve the Depths +wits\n🎲", "delve/moves/delve/delve_the_depths"},
+		{"delve face danger +2", "Face Danger", "Face Danger\n🎲", "classic/moves/adventure/face_danger"},
+		// Classic Ironsworn has no such move.
+		{"take decisive action 7", `Couldn't understand "take decisive action 7"`, `Couldn't understand "take decisive action 7": "take" is not`, ""},
+	}
+
+	for _, c := range cases {
+		title, text, rec := h.answer("telegram:1", c.query)
+		if title != c.title || !strings.HasPrefix(text, c.want) || rec.Move != c.move {
+			t.Fatalf("answer(%q) = %q, %q, %q; want %q, prefix %q, %q", c.query, title, text, rec.Move, c.title, c.want, c.move)
+		}
+	}
+
+	_, text, _ := h.answer("telegram:1", "sf odds +3")
+	if !strings.HasPrefix(text, "Strong Hit 33.2%") {
+		t.Fatalf("answer(sf odds +3) = %q", text)
+	}
+}
+
+func TestAnswerStatFromSheet(t *testing.T) {
+	store := character.NewMemoryStore()
+	sheet := character.New("telegram:1", "Kira")
+	sheet.Wits = 3
+	sheet.Momentum = 6
+	if err := store.Put(sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler(Config{
+		Roller:     roll.NewRoller(rand.New(rand.NewSource(1))),
+		Characters: store,
+	})
+
+	title, text, _ := h.answer("telegram:1", "+wits +1")
+	if title != "Ironsworn Roll +wits" || !strings.HasPrefix(text, "+wits: 🎲 (") || !strings.Contains(text, " +4) vs") {
+		t.Fatalf("answer = %q, %q; want a +wits roll with modifier +4", title, text)
+	}
+
+	// Without a sheet a bare stat cannot be resolved.
+	_, text, _ = h.answer("telegram:2", "+wits")
+	if !strings.HasPrefix(text, "You have no character sheet") {
+		t.Fatalf("answer without sheet = %q", text)
+	}
+
+	// Without a sheet the number is the whole modifier.
+	_, text, _ = h.answer("telegram:2", "wits +2")
+	if !strings.Contains(text, " +2) vs") {
+		t.Fatalf("answer without sheet = %q; want modifier +2", text)
+	}
+}
+
+func TestAnswerAssetBonus(t *testing.T) {
+	store := character.NewMemoryStore()
+	sheet := character.New("telegram:1", "Kira")
+	sheet.Wits = 2
+	hound, _ := asset.Builtin().Get("hound")
+	if err := sheet.AddAsset(hound); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler(Config{
+		Roller:     roll.NewRoller(rand.New(rand.NewSource(1))),
+		Characters: store,
+	})
+
+	// Sharp adds +1 to Gather Information with wits.
+	_, text, rec := h.answer("telegram:1", "gather information +wits")
+	if !strings.Contains(text, " +3) vs") || !strings.Contains(text, "🃏 +1 from Hound") || rec.Modifier != 3 {
+		t.Fatalf("answer = %q, modifier %d; want +3 with a Hound note", text, rec.Modifier)
+	}
+
+	// No bonus applies to other moves or stats.
+	for _, q := range []string{"face danger +wits", "gather information +heart"} {
+		if _, text, _ := h.answer("telegram:1", q); strings.Contains(text, "Hound") {
+			t.Fatalf("answer(%q) = %q; want no asset bonus", q, text)
+		}
+	}
+}
+
+func TestAnswersOfferChoices(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	cases := []struct {
+		query  string
+		titles []string
+	}{
+		{"+2", []string{"Ironsworn Roll", "Ask the Oracle 50/50"}},
+		{"face danger +2", []string{"Face Danger", "Ironsworn Roll", "Ask the Oracle 50/50"}},
+		{"strike iron +1", []string{"Strike", "Ironsworn Roll +iron", "Ask the Oracle 50/50"}},
+		{"7", []string{"Ironsworn Roll", "Ironsworn Progress Roll", "Ask the Oracle 50/50"}},
+		{"sf 0", []string{"Ironsworn Roll", "Ironsworn Progress Roll", "Ask the Oracle 50/50"}},
+		{"+7", []string{"Ironsworn Roll", "Ask the Oracle 50/50"}},
+		{"11", []string{"Ironsworn Roll", "Ask the Oracle 50/50"}},
+		{"p7", []string{"Ironsworn Progress Roll", "Ask the Oracle 50/50"}},
+		{"fulfill your vow 7", []string{"Fulfill Your Vow", "Ask the Oracle 50/50"}},
+		{"strike heart +3", []string{"Strike"}},
+		{"odds +3", []string{"Odds: +3"}},
+		{"? likely", []string{"Ask the Oracle"}},
+		{"2d6", []string{"Dice: 2d6"}},
+	}
+
+	for _, c := range cases {
+		results := h.answers(&tgbotapi.User{ID: 1}, c.query)
+		var titles []string
+		for _, r := range results {
+			titles = append(titles, r.title)
+		}
+		if strings.Join(titles, "|") != strings.Join(c.titles, "|") {
+			t.Fatalf("answers(%q) = %q; want %q", c.query, titles, c.titles)
+		}
+	}
+
+	// The plain roll of a move shows the same dice.
+	results := h.answers(&tgbotapi.User{ID: 1}, "face danger +2")
+	move, plain := results[0], results[1]
+	if plain.rec.Move != "" || plain.rec.ActionDie != move.rec.ActionDie || plain.rec.ChallengeDice != move.rec.ChallengeDice {
+		t.Fatalf("plain roll %+v differs from move roll %+v", plain.rec, move.rec)
+	}
+	if !strings.Contains(move.text, plain.text) {
+		t.Fatalf("move text %q does not contain plain text %q", move.text, plain.text)
+	}
+	if plain.keyboard == nil {
+		t.Fatal("expected reroll buttons on the plain roll")
+	}
+}
+
+func TestAnswerMisunderstood(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	cases := []struct {
+		query string
+		why   string
+	}{
+		{"face dangr +2", `"face" is not a modifier, stat or momentum`},
+		{"+2 mx", `"mx" is not a modifier, stat or momentum`},
+		{"+2 +1", "give one modifier, not +2 and +1"},
+		{"m5", "give a modifier or a stat"},
+		{"+2 m11", "momentum must be between -6 and +10"},
+		{"face danger", "give a modifier or a stat"},
+		{"face danger +2 m3 m3", "give momentum only once"},
+		{"p12", "give a progress score from 0 to 10"},
+		{"odds xyz", `"xyz" is not a modifier or progress score`},
+		{"? maybe", `"maybe" are not odds`},
+		{"2d0", "sides must be between 1 and 1000"},
+		{"3d6kh9", "can keep 1 to 3"},
+		{"sf +2 bad", `"bad" is not a modifier, stat or momentum`},
+	}
+
+	for _, c := range cases {
+		results := h.answers(&tgbotapi.User{ID: 1}, c.query)
+		if len(results) != 1 {
+			t.Fatalf("answers(%q) gave %d results; want 1", c.query, len(results))
+		}
+		r := results[0]
+		if !strings.HasPrefix(r.title, "Couldn't understand") || !strings.Contains(r.text, c.why) || !strings.Contains(r.text, "Try +2") {
+			t.Fatalf("answers(%q) = %q, %q; want the problem %q", c.query, r.title, r.text, c.why)
+		}
+		if r.rec.Rolled() || r.keyboard != nil {
+			t.Fatalf("answers(%q) rolled %+v", c.query, r.rec)
+		}
+	}
+}
+
+func FuzzAnswer(f *testing.F) {
+	for _, q := range []string{"+2", "face danger +2 m3", "+wits", "p7", "odds +3", "? likely", "sf strike +iron", "2d6", "+2 mx", "p12"} {
+		f.Add(q)
+	}
+	h := NewHandler(Config{})
+
+	f.Fuzz(func(t *testing.T, q string) {
+		for _, r := range h.answers(&tgbotapi.User{ID: 1}, q) {
+			if strings.HasPrefix(r.title, "Couldn't understand") && r.rec.Rolled() {
+				t.Fatalf("answers(%q) rolled a query it could not understand", q)
+			}
+		}
+	})
+}
+
+func TestInlineArticlesHaveUniqueIDs(t *testing.T) {
+	h := NewHandler(Config{})
+	results := h.answers(&tgbotapi.User{ID: 1}, "7")
+	now := time.Now()
+
+	articles := h.inlineArticles(results, now)
+	seen := map[string]bool{}
+	for _, a := range append(articles, h.inlineArticles(results, now.Add(time.Nanosecond))...) {
+		id := a.(tgbotapi.InlineQueryResultArticle).ID
+		if seen[id] {
+			t.Fatalf("duplicate result ID %q", id)
+		}
+		seen[id] = true
+	}
+
+	// Rolls are carried by their result IDs; the oracle answer is not
+	// a roll.
+	first := articles[0].(tgbotapi.InlineQueryResultArticle).ID
+	last := articles[len(articles)-1].(tgbotapi.InlineQueryResultArticle).ID
+	if rec, ok := h.decodeResultID(first); !ok || rec.Kind != results[0].rec.Kind || rec.Total != results[0].rec.Total {
+		t.Fatalf("decodeResultID(%q) = %+v, %v; want %+v", first, rec, ok, results[0].rec)
+	}
+	if rec, ok := h.decodeResultID(last); ok {
+		t.Fatalf("decodeResultID(%q) = %+v; want no roll for the oracle answer", last, rec)
+	}
 }
@@ -0,0 +1,240 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/dice"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// Query grammar
+//
+// An inline query, or the arguments of the /roll command, is one of:
+//
+//	query    = [ruleset] ( odds | ask | dice | progress | move | roll )
+//	odds     = "odds" [ modifier | progress ]
+//	ask      = "?" [ oracle odds, e.g. "likely" or "small chance" ]
+//	dice     = dice expression starting with a die, e.g. "2d10+1"
+//	progress = ( "p" | "progress" ) score
+//	move     = move name ( score | roll )
+//	roll     = one or more of: modifier, stat, momentum, in any order
+//	modifier = [ "+" | "-" ] digits, e.g. "+2"
+//	stat     = [ "+" ] stat name, e.g. "+wits"
+//	momentum = "m" [ "+" | "-" ] digits, e.g. "m5"
+//
+// A roll needs a modifier or a stat, so that nothing is rolled +0 by
+// accident. Anything else is reported back to the user (see
+// misunderstood) instead of being rolled.
+
+// rollArgs are the arguments of an action roll.
+type rollArgs struct {
+	stat        move.Stat // "" when no stat was given
+	modifier    int
+	hasModifier bool
+	momentum    int
+	hasMomentum bool
+}
+
+// parseRollArgs parses the arguments of an action roll: an optional
+// stat, modifier and momentum, in any order, e.g. "+wits +1 m3".
+//
+// It fails on any other word, on a stat, modifier or momentum given
+// twice, on momentum outside -6 to +10, and when neither a stat nor a
+// modifier is given.
+func parseRollArgs(raw string) (rollArgs, error) {
+	var a rollArgs
+
+	for _, f := range strings.Fields(raw) {
+		if m, ok, err := parseMomentum(f); ok {
+			if err != nil {
+				return rollArgs{}, err
+			}
+			if a.hasMomentum {
+				return rollArgs{}, errors.New("give momentum only once")
+			}
+			a.momentum, a.hasMomentum = m, true
+			continue
+		}
+
+		if stat, ok := move.ParseStat(f); ok {
+			if a.stat != "" {
+				return rollArgs{}, fmt.Errorf("roll with one stat, not %s and %s", a.stat, stat)
+			}
+			a.stat = stat
+			continue
+		}
+
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return rollArgs{}, fmt.Errorf("%q is not a modifier, stat or momentum", f)
+		}
+		if a.hasModifier {
+			return rollArgs{}, fmt.Errorf("give one modifier, not %+d and %+d", a.modifier, n)
+		}
+		a.modifier, a.hasModifier = n, true
+	}
+
+	if a.stat == "" && !a.hasModifier {
+		return rollArgs{}, errors.New("give a modifier or a stat")
+	}
+	return a, nil
+}
+
+// parseMomentum recognizes a momentum token such as "m5", "m+5" or
+// "m-3". It reports false for other words, and an error for momentum
+// outside the valid range.
+func parseMomentum(raw string) (int, bool, error) {
+	rest, found := strings.CutPrefix(strings.ToLower(raw), "m")
+	if !found {
+		return 0, false, nil
+	}
+	m, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0, false, nil
+	}
+	if m < roll.MinMomentum || m > roll.MaxMomentum {
+		return 0, true, fmt.Errorf("momentum must be between %d and %+d", roll.MinMomentum, roll.MaxMomentum)
+	}
+	return m, true, nil
+}
+
+// splitRuleset extracts a leading ruleset name such as "starforged"
+// or "sf" from a query.
+//
+// It returns the ruleset, the rest of the query, and whether the
+// query started with a ruleset name.
+func splitRuleset(raw string) (ruleset.ID, string, bool) {
+	first, rest, _ := strings.Cut(strings.TrimSpace(raw), " ")
+	id, ok := ruleset.Parse(first)
+	if !ok {
+		return "", raw, false
+	}
+	return id, strings.TrimSpace(rest), true
+}
+
+// parseDice recognizes a dice expression query such as "2d10+1",
+// "4d6kh3" or "d%".
+//
+// A query is a dice expression when it starts with a die, e.g. "d6"
+// or "3d8". It returns the parsed expression, or the parse error for
+// a query that starts with a die but is not a valid expression, and
+// reports false for any other query.
+func parseDice(raw string) (e dice.Expr, ok bool, err error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	rest := strings.TrimLeft(raw, "0123456789")
+	if len(rest) < 2 || rest[0] != 'd' || (rest[1] != '%' && (rest[1] < '0' || rest[1] > '9')) {
+		return dice.Expr{}, false, nil
+	}
+
+	e, err = dice.Parse(raw)
+	return e, true, err
+}
+
+// parseProgress recognizes a progress roll query such as "p7",
+// "p 7" or "progress 7".
+//
+// It reports false when the query is not a progress roll, and an
+// error for a progress roll without a valid score from 0 to 10.
+func parseProgress(raw string) (int, bool, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+
+	rest, found := strings.CutPrefix(raw, "progress")
+	if !found {
+		rest, found = strings.CutPrefix(raw, "p")
+	}
+	rest = strings.TrimSpace(rest)
+	if !found || (rest != "" && !strings.ContainsRune("+-0123456789", rune(rest[0]))) {
+		// Another word, e.g. the move "pay the price".
+		return 0, false, nil
+	}
+
+	score, ok := parseScore(rest)
+	if !ok {
+		return 0, true, fmt.Errorf("give a progress score from %d to %d, e.g. p7",
+			roll.MinProgressScore, roll.MaxProgressScore)
+	}
+	return score, true, nil
+}
+
+// parseOdds recognizes an odds query: "odds" followed by an optional
+// modifier, e.g. "odds +3", or a progress score, e.g. "odds p7".
+//
+// It returns the modifier or score and whether it is a progress score.
+// It reports false when the query is not an odds query, and an error
+// when what follows "odds" is neither.
+func parseOdds(raw string) (n int, progress, ok bool, err error) {
+	rest, found := strings.CutPrefix(strings.ToLower(strings.TrimSpace(raw)), "odds")
+	if !found {
+		return 0, false, false, nil
+	}
+	if rest != "" && !strings.HasPrefix(rest, " ") {
+		// A longer word such as "oddsmaker".
+		return 0, false, false, nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return 0, false, true, nil
+	}
+	if score, ok, err := parseProgress(rest); ok {
+		return score, true, true, err
+	}
+	n, err = strconv.Atoi(rest)
+	if err != nil {
+		return 0, false, true, fmt.Errorf("%q is not a modifier or progress score", rest)
+	}
+	return n, false, true, nil
+}
+
+// parseScore parses a progress score, reporting false when it is
+// not a number in the valid 0–10 range.
+func parseScore(raw string) (int, bool) {
+	score, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, false
+	}
+	if score < roll.MinProgressScore || score > roll.MaxProgressScore {
+		return 0, false
+	}
+
+	return score, true
+}
+
+// parseBareScore recognizes a query that is only a number from 0 to
+// 10 without a sign, such as "7", which may be meant as a modifier or
+// as a progress score.
+func parseBareScore(raw string) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw[0] < '0' || raw[0] > '9' {
+		return 0, false
+	}
+	return parseScore(raw)
+}
+
+// parseAsk recognizes an "Ask the Oracle" query: a "?" followed by
+// optional odds, e.g. "? likely". A bare "?" means 50/50 odds.
+//
+// It reports false when the query is not a question, and an error
+// when the odds are not recognized.
+func parseAsk(raw string) (oracle.Odds, bool, error) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(raw), "?")
+	if !found {
+		return "", false, nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return oracle.FiftyFifty, true, nil
+	}
+	odds, ok := oracle.ParseOdds(rest)
+	if !ok {
+		return "", true, fmt.Errorf("%q are not odds; use e.g. likely, 50/50 or small chance", rest)
+	}
+	return odds, true, nil
+}
@@ -1,25 +1,202 @@
 package telegram
 
-import "testing"
+import (
+	"testing"
 
-func TestParseModifierVariousInputs(t *testing.T) {
-	cases := map[string]int{
-		"":     0,
-		"+2":   2,
-		"2":    2,
-		"-1":   -1,
-		" +3 ": 3,
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+func TestParseRollArgs(t *testing.T) {
+	cases := []struct {
+		input string
+		want  rollArgs
+		err   string
+	}{
+		{"+2", rollArgs{modifier: 2, hasModifier: true}, ""},
+		{"2", rollArgs{modifier: 2, hasModifier: true}, ""},
+		{" -1 ", rollArgs{modifier: -1, hasModifier: true}, ""},
+		{"+0", rollArgs{hasModifier: true}, ""},
+		{"+2 m5", rollArgs{modifier: 2, hasModifier: true, momentum: 5, hasMomentum: true}, ""},
+		{"m-3 +1", rollArgs{modifier: 1, hasModifier: true, momentum: -3, hasMomentum: true}, ""},
+		{"0 M+4", rollArgs{hasModifier: true, momentum: 4, hasMomentum: true}, ""},
+		{"+wits", rollArgs{stat: move.Wits}, ""},
+		{"Iron 3", rollArgs{stat: move.Iron, modifier: 3, hasModifier: true}, ""},
+		{"+1 edge m10", rollArgs{stat: move.Edge, modifier: 1, hasModifier: true, momentum: 10, hasMomentum: true}, ""},
+		{"", rollArgs{}, "give a modifier or a stat"},
+		{"m5", rollArgs{}, "give a modifier or a stat"},
+		{"bad", rollArgs{}, `"bad" is not a modifier, stat or momentum`},
+		{"+2 mx", rollArgs{}, `"mx" is not a modifier, stat or momentum`},
+		{"face danger", rollArgs{}, `"face" is not a modifier, stat or momentum`},
+		{"2d6", rollArgs{}, `"2d6" is not a modifier, stat or momentum`},
+		{"+ 2", rollArgs{}, `"+" is not a modifier, stat or momentum`},
+		{"+2 +1", rollArgs{}, "give one modifier, not +2 and +1"},
+		{"+wits +edge", rollArgs{}, "roll with one stat, not wits and edge"},
+		{"+2 m3 m4", rollArgs{}, "give momentum only once"},
+		{"+2 m11", rollArgs{}, "momentum must be between -6 and +10"},
+		{"+2 m-7", rollArgs{}, "momentum must be between -6 and +10"},
+	}
+
+	for _, c := range cases {
+		got, err := parseRollArgs(c.input)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Fatalf("parseRollArgs(%q) error = %v; want %q", c.input, err, c.err)
+			}
+			continue
+		}
+		if err != nil || got != c.want {
+			t.Fatalf("parseRollArgs(%q) = %+v, %v; want %+v", c.input, got, err, c.want)
+		}
+	}
+}
+
+func TestParseProgressVariousInputs(t *testing.T) {
+	cases := []struct {
+		input string
+		want  int
+		ok    bool
+		err   bool
+	}{
+		{"p7", 7, true, false},
+		{"P 0", 0, true, false},
+		{"progress 10", 10, true, false},
+		{" progress3 ", 3, true, false},
+		{"p11", 0, true, true},
+		{"p-1", 0, true, true},
+		{"p7x", 0, true, true},
+		{"progress", 0, true, true},
+		{"p", 0, true, true},
+		{"+2", 0, false, false},
+		{"pay the price", 0, false, false},
+		{"progressive", 0, false, false},
 	}
 
-	for input, want := range cases {
-		got := parseModifier(input)
-		if got != want {
-			t.Fatalf("parseModifier(%q) = %d; want %d", input, got, want)
+	for _, c := range cases {
+		got, ok, err := parseProgress(c.input)
+		if got != c.want || ok != c.ok || (err != nil) != c.err {
+			t.Fatalf("parseProgress(%q) = %d, %v, %v; want %d, %v, error %v", c.input, got, ok, err, c.want, c.ok, c.err)
 		}
 	}
+}
 
-	// invalid numeric content -> treated as 0
-	if parseModifier("bad") != 0 {
-		t.Fatalf("parseModifier(" + "bad" + ") should return 0 on invalid input")
+func TestParseAsk(t *testing.T) {
+	cases := []struct {
+		input string
+		want  oracle.Odds
+		ok    bool
+		err   bool
+	}{
+		{"?", oracle.FiftyFifty, true, false},
+		{"? likely", oracle.Likely, true, false},
+		{"?small chance", oracle.SmallChance, true, false},
+		{" ? Almost Certain", oracle.AlmostCertain, true, false},
+		{"? maybe", "", true, true},
+		{"likely", "", false, false},
 	}
+
+	for _, c := range cases {
+		got, ok, err := parseAsk(c.input)
+		if got != c.want || ok != c.ok || (err != nil) != c.err {
+			t.Fatalf("parseAsk(%q) = %q, %v, %v; want %q, %v, error %v", c.input, got, ok, err, c.want, c.ok, c.err)
+		}
+	}
+}
+
+func TestParseOdds(t *testing.T) {
+	cases := []struct {
+		input    string
+		n        int
+		progress bool
+		ok       bool
+		err      bool
+	}{
+		{"odds", 0, false, true, false},
+		{"odds +3", 3, false, true, false},
+		{" Odds -1", -1, false, true, false},
+		{"odds p7", 7, true, true, false},
+		{"odds progress 10", 10, true, true, false},
+		{"odds p11", 0, true, true, true},
+		{"odds wits", 0, false, true, true},
+		{"odds +3 m2", 0, false, true, true},
+		{"oddsmaker", 0, false, false, false},
+		{"+3", 0, false, false, false},
+	}
+
+	for _, c := range cases {
+		n, progress, ok, err := parseOdds(c.input)
+		if n != c.n || progress != c.progress || ok != c.ok || (err != nil) != c.err {
+			t.Fatalf("parseOdds(%q) = %d, %v, %v, %v; want %d, %v, %v, error %v",
+				c.input, n, progress, ok, err, c.n, c.progress, c.ok, c.err)
+		}
+	}
+}
+
+func TestParseDice(t *testing.T) {
+	cases := []struct {
+		input string
+		expr  string
+		ok    bool
+		err   bool
+	}{
+		{"2d10+1", "2d10+1", true, false},
+		{" D% ", "1d100", true, false},
+		{"4d6kh3", "4d6kh3", true, false},
+		{"d6 + 2", "1d6+2", true, false},
+		{"2d6x", "", true, true},
+		{"d0", "", true, true},
+		{"delve the depths", "", false, false},
+		{"+2", "", false, false},
+		{"p7", "", false, false},
+		{"d", "", false, false},
+	}
+
+	for _, c := range cases {
+		e, ok, err := parseDice(c.input)
+		if ok != c.ok || (err != nil) != c.err || (err == nil && ok && e.String() != c.expr) {
+			t.Fatalf("parseDice(%q) = %q, %v, %v; want %q, %v, error %v", c.input, e, ok, err, c.expr, c.ok, c.err)
+		}
+	}
+}
+
+func TestSplitRuleset(t *testing.T) {
+	cases := []struct {
+		input string
+		id    ruleset.ID
+		rest  string
+		ok    bool
+	}{
+		{"starforged face danger +2", ruleset.Starforged, "face danger +2", true},
+		{"SF +1", ruleset.Starforged, "+1", true},
+		{"delve", ruleset.Delve, "", true},
+		{"face danger +2", "", "face danger +2", false},
+		{"+2", "", "+2", false},
+	}
+
+	for _, c := range cases {
+		id, rest, ok := splitRuleset(c.input)
+		if id != c.id || rest != c.rest || ok != c.ok {
+			t.Fatalf("splitRuleset(%q) = %q, %q, %v; want %q, %q, %v", c.input, id, rest, ok, c.id, c.rest, c.ok)
+		}
+	}
+}
+
+func FuzzParseRollArgs(f *testing.F) {
+	for _, q := range []string{"+2", "-1 m5", "+wits", "iron 2 m-6", "+2 mx", "+2 +1", "m11", ""} {
+		f.Add(q)
+	}
+
+	f.Fuzz(func(t *testing.T, q string) {
+		a, err := parseRollArgs(q)
+		if err != nil {
+			return
+		}
+		if !a.hasModifier && a.stat == "" {
+			t.Fatalf("parseRollArgs(%q) = %+v with neither modifier nor stat", q, a)
+		}
+		if a.hasMomentum && (a.momentum < -6 || a.momentum > 10) {
+			t.Fatalf("parseRollArgs(%q) momentum %d out of range", q, a.momentum)
+		}
+	})
 }
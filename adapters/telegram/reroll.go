@@ -0,0 +1,341 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+// Roll buttons
+//
+// An action roll sent by the bot, inline or in a chat, carries a
+// keyboard:
+//
+//   - "Reroll <die>" for each die, for assets that let a die be
+//     rerolled. The message gets the new dice and a line noting the
+//     original and replacement values; each die can be rerolled once.
+//   - "Burn momentum" when burning would improve the outcome. The
+//     message then shows the burned result and loses its buttons.
+//   - "Show odds", which adds the chance of each outcome with the
+//     roll's modifier.
+//
+// Pressing a button edits the message. The callback data carries
+// everything needed to redo the roll:
+//
+//	<op>;<die>;<user>;<ruleset>;<move key>;<dice>;<signature>
+//
+// where op is rr (reroll), bm (burn momentum) or od (show odds), die
+// is the index of the die in roll.AllSlots for a reroll and empty
+// otherwise, user is the Telegram user ID of the player who rolled,
+// and dice is the roll in roll.EncodeDice form. The signature is a
+// truncated HMAC-SHA256 of the rest under the handler's callback key:
+// data that was not made by the bot, or was altered to change the
+// dice or the player, is rejected.
+//
+// Only the player who rolled can press the buttons. Since the data
+// carries the roll, a reroll or burn button pressed twice, or pressed
+// again from a keyboard the message no longer shows, would redo it; the
+// handler remembers the rerolls and burns done on each message and
+// refuses repeats. The memory is bounded and kept per instance, so a
+// repeat that reaches another instance than the first press is not
+// caught.
+//
+// Telegram allows at most 64 bytes of callback data; when a long move
+// key does not fit it is left out and the edited message shows the
+// roll without the move.
+//
+// Rerolls and burned momentum are not added to the roll history, which
+// keeps the dice as first rolled, and burning does not reset the
+// momentum on a character sheet.
+
+// Callback data limits and operations.
+const (
+	maxCallbackData = 64
+	maxUsedButtons  = 1000 // Rerolls and burns remembered, oldest forgotten first
+	signatureSize   = 8    // Bytes of the HMAC kept in callback data
+
+	rerollPrefix = "rr"
+	burnPrefix   = "bm"
+	oddsPrefix   = "od"
+)
+
+// staleButton is the notice for callback data that cannot be decoded
+// or whose signature does not match.
+const staleButton = "This button no longer works."
+
+// rollButton is the decoded callback data of a roll button.
+type rollButton struct {
+	op      string
+	die     int   // Index in roll.AllSlots, for rerolls
+	user    int64 // Telegram user ID of the player who rolled
+	ruleset ruleset.ID
+	moveKey string
+	roll    roll.Result
+}
+
+// HandleCallbackQuery handles a button press on a message sent by the
+// bot. A roll button edits the message with the updated result.
+func (h *Handler) HandleCallbackQuery(bot *tgbotapi.BotAPI, cq *tgbotapi.CallbackQuery) {
+	if cq == nil || bot == nil {
+		return
+	}
+
+	var user int64
+	if cq.From != nil {
+		user = cq.From.ID
+	}
+	text, kb, problem := h.press(user, callbackMessage(cq), cq.Data)
+	if _, err := bot.Request(tgbotapi.NewCallback(cq.ID, problem)); err != nil {
+		slog.Error("telegram callback answer failed", "err", err)
+	}
+	if problem != "" {
+		return
+	}
+
+	edit := tgbotapi.EditMessageTextConfig{
+		BaseEdit: tgbotapi.BaseEdit{InlineMessageID: cq.InlineMessageID, ReplyMarkup: kb},
+		Text:     text,
+	}
+	if cq.Message != nil {
+		edit.ChatID = cq.Message.Chat.ID
+		edit.MessageID = cq.Message.MessageID
+	}
+	if _, err := bot.Request(edit); err != nil {
+		slog.Error("telegram roll button edit failed", "err", err)
+	}
+}
+
+// callbackMessage returns a key identifying the message whose button
+// was pressed: its inline message ID, or its chat and message IDs.
+func callbackMessage(cq *tgbotapi.CallbackQuery) string {
+	if cq.Message != nil {
+		return strconv.FormatInt(cq.Message.Chat.ID, 10) + ":" + strconv.Itoa(cq.Message.MessageID)
+	}
+	return cq.InlineMessageID
+}
+
+// press performs the action of a roll button that user pressed on a
+// message and returns the new message text and buttons, which are nil
+// when none are left, or a notice explaining why nothing was done.
+func (h *Handler) press(user int64, message, data string) (string, *tgbotapi.InlineKeyboardMarkup, string) {
+	b, ok := h.decodeButton(data)
+	if !ok {
+		return "", nil, staleButton
+	}
+	if b.user != user {
+		return "", nil, "Only the player who rolled can use these buttons."
+	}
+	rs := h.rulesets.Select(string(b.ruleset))
+	r := b.roll
+
+	switch b.op {
+	case rerollPrefix:
+		slot := roll.AllSlots[b.die]
+		if r.Rerolled(slot) || !h.used.claim(message, b.op+strconv.Itoa(b.die)) {
+			return "", nil, "That die was already rerolled."
+		}
+		rerolled, err := h.roller.Reroll(r, slot)
+		if err != nil {
+			slog.Error("telegram reroll failed", "err", err)
+			return "", nil, staleButton
+		}
+		return h.rollText(rs, b.moveKey, rerolled), h.rollKeyboard(rs.ID, b.user, b.moveKey, rerolled, true), ""
+
+	case burnPrefix:
+		burned, err := roll.Burn(r)
+		if err != nil {
+			return "", nil, "Burning momentum would not improve this roll."
+		}
+		if !h.used.claim(message, b.op) {
+			return "", nil, "Momentum was already burned."
+		}
+		return h.rollText(rs, b.moveKey, burned) + "\n🔥 Momentum burned; reset your momentum.", nil, ""
+
+	case oddsPrefix:
+		// Showing the odds changes nothing, so it may be repeated.
+		text := h.rollText(rs, b.moveKey, r) + fmt.Sprintf("\n\n📊 Odds with %+d:\n", r.Modifier) +
+			formatOdds(roll.ActionOdds(r.Modifier), rs.Terms)
+		return text, h.rollKeyboard(rs.ID, b.user, b.moveKey, r, false), ""
+
+	default:
+		return "", nil, staleButton
+	}
+}
+
+// rollText renders an action roll, with its rerolls and the move it
+// was made for, if any.
+func (h *Handler) rollText(rs *ruleset.Ruleset, moveKey string, r roll.Result) string {
+	text := formatResult(r, rs.Terms) + formatRerolls(r.Rerolls)
+	if m, ok := rs.Move(moveKey); ok && moveKey != "" && !m.Progress {
+		text = formatMoveResult(m, "", text, r.Outcome)
+	}
+	return text
+}
+
+// entryKeyboard returns the roll buttons for the roll recorded in
+// rec, made by the Telegram user with the given ID, or nil when it is
+// not an action roll.
+func (h *Handler) entryKeyboard(rs *ruleset.Ruleset, user int64, rec history.Entry) *tgbotapi.InlineKeyboardMarkup {
+	if rec.Kind != roll.KindAction {
+		return nil
+	}
+	key := ""
+	if m, ok := rs.Move(rec.Move); ok && rec.Move != "" {
+		key = m.Key
+	}
+	return h.rollKeyboard(rs.ID, user, key, entryResult(rec), true)
+}
+
+// entryResult restores the action roll recorded in rec.
+func entryResult(rec history.Entry) roll.Result {
+	r := roll.Result{ActionDie: rec.ActionDie, Modifier: rec.Modifier, ChallengeDice: rec.ChallengeDice, ID: rec.RollID}
+	if rec.Momentum != nil {
+		r.Momentum = &roll.MomentumEffect{Value: *rec.Momentum}
+	}
+	return roll.Rescore(r)
+}
+
+// rollKeyboard returns the buttons of an action roll made by the
+// Telegram user with the given ID: one reroll button per die of r not
+// yet rerolled, one row with the burn button when burning would help
+// and the odds button when odds is set, or nil when there is none.
+func (h *Handler) rollKeyboard(rs ruleset.ID, user int64, moveKey string, r roll.Result, odds bool) *tgbotapi.InlineKeyboardMarkup {
+	button := func(text string, b rollButton) tgbotapi.InlineKeyboardButton {
+		b.user, b.ruleset, b.moveKey, b.roll = user, rs, moveKey, r
+		data := h.encodeButton(b)
+		if len(data) > maxCallbackData {
+			b.moveKey = ""
+			data = h.encodeButton(b)
+		}
+		return tgbotapi.NewInlineKeyboardButtonData(text, data)
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, slot := range roll.AllSlots {
+		if r.Rerolled(slot) {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			button("Reroll "+slotName(slot), rollButton{op: rerollPrefix, die: i}),
+		))
+	}
+
+	var last []tgbotapi.InlineKeyboardButton
+	if r.Momentum != nil && r.Momentum.CanBurn {
+		last = append(last, button("🔥 Burn momentum", rollButton{op: burnPrefix}))
+	}
+	if odds {
+		last = append(last, button("📊 Show odds", rollButton{op: oddsPrefix}))
+	}
+	if len(last) > 0 {
+		rows = append(rows, last)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	kb := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &kb
+}
+
+// encodeButton returns the signed callback data of a roll button.
+func (h *Handler) encodeButton(b rollButton) string {
+	die := ""
+	if b.op == rerollPrefix {
+		die = strconv.Itoa(b.die)
+	}
+	user := strconv.FormatInt(b.user, 10)
+	payload := strings.Join([]string{b.op, die, user, string(b.ruleset), b.moveKey, roll.EncodeDice(b.roll)}, ";")
+	return payload + ";" + h.sign(payload)
+}
+
+// decodeButton checks the signature of callback data and decodes it.
+func (h *Handler) decodeButton(data string) (rollButton, bool) {
+	payload, sig, ok := cutLast(data, ";")
+	if !ok || !hmac.Equal([]byte(sig), []byte(h.sign(payload))) {
+		return rollButton{}, false
+	}
+
+	parts := strings.Split(payload, ";")
+	if len(parts) != 6 {
+		return rollButton{}, false
+	}
+	b := rollButton{op: parts[0], ruleset: ruleset.ID(parts[3]), moveKey: parts[4]}
+	if b.op == rerollPrefix {
+		i, err := strconv.Atoi(parts[1])
+		if err != nil || i < 0 || i >= len(roll.AllSlots) {
+			return rollButton{}, false
+		}
+		b.die = i
+	}
+	user, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return rollButton{}, false
+	}
+	b.user = user
+	r, err := roll.DecodeDice(parts[5])
+	if err != nil {
+		return rollButton{}, false
+	}
+	b.roll = r
+	return b, true
+}
+
+// sign returns the signature of callback data: the first bytes of its
+// HMAC-SHA256 under the callback key, in unpadded URL-safe base64.
+func (h *Handler) sign(payload string) string {
+	mac := hmac.New(sha256.New, h.callbackKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureSize])
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// usedButtons remembers the reroll and burn buttons pressed on each
+// message (see HandleCallbackQuery).
+type usedButtons struct {
+	mu    sync.Mutex
+	max   int
+	used  map[string]bool
+	order []string // Message and button keys, oldest first
+}
+
+func newUsedButtons(max int) *usedButtons {
+	return &usedButtons{max: max, used: make(map[string]bool)}
+}
+
+// claim records the press of a button on a message and reports whether
+// it was the first, forgetting the oldest when more than max are kept.
+func (u *usedButtons) claim(message, button string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	key := message + ";" + button
+	if u.used[key] {
+		return false
+	}
+	u.used[key] = true
+	u.order = append(u.order, key)
+	for len(u.order) > u.max {
+		delete(u.used, u.order[0])
+		u.order = u.order[1:]
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package telegram
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+)
+
+func TestReroll(t *testing.T) {
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+
+	_, _, rec := h.answer("telegram:1", "face danger +2")
+	kb := h.entryKeyboard(h.rulesets.Default(), 1, rec)
+	if kb == nil || len(kb.InlineKeyboard) != 4 {
+		t.Fatalf("entryKeyboard = %+v, want three reroll rows and an odds row", kb)
+	}
+	button := kb.InlineKeyboard[1][0]
+	if button.Text != "Reroll challenge die 1" || !strings.HasPrefix(*button.CallbackData, "rr;1;1;classic;face_danger;") {
+		t.Fatalf("button = %q, %q", button.Text, *button.CallbackData)
+	}
+
+	text, kb, problem := h.press(1, "m", *button.CallbackData)
+	if problem != "" {
+		t.Fatalf("reroll: %s", problem)
+	}
+	if !strings.HasPrefix(text, "Face Danger\n🎲") || !strings.Contains(text, "\n🔁 challenge die 1 ") {
+		t.Fatalf("reroll text = %q", text)
+	}
+	if kb == nil || len(kb.InlineKeyboard) != 3 {
+		t.Fatalf("reroll left %+v, want two reroll rows and an odds row", kb)
+	}
+
+	// A die already in the audit trail cannot be rerolled again.
+	again := resign(t, h, *button.CallbackData, func(dice string) string { return dice + "|13>4" })
+	if _, _, problem := h.press(1, "other", again); problem != "That die was already rerolled." {
+		t.Fatalf("second reroll problem = %q", problem)
+	}
+}
+
+func TestButtonsArePressedOnce(t *testing.T) {
+	h := NewHandler(Config{})
+
+	r := roll.Rescore(roll.Result{ActionDie: 2, Modifier: 1, ChallengeDice: [2]int{6, 7}, Momentum: &roll.MomentumEffect{Value: 8}})
+	kb := h.rollKeyboard(ruleset.Classic, 1, "", r, true)
+	reroll := *kb.InlineKeyboard[0][0].CallbackData
+	burn := *kb.InlineKeyboard[3][0].CallbackData
+	odds := *kb.InlineKeyboard[3][1].CallbackData
+
+	// Only the player who rolled can press the buttons.
+	if _, _, problem := h.press(2, "m", reroll); problem != "Only the player who rolled can use these buttons." {
+		t.Fatalf("press by another user problem = %q", problem)
+	}
+
+	cases := []struct {
+		message string
+		data    string
+		want    string
+	}{
+		{"m", reroll, ""},
+		{"m", reroll, "That die was already rerolled."},
+		{"m", odds, ""},
+		{"m", odds, ""},
+		{"m", burn, ""},
+		{"m", burn, "Momentum was already burned."},
+		{"other", reroll, ""}, // The same roll on another message
+		{"other", burn, ""},
+	}
+	for i, c := range cases {
+		if _, _, problem := h.press(1, c.message, c.data); problem != c.want {
+			t.Fatalf("press %d on %s problem = %q; want %q", i, c.message, problem, c.want)
+		}
+	}
+}
+
+func TestUsedButtonsAreBounded(t *testing.T) {
+	u := newUsedButtons(2)
+	for _, m := range []string{"1", "2", "3"} {
+		if !u.claim(m, "rr0") {
+			t.Fatalf("first claim on %s refused", m)
+		}
+	}
+	if u.claim("3", "rr0") {
+		t.Fatal("repeated claim allowed")
+	}
+	if !u.claim("1", "rr0") {
+		t.Fatal("expected the oldest claim to be forgotten")
+	}
+}
+
+func TestBurnMomentum(t *testing.T) {
+	h := NewHandler(Config{})
+
+	// 2 +1 = 3 against 6 and 7 misses; burning momentum 8 beats both.
+	r := roll.Rescore(roll.Result{ActionDie: 2, Modifier: 1, ChallengeDice: [2]int{6, 7}, Momentum: &roll.MomentumEffect{Value: 8}})
+	kb := h.rollKeyboard(ruleset.Classic, 1, "", r, true)
+	last := kb.InlineKeyboard[len(kb.InlineKeyboard)-1]
+	if len(last) != 2 || last[0].Text != "🔥 Burn momentum" || last[1].Text != "📊 Show odds" {
+		t.Fatalf("last row = %+v, want burn and odds buttons", last)
+	}
+
+	text, kb, problem := h.press(1, "m", *last[0].CallbackData)
+	if problem != "" {
+		t.Fatalf("burn: %s", problem)
+	}
+	if !strings.HasPrefix(text, "🎲 (2 +1) vs (6 & 7) → Strong Hit · momentum +8 burned") || kb != nil {
+		t.Fatalf("burn = %q, %+v; want a strong hit and no buttons", text, kb)
+	}
+
+	// Without a helpful burn the button is neither offered nor honored.
+	plain := roll.Rescore(roll.Result{ActionDie: 6, Modifier: 3, ChallengeDice: [2]int{2, 4}, Momentum: &roll.MomentumEffect{Value: 5}})
+	kb = h.rollKeyboard(ruleset.Classic, 1, "", plain, true)
+	if row := kb.InlineKeyboard[len(kb.InlineKeyboard)-1]; len(row) != 1 || row[0].Text != "📊 Show odds" {
+		t.Fatalf("last row = %+v, want only the odds button", row)
+	}
+	forged := h.encodeButton(rollButton{op: burnPrefix, user: 1, ruleset: ruleset.Classic, roll: plain})
+	if _, _, problem := h.press(1, "m", forged); problem != "Burning momentum would not improve this roll." {
+		t.Fatalf("burn problem = %q", problem)
+	}
+}
+
+func TestShowOdds(t *testing.T) {
+	h := NewHandler(Config{})
+
+	r := roll.Rescore(roll.Result{ActionDie: 4, Modifier: 3, ChallengeDice: [2]int{3, 9}})
+	data := h.encodeButton(rollButton{op: oddsPrefix, user: 1, ruleset: ruleset.Classic, moveKey: "strike", roll: r})
+	text, kb, problem := h.press(1, "m", data)
+	if problem != "" {
+		t.Fatalf("odds: %s", problem)
+	}
+	want := "\n\n📊 Odds with +3:\nStrong Hit 33.2% (Match 5.5%)\nWeak Hit 43.7%\nMiss 23.2% (Match 4.5%)"
+	if !strings.HasPrefix(text, "Strike\n🎲 (4 +3)") || !strings.HasSuffix(text, want) {
+		t.Fatalf("odds text = %q", text)
+	}
+	for _, row := range kb.InlineKeyboard {
+		for _, b := range row {
+			if b.Text == "📊 Show odds" {
+				t.Fatal("odds button still offered after showing odds")
+			}
+		}
+	}
+}
+
+func TestButtonsAreSigned(t *testing.T) {
+	h := NewHandler(Config{CallbackKey: []byte("key")})
+	other := NewHandler(Config{CallbackKey: []byte("other key")})
+
+	r := roll.Rescore(roll.Result{ActionDie: 1, Modifier: 0, ChallengeDice: [2]int{9, 10}})
+	data := h.encodeButton(rollButton{op: oddsPrefix, user: 1, ruleset: ruleset.Classic, roll: r})
+	if _, _, problem := h.press(1, "m", data); problem != "" {
+		t.Fatalf("press: %s", problem)
+	}
+
+	forged := []string{
+		"",
+		"other",
+		"od;;1;classic;;1,0,9,10", // unsigned
+		strings.Replace(data, ";1,0,9,10;", ";6,0,1,1;", 1), // dice changed
+		strings.Replace(data, "od;;1;", "od;;2;", 1),        // player changed
+		data[:len(data)-1] + "A",                            // signature changed
+	}
+	for _, d := range forged {
+		if _, _, problem := h.press(1, "m", d); problem != staleButton {
+			t.Fatalf("press(%q) problem = %q, want %q", d, problem, staleButton)
+		}
+	}
+
+	// Buttons made under another key are rejected.
+	if _, _, problem := other.press(1, "m", data); problem != staleButton {
+		t.Fatalf("press with another key problem = %q", problem)
+	}
+
+	// Well signed but malformed data is rejected too.
+	for _, payload := range []string{"rr;9;1;classic;;4,2,3,7", "rr;0;1;classic;;x", "rr;0;x;classic;;4,2,3,7", "zz;;1;classic;;4,2,3,7"} {
+		if _, _, problem := h.press(1, "m", payload+";"+h.sign(payload)); problem != staleButton {
+			t.Fatalf("press(%q) problem = %q", payload, problem)
+		}
+	}
+}
+
+func TestRollKeyboard(t *testing.T) {
+	h := NewHandler(Config{})
+	if kb := h.entryKeyboard(ruleset.Builtin(nil, nil).Default(), 1, history.Entry{Kind: roll.KindProgress}); kb != nil {
+		t.Fatalf("progress rolls should have no roll buttons: %+v", kb)
+	}
+
+	r := roll.Rescore(roll.Result{
+		ActionDie: 1, Modifier: -3, ChallengeDice: [2]int{10, 10},
+		Momentum: &roll.MomentumEffect{Value: 10},
+		Rerolls:  []roll.Reroll{{Die: roll.SlotChallenge1, Original: 10, Replacement: 10}},
+	})
+	kb := h.rollKeyboard(ruleset.Starforged, 1234567890123, "undertake_an_expedition", r, true)
+	for _, row := range kb.InlineKeyboard {
+		for _, b := range row {
+			if data := *b.CallbackData; len(data) > maxCallbackData {
+				t.Fatalf("callback data %q is longer than %d bytes", data, maxCallbackData)
+			}
+		}
+	}
+}
+
+// resign rewrites the dice of signed callback data with edit and
+// signs the result again under the key of h, as only the bot itself
+// could.
+func resign(t *testing.T, h *Handler, data string, edit func(dice string) string) string {
+	t.Helper()
+
+	payload, _, _ := cutLast(data, ";")
+	parts := strings.Split(payload, ";")
+	parts[5] = edit(parts[5])
+	payload = strings.Join(parts, ";")
+	return payload + ";" + h.sign(payload)
+}
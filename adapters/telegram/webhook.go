@@ -0,0 +1,118 @@
+package telegram
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Webhook mode
+//
+// Instead of polling for updates, the bot can have Telegram POST every
+// update to a public HTTPS URL, which lets several instances share the
+// bot behind a reverse proxy. SetWebhook registers the URL together
+// with a secret token; Telegram sends the token in the
+// X-Telegram-Bot-Api-Secret-Token header of every request, and Webhook
+// rejects requests without it, so only Telegram can feed updates to the
+// bot. DeleteWebhook switches the bot back to polling.
+
+// SecretTokenHeader is the header carrying the webhook secret token.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// maxUpdateSize bounds the body of a webhook request.
+const maxUpdateSize = 1 << 20
+
+// AllowedUpdates lists the update types the Handler answers.
+var AllowedUpdates = []string{"message", "inline_query", "chosen_inline_result", "callback_query"}
+
+// errWebhookSecret is returned by SetWebhook for an unusable secret.
+var errWebhookSecret = errors.New("telegram: webhook secret must be 1 to 256 letters, digits, _ or -")
+
+// HandleUpdate dispatches an update, received by polling or through
+// the webhook, to the handler of its kind. Other updates are ignored.
+func (h *Handler) HandleUpdate(bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch {
+	case update.InlineQuery != nil:
+		h.HandleInlineQuery(bot, update.InlineQuery)
+	case update.ChosenInlineResult != nil:
+		h.HandleChosenInlineResult(update.ChosenInlineResult)
+	case update.CallbackQuery != nil:
+		h.HandleCallbackQuery(bot, update.CallbackQuery)
+	case update.Message != nil:
+		h.HandleMessage(bot, update.Message)
+	}
+}
+
+// Webhook returns an http.Handler that receives the updates of bot
+// posted by Telegram. Requests must carry secret, the token given to
+// SetWebhook, in the SecretTokenHeader; others are rejected with 403
+// Forbidden. The update is answered before the request returns.
+func (h *Handler) Webhook(bot *tgbotapi.BotAPI, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := r.Header.Get(SecretTokenHeader)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			slog.Warn("telegram webhook request rejected", "remote", r.RemoteAddr)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var update tgbotapi.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+			slog.Warn("telegram webhook update invalid", "err", err)
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+
+		h.HandleUpdate(bot, update)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// SetWebhook registers url as the webhook of bot. Telegram then sends
+// secret with every update (see Webhook) and stops serving updates to
+// polling.
+func SetWebhook(bot *tgbotapi.BotAPI, url, secret string) error {
+	if !validSecret(secret) {
+		return errWebhookSecret
+	}
+
+	params := tgbotapi.Params{"url": url, "secret_token": secret}
+	if err := params.AddInterface("allowed_updates", AllowedUpdates); err != nil {
+		return err
+	}
+	_, err := bot.MakeRequest("setWebhook", params)
+	return err
+}
+
+// DeleteWebhook removes the webhook of bot, if any, so that its
+// updates can be polled. Pending updates are kept.
+func DeleteWebhook(bot *tgbotapi.BotAPI) error {
+	_, err := bot.Request(tgbotapi.DeleteWebhookConfig{})
+	return err
+}
+
+// validSecret reports whether s can be a webhook secret token:
+// 1 to 256 characters A-Z, a-z, 0-9, _ and -.
+func validSecret(s string) bool {
+	if len(s) < 1 || len(s) > 256 {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
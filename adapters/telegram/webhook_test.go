@@ -0,0 +1,196 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// fakeTelegram is a local stand-in for the Telegram Bot API that
+// records the methods called and their parameters.
+type fakeTelegram struct {
+	mu    sync.Mutex
+	calls []fakeCall
+}
+
+type fakeCall struct {
+	method string
+	params url.Values
+}
+
+// newFakeTelegram starts a fake Bot API server and returns a bot
+// connected to it.
+func newFakeTelegram(t *testing.T) (*fakeTelegram, *tgbotapi.BotAPI) {
+	t.Helper()
+
+	f := &fakeTelegram{}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint("123:token", srv.URL+"/bot%s/%s")
+	if err != nil {
+		t.Fatalf("failed to connect to the fake server: %v", err)
+	}
+	f.reset()
+	return f, bot
+}
+
+func (f *fakeTelegram) serve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{method, r.PostForm})
+	f.mu.Unlock()
+
+	var result any = true
+	switch method {
+	case "getMe":
+		result = tgbotapi.User{ID: 123, IsBot: true, UserName: "ironrollbot"}
+	case "sendMessage":
+		chatID, _ := json.Number(r.PostForm.Get("chat_id")).Int64()
+		result = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: chatID}, Text: r.PostForm.Get("text")}
+	}
+	data, _ := json.Marshal(result)
+	json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: true, Result: data})
+}
+
+// reset forgets the calls made so far.
+func (f *fakeTelegram) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// methods returns the methods called so far.
+func (f *fakeTelegram) methods() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var methods []string
+	for _, c := range f.calls {
+		methods = append(methods, c.method)
+	}
+	return methods
+}
+
+// last returns the parameters of the last call of method.
+func (f *fakeTelegram) last(method string) url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := len(f.calls) - 1; i >= 0; i-- {
+		if f.calls[i].method == method {
+			return f.calls[i].params
+		}
+	}
+	return nil
+}
+
+func TestSetAndDeleteWebhook(t *testing.T) {
+	fake, bot := newFakeTelegram(t)
+
+	if err := SetWebhook(bot, "https://example.com/telegram/webhook", "s3cret_token-1"); err != nil {
+		t.Fatal(err)
+	}
+	params := fake.last("setWebhook")
+	if params.Get("url") != "https://example.com/telegram/webhook" || params.Get("secret_token") != "s3cret_token-1" {
+		t.Fatalf("setWebhook params = %v", params)
+	}
+	if !strings.Contains(params.Get("allowed_updates"), `"inline_query"`) {
+		t.Fatalf("allowed_updates = %q", params.Get("allowed_updates"))
+	}
+
+	for _, secret := range []string{"", "has space", strings.Repeat("x", 257)} {
+		if err := SetWebhook(bot, "https://example.com/telegram/webhook", secret); err == nil {
+			t.Fatalf("SetWebhook with secret %q succeeded", secret)
+		}
+	}
+
+	if err := DeleteWebhook(bot); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fake.methods(), ","); got != "setWebhook,deleteWebhook" {
+		t.Fatalf("methods called = %s", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	fake, bot := newFakeTelegram(t)
+	h := NewHandler(Config{Roller: roll.NewRoller(rand.New(rand.NewSource(1)))})
+	webhook := h.Webhook(bot, "s3cret")
+
+	var update bytes.Buffer
+	for _, u := range loadUpdates(t, "testdata/updates.json") {
+		if u.Message.Text == "/roll +2" {
+			json.NewEncoder(&update).Encode(u)
+		}
+	}
+
+	post := func(method, secret, body string) int {
+		req := httptest.NewRequest(method, "/telegram/webhook", strings.NewReader(body))
+		if secret != "" {
+			req.Header.Set(SecretTokenHeader, secret)
+		}
+		rw := httptest.NewRecorder()
+		webhook.ServeHTTP(rw, req)
+		return rw.Code
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		secret string
+		body   string
+		code   int
+	}{
+		{"no secret", http.MethodPost, "", update.String(), http.StatusForbidden},
+		{"wrong secret", http.MethodPost, "guess", update.String(), http.StatusForbidden},
+		{"wrong method", http.MethodGet, "s3cret", "", http.StatusMethodNotAllowed},
+		{"invalid update", http.MethodPost, "s3cret", "{", http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		if code := post(c.method, c.secret, c.body); code != c.code {
+			t.Fatalf("%s: got %d, want %d", c.name, code, c.code)
+		}
+	}
+	if methods := fake.methods(); len(methods) != 0 {
+		t.Fatalf("rejected requests called %v", methods)
+	}
+
+	if code := post(http.MethodPost, "s3cret", update.String()); code != http.StatusOK {
+		t.Fatalf("got %d, want 200", code)
+	}
+	sent := fake.last("sendMessage")
+	if sent.Get("chat_id") != "42" || !strings.HasPrefix(sent.Get("text"), "🎲 (") {
+		t.Fatalf("sendMessage params = %v", sent)
+	}
+	if !strings.Contains(sent.Get("reply_markup"), "Reroll") {
+		t.Fatalf("reply_markup = %q; want reroll buttons", sent.Get("reply_markup"))
+	}
+}
+
+func TestWebhookWithoutSecretRejectsAll(t *testing.T) {
+	_, bot := newFakeTelegram(t)
+	webhook := NewHandler(Config{}).Webhook(bot, "")
+
+	req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader("{}"))
+	rw := httptest.NewRecorder()
+	webhook.ServeHTTP(rw, req)
+	if rw.Code != http.StatusForbidden {
+		t.Fatalf("got %d, want 403", rw.Code)
+	}
+}
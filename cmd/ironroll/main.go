@@ -3,10 +3,13 @@ package main
 import (
 	cryptorand "crypto/rand"
 	"encoding/binary"
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,47 +18,223 @@ import (
 	"github.com/mtzvd/ironroll/adapters/discord"
 	"github.com/mtzvd/ironroll/adapters/httpapi"
 	"github.com/mtzvd/ironroll/adapters/telegram"
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/fair"
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
 	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/ruleset"
+	"github.com/mtzvd/ironroll/core/track"
+	"github.com/mtzvd/ironroll/datasworn"
 	"github.com/mtzvd/ironroll/ratelimit"
+	"github.com/mtzvd/ironroll/store"
 	"github.com/mtzvd/ironroll/util/env"
 	"github.com/mtzvd/ironroll/util/logging"
 )
 
 func main() {
 	// ---------------------------------------------------------------------
-	// Logging
+	// Environment and logging
+	//
+	// .env is loaded first so that LOG_LEVEL may be set there; a load
+	// error is reported once logging is set up.
 	// ---------------------------------------------------------------------
 
+	envErr := env.Load()
 	logging.Setup()
 	slog.Info("starting ironroll service")
+	if envErr != nil {
+		slog.Error("failed to load .env", "err", envErr)
+	}
 
 	// ---------------------------------------------------------------------
 	// RNG initialization
 	//
-	// core/roll uses an injectable RNG via SetRand / ResetRand.
-	// We use crypto/rand for seeding to ensure true randomness,
-	// even in containerized environments where time-based seeds
-	// may produce identical values across restarts.
+	// All adapters share a single roll.Roller, which serializes access
+	// to its source and is therefore safe for concurrent use.
+	//
+	// DICE_SOURCE selects the source of randomness:
+	//   - "crypto" (default): every die is drawn from crypto/rand,
+	//     so no roll can be predicted from logs or earlier rolls
+	//   - "math": a math/rand generator seeded from DICE_SEED, or from
+	//     crypto/rand when unset. Deterministic and replayable; the seed
+	//     is only logged at debug level because it predicts every roll.
 	// ---------------------------------------------------------------------
 
-	var seedBytes [8]byte
-	if _, err := cryptorand.Read(seedBytes[:]); err != nil {
-		slog.Error("failed to seed RNG", "err", err)
+	source, err := newDiceSource(os.Getenv("DICE_SOURCE"), os.Getenv("DICE_SEED"))
+	if err != nil {
+		slog.Error("failed to initialize dice source", "err", err)
 		os.Exit(1)
 	}
-	seed := int64(binary.LittleEndian.Uint64(seedBytes[:]))
-	slog.Info("RNG initialized", "seed", seed)
-	roll.SetRand(rand.New(rand.NewSource(seed)))
+	roller := roll.NewRoller(source)
 
 	// ---------------------------------------------------------------------
-	// Environment
+	// Verifiable rolls
+	//
+	// With FAIR_ROLLS=true every roll is derived from a committed server
+	// secret and a per-roll nonce instead of DICE_SOURCE. The secret is
+	// rotated (and revealed) every FAIR_ROTATE, default 24h.
 	// ---------------------------------------------------------------------
 
-	if err := env.Load(); err != nil {
-		slog.Error("failed to load .env", "err", err)
+	var prover *fair.Prover
+	if os.Getenv("FAIR_ROLLS") == "true" {
+		prover, err = fair.NewProver()
+		if err != nil {
+			slog.Error("failed to initialize verifiable rolls", "err", err)
+			os.Exit(1)
+		}
+		roller = roll.NewVerifiableRoller(prover)
+
+		rotate := 24 * time.Hour
+		if raw := os.Getenv("FAIR_ROTATE"); raw != "" {
+			rotate, err = time.ParseDuration(raw)
+			if err != nil || rotate <= 0 {
+				slog.Error("invalid FAIR_ROTATE", "value", raw, "err", err)
+				os.Exit(1)
+			}
+		}
+
+		go func() {
+			for range time.Tick(rotate) {
+				rev, err := prover.Rotate()
+				if err != nil {
+					slog.Error("failed to rotate fair roll secret", "err", err)
+					continue
+				}
+				slog.Info("fair roll secret revealed", "commitment", rev.Commitment, "next", prover.Commitment())
+			}
+		}()
+
+		slog.Info("verifiable rolls enabled", "commitment", prover.Commitment(), "rotate", rotate)
+	}
+
+	// ---------------------------------------------------------------------
+	// Game content
+	//
+	// The built-in oracles, moves and assets of each ruleset (classic Ironsworn,
+	// Delve and Starforged) can be extended or overridden with Datasworn
+	// JSON from DATASWORN_PATH (a file or a directory of *.json files).
+	// Content goes to the ruleset named by the first segment of its ID;
+	// anything else extends classic Ironsworn.
+	// Malformed content is fatal so that problems surface at deploy time.
+	// ---------------------------------------------------------------------
+
+	rulesets := ruleset.Builtin(oracle.Builtin(), move.Builtin())
+
+	if path := os.Getenv("DATASWORN_PATH"); path != "" {
+		content, err := datasworn.Load(path)
+		if err != nil {
+			slog.Error("failed to load datasworn content", "path", path, "err", err)
+			os.Exit(1)
+		}
+
+		for _, table := range content.Oracles {
+			rs := contentRuleset(rulesets, table.ID)
+			// Keep the short key of a built-in table the import replaces.
+			if existing, ok := rs.Oracles.Get(table.ID); ok {
+				table.Key = existing.Key
+			}
+			// A key another table already has stays with it; the import is
+			// then found by its ID only.
+			if owner, ok := rs.Oracles.Get(table.Key); ok && owner.ID != table.ID {
+				slog.Warn("datasworn oracle key already taken", "id", table.ID, "key", table.Key, "owner", owner.ID)
+				table.Key = table.ID
+			}
+			if err := rs.Oracles.Add(table); err != nil {
+				slog.Error("failed to register datasworn oracle", "id", table.ID, "err", err)
+				os.Exit(1)
+			}
+		}
+
+		for _, dm := range content.Moves {
+			m, ok := dm.Playable()
+			if !ok {
+				continue
+			}
+			if err := contentRuleset(rulesets, m.ID).Moves.Add(m); err != nil {
+				slog.Error("failed to register datasworn move", "id", m.ID, "err", err)
+				os.Exit(1)
+			}
+		}
+
+		for _, da := range content.Assets {
+			a := da.Playable()
+			rs := contentRuleset(rulesets, a.ID)
+			// Keep the roll bonuses of a built-in asset the import replaces.
+			if existing, ok := rs.Assets.Get(a.ID); ok {
+				a.Key = existing.Key
+				for i := range min(len(a.Abilities), len(existing.Abilities)) {
+					a.Abilities[i].Bonuses = existing.Abilities[i].Bonuses
+				}
+			}
+			if err := rs.Assets.Add(a); err != nil {
+				slog.Error("failed to register datasworn asset", "id", a.ID, "err", err)
+				os.Exit(1)
+			}
+		}
+
+		slog.Info(
+			"datasworn content loaded",
+			"path", path,
+			"oracles", len(content.Oracles),
+			"moves", len(content.Moves),
+			"assets", len(content.Assets),
+			"skipped", len(content.Skipped),
+		)
+	}
+
+	// ---------------------------------------------------------------------
+	// Persistent state
+	//
+	// Character sheets, progress tracks and campaigns are kept in the JSON
+	// file at STORE_PATH. Without it they live in memory and are lost on
+	// restart.
+	// ---------------------------------------------------------------------
+
+	var (
+		characters character.Store = character.NewMemoryStore()
+		tracks     track.Store     = track.NewMemoryStore()
+		campaigns  campaign.Store  = campaign.NewMemoryStore()
+	)
+
+	if path := os.Getenv("STORE_PATH"); path != "" {
+		file, err := store.Open(path)
+		if err != nil {
+			slog.Error("failed to open state file", "path", path, "err", err)
+			os.Exit(1)
+		}
+		characters = file.Characters()
+		tracks = file.Tracks()
+		campaigns = file.Campaigns()
+		slog.Info("state file opened", "path", path)
+	} else {
+		slog.Warn("no STORE_PATH set, characters, tracks and campaigns will not survive a restart")
+	}
+
+	// ---------------------------------------------------------------------
+	// Roll history
+	//
+	// Every roll is appended to the JSON lines file at HISTORY_PATH.
+	// Without it only the last rolls are kept, in memory.
+	// ---------------------------------------------------------------------
+
+	var rolls history.Store = history.NewRing(history.DefaultCapacity)
+
+	if path := os.Getenv("HISTORY_PATH"); path != "" {
+		file, err := store.OpenHistory(path)
+		if err != nil {
+			slog.Error("failed to open history file", "path", path, "err", err)
+			os.Exit(1)
+		}
+		rolls = file
+		slog.Info("history file opened", "path", path)
 	}
 
 	telegramToken := os.Getenv("TELEGRAM_BOT_TOKEN")
+	webhookURL := os.Getenv("TELEGRAM_WEBHOOK_URL")
+	webhookSecret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
 	discordToken := os.Getenv("DISCORD_BOT_TOKEN")
 
 	port := os.Getenv("PORT")
@@ -73,12 +252,23 @@ func main() {
 		5*time.Minute, // temporary block
 	)
 
+	api := httpapi.New(httpapi.Config{
+		Roller:     roller,
+		Prover:     prover,
+		Rulesets:   rulesets,
+		Characters: characters,
+		Tracks:     tracks,
+		Campaigns:  campaigns,
+		History:    rolls,
+		Token:      os.Getenv("API_TOKEN"),
+	})
+
 	httpHandler := httpapi.RateLimitMiddleware(
 		limiter,
-		http.HandlerFunc(httpapi.RollHandler),
+		api.Routes(),
 	)
 
-	http.Handle("/roll", httpHandler)
+	http.Handle("/", httpHandler)
 
 	go func() {
 		slog.Info("http api started", "port", port)
@@ -89,7 +279,12 @@ func main() {
 	}()
 
 	// ---------------------------------------------------------------------
-	// Telegram Inline Bot
+	// Telegram Bot
+	//
+	// Updates are polled unless TELEGRAM_WEBHOOK_URL is set. Telegram then
+	// posts them to that public URL, which must reach the path of the same
+	// name on this server (e.g. through a reverse proxy), with
+	// TELEGRAM_WEBHOOK_SECRET as the secret token of every request.
 	// ---------------------------------------------------------------------
 
 	slog.Info("telegram token check", "present", telegramToken != "")
@@ -108,18 +303,56 @@ func main() {
 			"username", bot.Self.UserName,
 		)
 
-		u := tgbotapi.NewUpdate(0)
-		u.Timeout = 60
-		updates := bot.GetUpdatesChan(u)
+		if _, err := bot.Request(tgbotapi.NewSetMyCommands(telegram.Commands...)); err != nil {
+			slog.Error("failed to register telegram commands", "err", err)
+		}
 
-		go func() {
-			slog.Info("telegram bot started")
-			for update := range updates {
-				if update.InlineQuery != nil {
-					telegram.HandleInlineQuery(bot, update.InlineQuery)
-				}
+		handler := telegram.NewHandler(telegram.Config{
+			Roller:     roller,
+			Rulesets:   rulesets,
+			Characters: characters,
+			Campaigns:  campaigns,
+			History:    rolls,
+			// The token is secret and the same on every instance, so roll
+			// buttons keep working across restarts and instances.
+			CallbackKey: []byte(telegramToken),
+		})
+
+		if webhookURL != "" {
+			// Webhook mode: Telegram posts updates to the HTTP server.
+			// The webhook bypasses the rate limiter, which would block
+			// Telegram's servers in a busy chat.
+			path, err := webhookPath(webhookURL)
+			if err != nil {
+				slog.Error("invalid TELEGRAM_WEBHOOK_URL", "err", err)
+				os.Exit(1)
 			}
-		}()
+			http.Handle(path, handler.Webhook(bot, webhookSecret))
+
+			if err := telegram.SetWebhook(bot, webhookURL, webhookSecret); err != nil {
+				slog.Error("failed to register telegram webhook", "err", err)
+				os.Exit(1)
+			}
+			slog.Info("telegram bot started", "mode", "webhook", "path", path)
+		} else {
+			// Polling mode. A webhook left from an earlier deployment
+			// would make every poll fail, so it is removed first.
+			if err := telegram.DeleteWebhook(bot); err != nil {
+				slog.Error("failed to remove telegram webhook", "err", err)
+				os.Exit(1)
+			}
+
+			u := tgbotapi.NewUpdate(0)
+			u.Timeout = 60
+			updates := bot.GetUpdatesChan(u)
+
+			go func() {
+				slog.Info("telegram bot started", "mode", "polling")
+				for update := range updates {
+					handler.HandleUpdate(bot, update)
+				}
+			}()
+		}
 	} else {
 		slog.Warn("telegram bot disabled (no TELEGRAM_BOT_TOKEN)")
 	}
@@ -135,20 +368,29 @@ func main() {
 			os.Exit(1)
 		}
 
-		dg.AddHandler(discord.HandleInteraction)
+		handler := discord.NewHandler(discord.Config{
+			Roller:     roller,
+			Prover:     prover,
+			Rulesets:   rulesets,
+			Characters: characters,
+			Tracks:     tracks,
+			Campaigns:  campaigns,
+			History:    rolls,
+		})
+		dg.AddHandler(handler.HandleInteraction)
 
 		if err := dg.Open(); err != nil {
 			slog.Error("failed to open discord connection", "err", err)
 			os.Exit(1)
 		}
 
-		_, err = dg.ApplicationCommandCreate(
+		_, err = dg.ApplicationCommandBulkOverwrite(
 			dg.State.User.ID,
-			"", // global command
-			discord.Command,
+			"", // global commands
+			discord.Commands,
 		)
 		if err != nil {
-			slog.Error("failed to register discord command", "err", err)
+			slog.Error("failed to register discord commands", "err", err)
 			os.Exit(1)
 		}
 
@@ -163,3 +405,67 @@ func main() {
 
 	select {}
 }
+
+// contentRuleset returns the ruleset that imported Datasworn content
+// belongs to, by the first segment of its ID. Content of other rulesets
+// extends the default one.
+func contentRuleset(r *ruleset.Registry, id string) *ruleset.Ruleset {
+	if rid, ok := ruleset.Of(id); ok {
+		if rs, ok := r.Get(rid); ok {
+			return rs
+		}
+	}
+	return r.Default()
+}
+
+// webhookPath returns the path of a Telegram webhook URL, which the
+// webhook is served at. The root path is taken by the HTTP API.
+func webhookPath(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("%q is not an https URL", raw)
+	}
+	if u.Path == "" || u.Path == "/" {
+		return "", fmt.Errorf("%q needs a path, e.g. /telegram/webhook", raw)
+	}
+	return u.Path, nil
+}
+
+// newDiceSource builds the roll.Source selected by configuration.
+func newDiceSource(kind, rawSeed string) (roll.Source, error) {
+	switch kind {
+	case "", "crypto":
+		if rawSeed != "" {
+			slog.Warn("DICE_SEED is ignored by the crypto dice source")
+		}
+		slog.Info("dice source initialized", "source", "crypto")
+		return roll.CryptoSource(), nil
+
+	case "math":
+		var seed int64
+		if rawSeed != "" {
+			s, err := strconv.ParseInt(rawSeed, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DICE_SEED %q: %w", rawSeed, err)
+			}
+			seed = s
+		} else {
+			// Seed from crypto/rand so that containers restarted at the
+			// same instant do not produce identical roll sequences.
+			var seedBytes [8]byte
+			if _, err := cryptorand.Read(seedBytes[:]); err != nil {
+				return nil, fmt.Errorf("seed RNG: %w", err)
+			}
+			seed = int64(binary.LittleEndian.Uint64(seedBytes[:]))
+		}
+		slog.Info("dice source initialized", "source", "math")
+		slog.Debug("dice source seed", "seed", seed)
+		return rand.New(rand.NewSource(seed)), nil
+
+	default:
+		return nil, fmt.Errorf("unknown DICE_SOURCE %q (want crypto or math)", kind)
+	}
+}
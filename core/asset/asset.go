@@ -0,0 +1,169 @@
+package asset
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mtzvd/ironroll/core/move"
+)
+
+// Category is the kind of an asset, as printed on the card.
+type Category string
+
+const (
+	Companion    Category = "Companion"
+	Path         Category = "Path"
+	CombatTalent Category = "Combat Talent"
+	Ritual       Category = "Ritual"
+)
+
+// Bonus is an add an ability gives to rolls of a move.
+type Bonus struct {
+	Move  string      // Key of the move, e.g. "secure_an_advantage"
+	Stats []move.Stat // Stats the bonus applies to; empty for any
+	Add   int
+}
+
+// Applies reports whether the bonus applies to a roll of the move
+// with the given stat.
+func (b Bonus) Applies(moveKey string, stat move.Stat) bool {
+	if normalizeKey(b.Move) != normalizeKey(moveKey) {
+		return false
+	}
+	return len(b.Stats) == 0 || slices.Contains(b.Stats, stat)
+}
+
+// Ability is one of the (usually three) abilities of an asset.
+type Ability struct {
+	Text    string
+	Enabled bool // Marked when the asset is acquired
+
+	// Bonuses are applied to rolls once the ability is marked. Only
+	// bonuses a roll can apply on its own are listed; abilities that
+	// need a choice at the table are left to the players.
+	Bonuses []Bonus
+}
+
+// Track is a meter printed on an asset, such as companion health.
+// It runs from 0 to Max.
+type Track struct {
+	Name string
+	Max  int
+}
+
+// Asset is a single asset card.
+type Asset struct {
+	ID       string // Datasworn-style ID, e.g. "classic/assets/companion/hound"
+	Key      string // Short lookup key, e.g. "hound"
+	Name     string // Display name, e.g. "Hound"
+	Category Category
+
+	Abilities []Ability
+	Tracks    []Track
+}
+
+// Track finds a track of the asset by name, ignoring case.
+func (a Asset) Track(name string) (Track, bool) {
+	for _, t := range a.Tracks {
+		if normalizeKey(t.Name) == normalizeKey(name) {
+			return t, true
+		}
+	}
+	return Track{}, false
+}
+
+// Catalog indexes assets by ID and by key.
+//
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	mu    sync.RWMutex
+	byID  map[string]Asset
+	byKey map[string]string // key -> ID
+}
+
+// NewCatalog returns a catalog holding the given assets.
+func NewCatalog(assets ...Asset) (*Catalog, error) {
+	c := &Catalog{
+		byID:  make(map[string]Asset),
+		byKey: make(map[string]string),
+	}
+
+	for _, a := range assets {
+		if err := c.Add(a); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Add adds an asset to the catalog, replacing any asset with the same
+// ID. A key already used by a different asset is reassigned to the new
+// asset.
+func (c *Catalog) Add(a Asset) error {
+	if a.ID == "" || a.Key == "" || a.Name == "" {
+		return fmt.Errorf("asset: %q: id, key and name are required", a.ID)
+	}
+	if len(a.Abilities) == 0 {
+		return fmt.Errorf("asset: %q: at least one ability is required", a.ID)
+	}
+	for _, t := range a.Tracks {
+		if t.Name == "" || t.Max <= 0 {
+			return fmt.Errorf("asset: %q: tracks need a name and a positive maximum", a.ID)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.byID[a.ID]; ok {
+		delete(c.byKey, normalizeKey(old.Key))
+	}
+	c.byID[a.ID] = a
+	c.byKey[normalizeKey(a.Key)] = a.ID
+	return nil
+}
+
+// Get finds an asset by ID, key or name. Keys and names are matched
+// case-insensitively, with spaces, dashes and underscores treated
+// alike, so "Sword Master" and "sword-master" find "sword_master".
+func (c *Catalog) Get(name string) (Asset, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if a, ok := c.byID[name]; ok {
+		return a, true
+	}
+	if id, ok := c.byKey[normalizeKey(name)]; ok {
+		return c.byID[id], true
+	}
+	for _, a := range c.byID {
+		if normalizeKey(a.Name) == normalizeKey(name) {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Assets returns every asset in the catalog ordered by ID.
+func (c *Catalog) Assets() []Asset {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]Asset, 0, len(c.byID))
+	for _, a := range c.byID {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// normalizeKey folds a user-supplied asset or move name into its
+// canonical form.
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	key = strings.NewReplacer("-", " ", "_", " ").Replace(key)
+	return strings.Join(strings.Fields(key), "_")
+}
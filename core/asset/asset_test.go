@@ -0,0 +1,96 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/move"
+)
+
+func TestBuiltin(t *testing.T) {
+	c := Builtin()
+	moves := move.Builtin()
+
+	assets := c.Assets()
+	if len(assets) != len(classicAssets()) {
+		t.Fatalf("expected %d assets, got %d", len(classicAssets()), len(assets))
+	}
+
+	categories := make(map[Category]bool)
+	for _, a := range assets {
+		categories[a.Category] = true
+		for _, ab := range a.Abilities {
+			for _, b := range ab.Bonuses {
+				m, ok := moves.Get(b.Move)
+				if !ok {
+					t.Fatalf("%s: bonus to unknown move %q", a.ID, b.Move)
+				}
+				for _, s := range b.Stats {
+					if !m.Allows(s) {
+						t.Fatalf("%s: %s is not rolled with %s", a.ID, m.Name, s)
+					}
+				}
+			}
+		}
+	}
+	for _, cat := range []Category{Companion, Path, CombatTalent, Ritual} {
+		if !categories[cat] {
+			t.Fatalf("no built-in %s asset", cat)
+		}
+	}
+}
+
+func TestCatalogGet(t *testing.T) {
+	c := Builtin()
+
+	for _, name := range []string{"classic/assets/companion/hound", "hound", "Hound", " HOUND "} {
+		a, ok := c.Get(name)
+		if !ok || a.Key != "hound" {
+			t.Fatalf("Get(%q) = %q, %v; want hound", name, a.Key, ok)
+		}
+	}
+	if _, ok := c.Get("dragon"); ok {
+		t.Error("expected unknown asset to be missing")
+	}
+}
+
+func TestCatalogAdd(t *testing.T) {
+	c, err := NewCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad := []Asset{
+		{Key: "x", Name: "X", Abilities: []Ability{{Text: "x"}}},
+		{ID: "x", Key: "x", Name: "X"},
+		{ID: "x", Key: "x", Name: "X", Abilities: []Ability{{Text: "x"}}, Tracks: []Track{{Name: "health"}}},
+	}
+	for _, a := range bad {
+		if err := c.Add(a); err == nil {
+			t.Fatalf("expected error adding %+v", a)
+		}
+	}
+
+	old := Asset{ID: "a/assets/x", Key: "old", Name: "X", Abilities: []Ability{{Text: "x"}}}
+	replaced := Asset{ID: "a/assets/x", Key: "new", Name: "X", Abilities: []Ability{{Text: "y"}}}
+	for _, a := range []Asset{old, replaced} {
+		if err := c.Add(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := c.Get("old"); ok {
+		t.Error("expected the old key to be dropped")
+	}
+	if a, ok := c.Get("new"); !ok || a.Abilities[0].Text != "y" {
+		t.Fatalf("Get(new) = %+v, %v", a, ok)
+	}
+}
+
+func TestTrack(t *testing.T) {
+	a, _ := Builtin().Get("hound")
+	if tr, ok := a.Track("Health"); !ok || tr.Max != 4 {
+		t.Fatalf("Track(Health) = %+v, %v", tr, ok)
+	}
+	if _, ok := a.Track("spirit"); ok {
+		t.Error("expected no spirit track")
+	}
+}
@@ -0,0 +1,136 @@
+package asset
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/mtzvd/ironroll/core/move"
+)
+
+// Card is an asset attached to a character: the marked abilities and
+// the current value of each track.
+type Card struct {
+	Asset     string         `json:"asset"`     // Asset ID
+	Abilities []bool         `json:"abilities"` // Marked abilities, in card order
+	Tracks    map[string]int `json:"tracks,omitempty"`
+}
+
+// NewCard returns a card for a newly acquired asset, with the abilities
+// enabled by default marked and every track full.
+func NewCard(a Asset) Card {
+	c := Card{Asset: a.ID, Abilities: make([]bool, len(a.Abilities))}
+	for i, ab := range a.Abilities {
+		c.Abilities[i] = ab.Enabled
+	}
+	for _, t := range a.Tracks {
+		if c.Tracks == nil {
+			c.Tracks = make(map[string]int)
+		}
+		c.Tracks[t.Name] = t.Max
+	}
+	return c
+}
+
+// Clone returns a copy of c that shares no memory with it.
+func (c Card) Clone() Card {
+	c.Abilities = slices.Clone(c.Abilities)
+	c.Tracks = maps.Clone(c.Tracks)
+	return c
+}
+
+// Marked reports whether the ability at index i (0-based) is marked.
+func (c Card) Marked(i int) bool {
+	return i >= 0 && i < len(c.Abilities) && c.Abilities[i]
+}
+
+// Track returns the current value of a track of the card's asset. A
+// track the card does not record, such as one added to the asset after
+// the card was made, is full.
+func (c Card) Track(t Track) int {
+	if v, ok := c.Tracks[t.Name]; ok {
+		return v
+	}
+	return t.Max
+}
+
+// Mark marks or clears ability n of the asset, counting from 1 as
+// printed on the card.
+func (c *Card) Mark(a Asset, n int, marked bool) error {
+	if n < 1 || n > len(a.Abilities) {
+		return fmt.Errorf("%s has abilities 1 to %d", a.Name, len(a.Abilities))
+	}
+	// Cards saved before the asset gained abilities are padded.
+	for len(c.Abilities) < len(a.Abilities) {
+		c.Abilities = append(c.Abilities, false)
+	}
+	c.Abilities[n-1] = marked
+	return nil
+}
+
+// SetTrack sets a track of the asset, such as a companion's health.
+func (c *Card) SetTrack(a Asset, name string, value int) error {
+	t, ok := a.Track(name)
+	if !ok {
+		return fmt.Errorf("%s has no track %q", a.Name, name)
+	}
+	if value < 0 || value > t.Max {
+		return fmt.Errorf("%s %s must be between 0 and %d", a.Name, t.Name, t.Max)
+	}
+	if c.Tracks == nil {
+		c.Tracks = make(map[string]int)
+	}
+	c.Tracks[t.Name] = value
+	return nil
+}
+
+// Validate checks the card against its asset.
+func (c Card) Validate(a Asset) error {
+	if c.Asset != a.ID {
+		return fmt.Errorf("asset: card is for %q, not %q", c.Asset, a.ID)
+	}
+	if len(c.Abilities) > len(a.Abilities) {
+		return fmt.Errorf("asset: %s has %d abilities, card marks %d", a.ID, len(a.Abilities), len(c.Abilities))
+	}
+	for name, v := range c.Tracks {
+		t, ok := a.Track(name)
+		if !ok {
+			return fmt.Errorf("asset: %s has no track %q", a.ID, name)
+		}
+		if v < 0 || v > t.Max {
+			return fmt.Errorf("asset: %s %s must be between 0 and %d", a.ID, t.Name, t.Max)
+		}
+	}
+	return nil
+}
+
+// RollBonus returns the total add the marked abilities of cards give to a
+// roll of the move with a stat, and the names of the assets that
+// contributed, in card order. lookup finds an asset by ID; cards it
+// does not find are skipped.
+func RollBonus(cards []Card, lookup func(id string) (Asset, bool), moveKey string, stat move.Stat) (int, []string) {
+	add := 0
+	var names []string
+	for _, c := range cards {
+		a, ok := lookup(c.Asset)
+		if !ok {
+			continue
+		}
+		contributed := false
+		for i, ab := range a.Abilities {
+			if !c.Marked(i) {
+				continue
+			}
+			for _, b := range ab.Bonuses {
+				if b.Applies(moveKey, stat) {
+					add += b.Add
+					contributed = true
+				}
+			}
+		}
+		if contributed {
+			names = append(names, a.Name)
+		}
+	}
+	return add, names
+}
@@ -0,0 +1,110 @@
+package asset
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/move"
+)
+
+func TestNewCard(t *testing.T) {
+	a, _ := Builtin().Get("hound")
+	c := NewCard(a)
+
+	if c.Asset != a.ID {
+		t.Fatalf("asset = %q", c.Asset)
+	}
+	if !slices.Equal(c.Abilities, []bool{true, false, false}) {
+		t.Fatalf("abilities = %v", c.Abilities)
+	}
+	if c.Tracks["health"] != 4 {
+		t.Fatalf("health = %d, want 4", c.Tracks["health"])
+	}
+	if err := c.Validate(a); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCardMark(t *testing.T) {
+	a, _ := Builtin().Get("hound")
+	c := Card{Asset: a.ID}
+
+	if err := c.Mark(a, 3, true); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(c.Abilities, []bool{false, false, true}) {
+		t.Fatalf("abilities = %v", c.Abilities)
+	}
+	for _, n := range []int{0, 4} {
+		if err := c.Mark(a, n, true); err == nil {
+			t.Fatalf("expected error marking ability %d", n)
+		}
+	}
+}
+
+func TestCardSetTrack(t *testing.T) {
+	a, _ := Builtin().Get("hound")
+	c := NewCard(a)
+
+	if err := c.SetTrack(a, "Health", 2); err != nil {
+		t.Fatal(err)
+	}
+	if v := c.Track(a.Tracks[0]); v != 2 {
+		t.Fatalf("health = %d, want 2", v)
+	}
+	if v := (Card{Asset: a.ID}).Track(a.Tracks[0]); v != 4 {
+		t.Fatalf("unrecorded health = %d, want 4", v)
+	}
+	if err := c.SetTrack(a, "health", 5); err == nil {
+		t.Error("expected error above the maximum")
+	}
+	if err := c.SetTrack(a, "spirit", 1); err == nil {
+		t.Error("expected error for an unknown track")
+	}
+}
+
+func TestCardValidate(t *testing.T) {
+	a, _ := Builtin().Get("hound")
+
+	bad := []Card{
+		{Asset: "classic/assets/path/slayer"},
+		{Asset: a.ID, Abilities: []bool{true, true, true, true}},
+		{Asset: a.ID, Tracks: map[string]int{"health": 5}},
+		{Asset: a.ID, Tracks: map[string]int{"spirit": 1}},
+	}
+	for _, c := range bad {
+		if err := c.Validate(a); err == nil {
+			t.Fatalf("expected error for %+v", c)
+		}
+	}
+}
+
+func TestRollBonus(t *testing.T) {
+	catalog := Builtin()
+	hound, _ := catalog.Get("hound")
+	slayer, _ := catalog.Get("slayer")
+
+	houndCard := NewCard(hound)
+	slayerCard := NewCard(slayer)
+	slayerCard.Mark(slayer, 1, true)
+	cards := []Card{houndCard, slayerCard, {Asset: "unknown/asset"}}
+
+	cases := []struct {
+		move  string
+		stat  move.Stat
+		add   int
+		names []string
+	}{
+		{"gather_information", move.Wits, 2, []string{"Hound", "Slayer"}},
+		{"Gather Information", move.Heart, 1, []string{"Slayer"}},
+		{"endure_stress", move.Spirit, 0, nil},
+		{"face_danger", move.Wits, 0, nil},
+	}
+
+	for _, c := range cases {
+		add, names := RollBonus(cards, catalog.Get, c.move, c.stat)
+		if add != c.add || !slices.Equal(names, c.names) {
+			t.Fatalf("RollBonus(%s, %s) = %d, %v; want %d, %v", c.move, c.stat, add, names, c.add, c.names)
+		}
+	}
+}
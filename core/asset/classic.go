@@ -0,0 +1,91 @@
+package asset
+
+import "github.com/mtzvd/ironroll/core/move"
+
+// A sample of classic Ironsworn assets, one or more per category. The
+// full set is imported from Datasworn.
+//
+// The asset text is condensed from Ironsworn by Shawn Tomkin,
+// licensed under CC BY 4.0 (https://creativecommons.org/licenses/by/4.0/).
+
+// Builtin returns a catalog holding the built-in classic assets.
+func Builtin() *Catalog {
+	c, err := NewCatalog(classicAssets()...)
+	if err != nil {
+		// The built-in assets are covered by tests; an invalid one is a bug.
+		panic(err)
+	}
+	return c
+}
+
+// classicAssets returns the built-in classic Ironsworn assets.
+func classicAssets() []Asset {
+	return []Asset{
+		{
+			ID: "classic/assets/companion/hound", Key: "hound", Name: "Hound", Category: Companion,
+			Abilities: []Ability{
+				{
+					Text:    "Sharp: When you Gather Information using their keen senses to track your quarry or investigate a scene, add +1 and take +1 momentum on a hit.",
+					Enabled: true,
+					Bonuses: []Bonus{{Move: "gather_information", Stats: []move.Stat{move.Wits}, Add: 1}},
+				},
+				{Text: "Ferocious: When you Strike or Clash alongside your hound and score a match, you may resolve it as a strong hit."},
+				{
+					Text:    "Loyal: When you Endure Stress in the company of your hound, add +1.",
+					Bonuses: []Bonus{{Move: "endure_stress", Add: 1}},
+				},
+			},
+			Tracks: []Track{{Name: "health", Max: 4}},
+		},
+		{
+			ID: "classic/assets/path/slayer", Key: "slayer", Name: "Slayer", Category: Path,
+			Abilities: []Ability{
+				{
+					Text:    "When you Gather Information to track or study a monstrous foe, add +1 and take +1 momentum on a hit.",
+					Bonuses: []Bonus{{Move: "gather_information", Add: 1}},
+				},
+				{Text: "When you Swear an Iron Vow to slay a monstrous creature, you may reroll any dice."},
+				{
+					Text:    "When you Endure Stress from the horror of a monstrous foe, add +1.",
+					Bonuses: []Bonus{{Move: "endure_stress", Add: 1}},
+				},
+			},
+		},
+		{
+			ID: "classic/assets/combat_talent/archer", Key: "archer", Name: "Archer", Category: CombatTalent,
+			Abilities: []Ability{
+				{
+					Text:    "When you Secure an Advantage by taking a moment to aim, add +1 and take +1 momentum on a hit.",
+					Bonuses: []Bonus{{Move: "secure_an_advantage", Stats: []move.Stat{move.Edge}, Add: 1}},
+				},
+				{
+					Text:    "When you Strike with your bow, add +1.",
+					Bonuses: []Bonus{{Move: "strike", Stats: []move.Stat{move.Edge}, Add: 1}},
+				},
+				{Text: "When you Resupply by recovering or crafting arrows, take +1 supply on a hit."},
+			},
+		},
+		{
+			ID: "classic/assets/combat_talent/ironclad", Key: "ironclad", Name: "Ironclad", Category: CombatTalent,
+			Abilities: []Ability{
+				{Text: "When you equip or adjust your armor, choose your protection. While geared for war, add +1 when you Endure Harm in a fight."},
+				{
+					Text:    "When you Clash while wearing your armor, add +1.",
+					Bonuses: []Bonus{{Move: "clash", Stats: []move.Stat{move.Iron}, Add: 1}},
+				},
+				{
+					Text:    "When you Enter the Fray by boldly facing your foes head-on, add +1.",
+					Bonuses: []Bonus{{Move: "enter_the_fray", Stats: []move.Stat{move.Heart}, Add: 1}},
+				},
+			},
+		},
+		{
+			ID: "classic/assets/ritual/invoke", Key: "invoke", Name: "Invoke", Category: Ritual,
+			Abilities: []Ability{
+				{Text: "When you perform this ritual to draw on the essence of a place, roll +spirit. On a strong hit, take +2 momentum or a +1 on your next move there."},
+				{Text: "As above, and you may suffer -1 spirit to reroll your action die."},
+				{Text: "As above, and on a strong hit you also glimpse what the place has seen."},
+			},
+		},
+	}
+}
@@ -0,0 +1,17 @@
+// Package asset models Ironsworn assets and the asset cards of a
+// character.
+//
+// An Asset is the printed card: a companion, path, combat talent or
+// ritual with (usually three) abilities and optional tracks such as a
+// companion's health. Abilities may carry move bonuses, such as +1 on
+// Secure an Advantage, that apply automatically to rolls of the named
+// move once the ability is marked.
+//
+// A Card is an asset attached to a character sheet: which abilities
+// are marked and the current value of each track. Cards refer to their
+// asset by ID, so the asset text is not copied into the sheet.
+//
+// Like moves, assets are identified by a Datasworn-style ID
+// ("classic/assets/companion/hound") and a short key ("hound"), and a
+// Catalog indexes them by both.
+package asset
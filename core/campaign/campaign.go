@@ -0,0 +1,146 @@
+package campaign
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Binding keys for the chat locations a campaign can be bound to.
+
+// DiscordChannel returns the binding key of a Discord channel.
+// Its server is included so that campaigns can be listed per server.
+func DiscordChannel(guildID, channelID string) string {
+	return DiscordGuild(guildID) + ":channel:" + channelID
+}
+
+// DiscordGuild returns the binding key of a whole Discord server.
+func DiscordGuild(id string) string { return "discord:guild:" + id }
+
+// TelegramChat returns the binding key of a Telegram chat.
+func TelegramChat(id string) string { return "telegram:chat:" + id }
+
+// Campaign is a group of characters and shared progress.
+type Campaign struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Ruleset  string   `json:"ruleset,omitempty"`  // Ruleset ID (see core/ruleset); empty for the default
+	Bindings []string `json:"bindings,omitempty"` // Chat locations bound to the campaign
+	Members  []string `json:"members,omitempty"`  // Character owners of the party
+}
+
+// New returns a campaign with a fresh random ID.
+func New(name string) (Campaign, error) {
+	c := Campaign{ID: newID(), Name: strings.TrimSpace(name)}
+	if err := c.Validate(); err != nil {
+		return Campaign{}, err
+	}
+	return c, nil
+}
+
+// newID returns a short random campaign ID.
+func newID() string {
+	var b [4]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms.
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Validate checks that the campaign has an ID and a name.
+func (c Campaign) Validate() error {
+	switch {
+	case c.ID == "":
+		return errors.New("campaign: id is required")
+	case c.Name == "":
+		return errors.New("campaign: name is required")
+	}
+	return nil
+}
+
+// Owner returns the owner key of state shared by the whole campaign.
+func (c Campaign) Owner() string {
+	return "campaign:" + c.ID
+}
+
+// CharacterOwner returns the owner key of a user's character
+// within the campaign. user is the platform user key, such as
+// "discord:<user>" or "telegram:<user>".
+func (c Campaign) CharacterOwner(user string) string {
+	return c.Owner() + ":" + user
+}
+
+// SplitOwner splits an owner key made by Owner or CharacterOwner into
+// the campaign ID and the platform user key, which is empty for the
+// campaign's shared owner. Any other owner is returned as the user
+// with an empty campaign ID.
+func SplitOwner(owner string) (id, user string) {
+	rest, ok := strings.CutPrefix(owner, "campaign:")
+	if !ok {
+		return "", owner
+	}
+	id, user, _ = strings.Cut(rest, ":")
+	return id, user
+}
+
+// Bind binds the campaign to a chat location.
+func (c *Campaign) Bind(binding string) {
+	if !c.Bound(binding) {
+		c.Bindings = append(c.Bindings, binding)
+	}
+}
+
+// Unbind removes a chat location binding.
+func (c *Campaign) Unbind(binding string) {
+	c.Bindings = remove(c.Bindings, binding)
+}
+
+// Bound reports whether the campaign is bound to a chat location.
+func (c Campaign) Bound(binding string) bool {
+	return contains(c.Bindings, binding)
+}
+
+// AddMember adds a character owner to the party.
+func (c *Campaign) AddMember(owner string) {
+	if !c.HasMember(owner) {
+		c.Members = append(c.Members, owner)
+	}
+}
+
+// RemoveMember removes a character owner from the party.
+func (c *Campaign) RemoveMember(owner string) {
+	c.Members = remove(c.Members, owner)
+}
+
+// HasMember reports whether a character owner belongs to the party.
+func (c Campaign) HasMember(owner string) bool {
+	return contains(c.Members, owner)
+}
+
+// Clone returns a copy of c that shares no slices with it.
+func (c Campaign) Clone() Campaign {
+	c.Bindings = append([]string(nil), c.Bindings...)
+	c.Members = append([]string(nil), c.Members...)
+	return c
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, s string) []string {
+	var out []string
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package campaign
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	c, err := New("  The Ironlands  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "The Ironlands" || len(c.ID) != 8 {
+		t.Fatalf("unexpected campaign: %+v", c)
+	}
+
+	if _, err := New(" "); err == nil {
+		t.Fatal("expected an error for an empty name")
+	}
+}
+
+func TestOwners(t *testing.T) {
+	camp := Campaign{ID: "abc", Name: "X"}
+
+	if got := camp.Owner(); got != "campaign:abc" {
+		t.Fatalf("Owner() = %q", got)
+	}
+	if got := camp.CharacterOwner("discord:42"); got != "campaign:abc:discord:42" {
+		t.Fatalf("CharacterOwner() = %q", got)
+	}
+
+	cases := []struct {
+		owner, id, user string
+	}{
+		{camp.Owner(), "abc", ""},
+		{camp.CharacterOwner("discord:42"), "abc", "discord:42"},
+		{"discord:42", "", "discord:42"},
+	}
+	for _, c := range cases {
+		if id, user := SplitOwner(c.owner); id != c.id || user != c.user {
+			t.Fatalf("SplitOwner(%q) = %q, %q; want %q, %q", c.owner, id, user, c.id, c.user)
+		}
+	}
+}
+
+func TestBindingsAndMembers(t *testing.T) {
+	c := Campaign{ID: "abc", Name: "X"}
+
+	c.Bind(DiscordChannel("g", "1"))
+	c.Bind(DiscordChannel("g", "1"))
+	c.Bind(TelegramChat("-100"))
+	if len(c.Bindings) != 2 || !c.Bound("telegram:chat:-100") {
+		t.Fatalf("unexpected bindings: %v", c.Bindings)
+	}
+	c.Unbind(DiscordChannel("g", "1"))
+	if c.Bound(DiscordChannel("g", "1")) {
+		t.Fatal("expected the channel to be unbound")
+	}
+
+	c.AddMember("campaign:abc:discord:42")
+	c.AddMember("campaign:abc:discord:42")
+	if len(c.Members) != 1 || !c.HasMember("campaign:abc:discord:42") {
+		t.Fatalf("unexpected members: %v", c.Members)
+	}
+	c.RemoveMember("campaign:abc:discord:42")
+	if c.HasMember("campaign:abc:discord:42") {
+		t.Fatal("expected the member to be removed")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	s := NewMemoryStore()
+
+	server := Campaign{ID: "s", Name: "Server", Bindings: []string{DiscordGuild("g")}}
+	channel := Campaign{ID: "c", Name: "Channel", Bindings: []string{DiscordChannel("g", "ch")}}
+	for _, c := range []Campaign{server, channel} {
+		if err := s.Put(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The channel binding is more specific than the server binding.
+	if got, err := Resolve(s, DiscordChannel("g", "ch"), DiscordGuild("g")); err != nil || got.ID != "c" {
+		t.Fatalf("Resolve(channel) = %+v, %v", got, err)
+	}
+	if got, err := Resolve(s, DiscordChannel("g", "other"), DiscordGuild("g")); err != nil || got.ID != "s" {
+		t.Fatalf("Resolve(server) = %+v, %v", got, err)
+	}
+	if _, err := Resolve(s, TelegramChat("1")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Resolve(unbound) err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreRejectsSharedBinding(t *testing.T) {
+	s := NewMemoryStore()
+
+	if err := s.Put(Campaign{ID: "a", Name: "A", Bindings: []string{DiscordChannel("g", "1")}}); err != nil {
+		t.Fatal(err)
+	}
+	err := s.Put(Campaign{ID: "b", Name: "B", Bindings: []string{DiscordChannel("g", "1")}})
+	if !errors.Is(err, ErrBound) {
+		t.Fatalf("Put with a taken binding: err = %v; want ErrBound", err)
+	}
+
+	// Re-saving the owner of the binding is fine.
+	if err := s.Put(Campaign{ID: "a", Name: "A2", Bindings: []string{DiscordChannel("g", "1")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, _ := s.List()
+	if len(list) != 1 || list[0].Name != "A2" {
+		t.Fatalf("unexpected campaigns: %+v", list)
+	}
+
+	if err := s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v; want ErrNotFound", err)
+	}
+}
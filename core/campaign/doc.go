@@ -0,0 +1,16 @@
+// Package campaign groups the shared state of one Ironsworn campaign.
+//
+// A campaign is bound to one or more chat locations: a Discord channel,
+// a whole Discord server, or a Telegram chat. Commands issued in a bound
+// location act on that campaign, so one server can run several
+// campaigns side by side in different channels.
+//
+// A campaign scopes state kept elsewhere by owner key:
+//
+//   - Owner() ("campaign:<id>") owns shared progress tracks, such as
+//     a vow the whole party swore.
+//   - CharacterOwner(user) ("campaign:<id>:<user>") owns a player's
+//     character sheet and personal tracks within the campaign.
+//
+// The Members list records the character owners of the party.
+package campaign
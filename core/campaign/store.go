@@ -0,0 +1,139 @@
+package campaign
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Errors returned by stores.
+var (
+	ErrNotFound = errors.New("campaign: not found")
+	ErrBound    = errors.New("campaign: location is bound to another campaign")
+)
+
+// Store persists campaigns keyed by ID.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the campaign with the given ID, or ErrNotFound.
+	Get(id string) (Campaign, error)
+
+	// List returns every campaign, ordered by name.
+	List() ([]Campaign, error)
+
+	// Put creates or replaces a campaign. It returns ErrBound when
+	// one of its bindings belongs to another campaign.
+	Put(c Campaign) error
+
+	// Delete removes a campaign. Deleting a missing campaign is not an error.
+	Delete(id string) error
+}
+
+// Resolve returns the campaign bound to the first of the given
+// bindings that has one, or ErrNotFound. Pass the most specific
+// binding first, e.g. a Discord channel before its server.
+func Resolve(s Store, bindings ...string) (Campaign, error) {
+	campaigns, err := s.List()
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	for _, b := range bindings {
+		for _, c := range campaigns {
+			if c.Bound(b) {
+				return c, nil
+			}
+		}
+	}
+	return Campaign{}, ErrNotFound
+}
+
+// CheckBindings returns ErrBound when c shares a binding
+// with any other campaign in existing.
+func CheckBindings(existing []Campaign, c Campaign) error {
+	for _, other := range existing {
+		if other.ID == c.ID {
+			continue
+		}
+		for _, b := range c.Bindings {
+			if other.Bound(b) {
+				return ErrBound
+			}
+		}
+	}
+	return nil
+}
+
+// SortByName orders campaigns by name, then by ID.
+func SortByName(campaigns []Campaign) {
+	sort.Slice(campaigns, func(i, j int) bool {
+		if campaigns[i].Name != campaigns[j].Name {
+			return campaigns[i].Name < campaigns[j].Name
+		}
+		return campaigns[i].ID < campaigns[j].ID
+	})
+}
+
+// MemoryStore is a Store that keeps campaigns in memory.
+// Its contents are lost when the process exits.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	campaigns map[string]Campaign
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{campaigns: make(map[string]Campaign)}
+}
+
+func (m *MemoryStore) Get(id string) (Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	c, ok := m.campaigns[id]
+	if !ok {
+		return Campaign{}, ErrNotFound
+	}
+	return c.Clone(), nil
+}
+
+func (m *MemoryStore) List() ([]Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]Campaign, 0, len(m.campaigns))
+	for _, c := range m.campaigns {
+		out = append(out, c.Clone())
+	}
+	SortByName(out)
+	return out, nil
+}
+
+func (m *MemoryStore) Put(c Campaign) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing := make([]Campaign, 0, len(m.campaigns))
+	for _, other := range m.campaigns {
+		existing = append(existing, other)
+	}
+	if err := CheckBindings(existing, c); err != nil {
+		return err
+	}
+
+	m.campaigns[c.ID] = c.Clone()
+	return nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.campaigns, id)
+	return nil
+}
@@ -0,0 +1,15 @@
+// Package character models Ironsworn character sheets.
+//
+// A Sheet holds the five stats, the condition meters, momentum and
+// debilities of one player's character, along with its asset cards,
+// experience and Starforged legacy tracks. Sheets are keyed by an owner
+// string that the adapters derive from the platform user ID, such as
+// "telegram:1234" or "discord:<guild>:<user>", so the same person has
+// one character per Telegram account and one per Discord server.
+//
+// Sheets are stored behind the Store interface. MemoryStore keeps them
+// in memory; the store package provides a JSON file implementation.
+//
+// Adapters use a sheet to resolve a roll such as "+wits" into the
+// character's stat value and current momentum.
+package character
@@ -0,0 +1,314 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Stat and meter bounds.
+const (
+	MinStat = 1
+	MaxStat = 3
+
+	MinMeter = 0
+	MaxMeter = 5
+
+	// StartMomentum is the momentum of a new character,
+	// and the momentum reset without debilities.
+	StartMomentum = 2
+)
+
+// Debility is a condition, bane or burden marked on a sheet.
+type Debility string
+
+const (
+	// Conditions
+	Wounded    Debility = "wounded"
+	Shaken     Debility = "shaken"
+	Unprepared Debility = "unprepared"
+	Encumbered Debility = "encumbered"
+
+	// Banes
+	Maimed    Debility = "maimed"
+	Corrupted Debility = "corrupted"
+
+	// Burdens
+	Cursed    Debility = "cursed"
+	Tormented Debility = "tormented"
+)
+
+// AllDebilities lists every debility in sheet order.
+var AllDebilities = []Debility{
+	Wounded, Shaken, Unprepared, Encumbered,
+	Maimed, Corrupted,
+	Cursed, Tormented,
+}
+
+// ParseDebility converts user input such as "Wounded" into a Debility.
+func ParseDebility(raw string) (Debility, bool) {
+	d := Debility(strings.ToLower(strings.TrimSpace(raw)))
+	for _, debility := range AllDebilities {
+		if d == debility {
+			return debility, true
+		}
+	}
+	return "", false
+}
+
+// Sheet is one player's Ironsworn character.
+type Sheet struct {
+	Owner string `json:"owner"` // Platform-qualified user ID, e.g. "telegram:1234"
+	Name  string `json:"name"`
+
+	Edge   int `json:"edge"`
+	Heart  int `json:"heart"`
+	Iron   int `json:"iron"`
+	Shadow int `json:"shadow"`
+	Wits   int `json:"wits"`
+
+	Health   int `json:"health"`
+	Spirit   int `json:"spirit"`
+	Supply   int `json:"supply"`
+	Momentum int `json:"momentum"`
+
+	Debilities []Debility `json:"debilities,omitempty"`
+
+	Assets []asset.Card `json:"assets,omitempty"`
+
+	XPEarned int                    `json:"xp_earned,omitempty"`
+	XPSpent  int                    `json:"xp_spent,omitempty"`
+	Legacies map[Legacy]LegacyTrack `json:"legacies,omitempty"` // Starforged legacy tracks
+}
+
+// New returns a fresh sheet for owner with every stat at its minimum,
+// full condition meters and starting momentum.
+func New(owner, name string) Sheet {
+	return Sheet{
+		Owner:    owner,
+		Name:     name,
+		Edge:     MinStat,
+		Heart:    MinStat,
+		Iron:     MinStat,
+		Shadow:   MinStat,
+		Wits:     MinStat,
+		Health:   MaxMeter,
+		Spirit:   MaxMeter,
+		Supply:   MaxMeter,
+		Momentum: StartMomentum,
+	}
+}
+
+// Clone returns a deep copy of s: changing the debilities, asset cards
+// or legacy tracks of either does not change the other. Stores hand out
+// and keep clones so that sheets being edited never share memory with
+// stored ones.
+func (s Sheet) Clone() Sheet {
+	s.Debilities = slices.Clone(s.Debilities)
+	if s.Assets != nil {
+		cards := make([]asset.Card, len(s.Assets))
+		for i, c := range s.Assets {
+			cards[i] = c.Clone()
+		}
+		s.Assets = cards
+	}
+	s.Legacies = maps.Clone(s.Legacies)
+	return s
+}
+
+// field returns a pointer to the stat or meter with the given name.
+func (s *Sheet) field(name move.Stat) *int {
+	switch name {
+	case move.Edge:
+		return &s.Edge
+	case move.Heart:
+		return &s.Heart
+	case move.Iron:
+		return &s.Iron
+	case move.Shadow:
+		return &s.Shadow
+	case move.Wits:
+		return &s.Wits
+	case move.Health:
+		return &s.Health
+	case move.Spirit:
+		return &s.Spirit
+	case move.Supply:
+		return &s.Supply
+	default:
+		return nil
+	}
+}
+
+// Value returns the value of a stat or condition meter.
+func (s Sheet) Value(stat move.Stat) (int, bool) {
+	p := s.field(stat)
+	if p == nil {
+		return 0, false
+	}
+	return *p, true
+}
+
+// Set changes a stat, a condition meter or momentum by name.
+// It rejects unknown names and values outside the allowed range.
+func (s *Sheet) Set(name string, value int) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "momentum" {
+		if value < roll.MinMomentum || value > s.MaxMomentum() {
+			return fmt.Errorf("momentum must be between %d and %d", roll.MinMomentum, s.MaxMomentum())
+		}
+		s.Momentum = value
+		return nil
+	}
+
+	stat, ok := move.ParseStat(name)
+	if !ok {
+		return fmt.Errorf("unknown stat %q", name)
+	}
+
+	lo, hi := Range(stat)
+	if value < lo || value > hi {
+		return fmt.Errorf("%s must be between %d and %d", stat, lo, hi)
+	}
+	*s.field(stat) = value
+	return nil
+}
+
+// Mark marks or clears a debility. Marking a debility lowers the
+// maximum momentum, and momentum above the new maximum is reduced.
+func (s *Sheet) Mark(d Debility, marked bool) {
+	var kept []Debility
+	for _, existing := range s.Debilities {
+		if existing != d {
+			kept = append(kept, existing)
+		}
+	}
+	if marked {
+		kept = append(kept, d)
+	}
+	s.Debilities = kept
+
+	s.Momentum = min(s.Momentum, s.MaxMomentum())
+}
+
+// Marked reports whether a debility is marked.
+func (s Sheet) Marked(d Debility) bool {
+	for _, existing := range s.Debilities {
+		if existing == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Card returns the card of the asset with the given ID, or nil when
+// the character does not have the asset.
+func (s *Sheet) Card(id string) *asset.Card {
+	for i := range s.Assets {
+		if s.Assets[i].Asset == id {
+			return &s.Assets[i]
+		}
+	}
+	return nil
+}
+
+// AddAsset attaches a newly acquired asset to the sheet.
+func (s *Sheet) AddAsset(a asset.Asset) error {
+	if s.Card(a.ID) != nil {
+		return fmt.Errorf("%s already has %s", s.Name, a.Name)
+	}
+	s.Assets = append(s.Assets, asset.NewCard(a))
+	return nil
+}
+
+// RemoveAsset removes the asset with the given ID, reporting whether
+// the character had it.
+func (s *Sheet) RemoveAsset(id string) bool {
+	for i, c := range s.Assets {
+		if c.Asset == id {
+			s.Assets = append(s.Assets[:i:i], s.Assets[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AssetBonus returns the add the character's assets give to a roll of
+// the move with a stat, and the names of the assets that gave it (see
+// asset.RollBonus).
+func (s Sheet) AssetBonus(lookup func(id string) (asset.Asset, bool), moveKey string, stat move.Stat) (int, []string) {
+	return asset.RollBonus(s.Assets, lookup, moveKey, stat)
+}
+
+// MaxMomentum returns the momentum maximum: 10, minus one per debility.
+func (s Sheet) MaxMomentum() int {
+	return roll.MaxMomentum - len(s.Debilities)
+}
+
+// MomentumReset returns the value momentum resets to after it is
+// burned: 2 without debilities, 1 with one, and 0 with more.
+func (s Sheet) MomentumReset() int {
+	return max(StartMomentum-len(s.Debilities), 0)
+}
+
+// Validate checks every value on the sheet against its allowed range.
+func (s Sheet) Validate() error {
+	if s.Owner == "" {
+		return errors.New("character: owner is required")
+	}
+
+	for _, stat := range move.AllStats {
+		lo, hi := Range(stat)
+		if v, _ := s.Value(stat); v < lo || v > hi {
+			return fmt.Errorf("character: %s must be between %d and %d", stat, lo, hi)
+		}
+	}
+
+	seen := make(map[Debility]bool)
+	for _, d := range s.Debilities {
+		if _, ok := ParseDebility(string(d)); !ok || seen[d] {
+			return fmt.Errorf("character: invalid or duplicate debility %q", d)
+		}
+		seen[d] = true
+	}
+
+	assets := make(map[string]bool)
+	for _, c := range s.Assets {
+		if c.Asset == "" || assets[c.Asset] {
+			return fmt.Errorf("character: invalid or duplicate asset %q", c.Asset)
+		}
+		assets[c.Asset] = true
+	}
+
+	if s.Momentum < roll.MinMomentum || s.Momentum > s.MaxMomentum() {
+		return fmt.Errorf("character: momentum must be between %d and %d", roll.MinMomentum, s.MaxMomentum())
+	}
+	return s.validateXP()
+}
+
+// Roll makes an action roll with a stat or meter from the sheet plus
+// adds, taking the character's momentum into account.
+func (s Sheet) Roll(r *roll.Roller, stat move.Stat, adds int) (roll.Result, error) {
+	v, ok := s.Value(stat)
+	if !ok {
+		return roll.Result{}, fmt.Errorf("character: unknown stat %q", stat)
+	}
+	return r.RollWithMomentum(v+adds, s.Momentum), nil
+}
+
+// Range returns the allowed range of a stat or condition meter.
+func Range(stat move.Stat) (int, int) {
+	switch stat {
+	case move.Health, move.Spirit, move.Supply:
+		return MinMeter, MaxMeter
+	default:
+		return MinStat, MaxStat
+	}
+}
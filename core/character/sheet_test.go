@@ -0,0 +1,149 @@
+package character
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestNewIsValid(t *testing.T) {
+	s := New("telegram:1", "Kira")
+	if err := s.Validate(); err != nil {
+		t.Fatalf("new sheet is invalid: %v", err)
+	}
+	if v, _ := s.Value(move.Health); v != MaxMeter {
+		t.Fatalf("health = %d; want %d", v, MaxMeter)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := New("telegram:1", "Kira")
+
+	cases := []struct {
+		name  string
+		value int
+		ok    bool
+	}{
+		{"edge", 3, true},
+		{"Wits", 2, true},
+		{"heart", 4, false},
+		{"iron", 0, false},
+		{"health", 0, true},
+		{"supply", 6, false},
+		{"momentum", -6, true},
+		{"momentum", 11, false},
+		{"luck", 1, false},
+	}
+
+	for _, c := range cases {
+		err := s.Set(c.name, c.value)
+		if (err == nil) != c.ok {
+			t.Fatalf("Set(%q, %d) error = %v; want ok %v", c.name, c.value, err, c.ok)
+		}
+	}
+
+	if s.Edge != 3 || s.Wits != 2 || s.Health != 0 || s.Momentum != -6 {
+		t.Fatalf("unexpected sheet after Set: %+v", s)
+	}
+}
+
+func TestMarkAdjustsMomentum(t *testing.T) {
+	s := New("telegram:1", "Kira")
+	s.Momentum = 10
+
+	s.Mark(Wounded, true)
+	s.Mark(Shaken, true)
+	s.Mark(Wounded, true) // marking twice has no further effect
+
+	if len(s.Debilities) != 2 || !s.Marked(Wounded) || !s.Marked(Shaken) {
+		t.Fatalf("unexpected debilities: %v", s.Debilities)
+	}
+	if s.MaxMomentum() != 8 || s.Momentum != 8 {
+		t.Fatalf("max momentum = %d, momentum = %d; want 8, 8", s.MaxMomentum(), s.Momentum)
+	}
+	if s.MomentumReset() != 0 {
+		t.Fatalf("momentum reset = %d; want 0", s.MomentumReset())
+	}
+
+	s.Mark(Shaken, false)
+	if s.Marked(Shaken) || s.MaxMomentum() != 9 || s.MomentumReset() != 1 {
+		t.Fatalf("unexpected sheet after clearing: %+v", s)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*Sheet)
+	}{
+		{"NoOwner", func(s *Sheet) { s.Owner = "" }},
+		{"StatTooHigh", func(s *Sheet) { s.Shadow = 4 }},
+		{"MeterTooLow", func(s *Sheet) { s.Spirit = -1 }},
+		{"UnknownDebility", func(s *Sheet) { s.Debilities = []Debility{"sleepy"} }},
+		{"DuplicateDebility", func(s *Sheet) { s.Debilities = []Debility{Maimed, Maimed} }},
+		{"DuplicateAsset", func(s *Sheet) { s.Assets = []asset.Card{{Asset: "a"}, {Asset: "a"}} }},
+		{"MomentumAboveMax", func(s *Sheet) { s.Debilities = []Debility{Cursed}; s.Momentum = 10 }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := New("telegram:1", "Kira")
+			c.modify(&s)
+			if err := s.Validate(); err == nil {
+				t.Fatal("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestRollUsesStatAndMomentum(t *testing.T) {
+	s := New("telegram:1", "Kira")
+	s.Wits = 3
+	s.Momentum = 5
+
+	r, err := s.Roll(roll.NewRoller(rand.New(rand.NewSource(1))), move.Wits, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Modifier != 4 {
+		t.Fatalf("modifier = %d; want 4", r.Modifier)
+	}
+	if r.Momentum == nil || r.Momentum.Value != 5 {
+		t.Fatalf("momentum = %+v; want value 5", r.Momentum)
+	}
+
+	if _, err := s.Roll(roll.Default(), "luck", 0); err == nil {
+		t.Fatal("expected an error for an unknown stat")
+	}
+}
+
+func TestAssets(t *testing.T) {
+	catalog := asset.Builtin()
+	hound, _ := catalog.Get("hound")
+
+	s := New("telegram:1", "Kira")
+	if err := s.AddAsset(hound); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddAsset(hound); err == nil {
+		t.Error("expected an error adding an asset twice")
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	add, names := s.AssetBonus(catalog.Get, "gather_information", move.Wits)
+	if add != 1 || len(names) != 1 || names[0] != "Hound" {
+		t.Fatalf("AssetBonus = %d, %v; want 1, [Hound]", add, names)
+	}
+
+	if !s.RemoveAsset(hound.ID) || s.Card(hound.ID) != nil {
+		t.Error("expected the hound to be removed")
+	}
+	if s.RemoveAsset(hound.ID) {
+		t.Error("expected removing a missing asset to report false")
+	}
+}
@@ -0,0 +1,67 @@
+package character
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotFound is returned when an owner has no character sheet.
+var ErrNotFound = errors.New("character: sheet not found")
+
+// Store persists character sheets keyed by owner.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the sheet of owner, or ErrNotFound.
+	Get(owner string) (Sheet, error)
+
+	// Put creates or replaces the sheet of its owner.
+	Put(s Sheet) error
+
+	// Delete removes the sheet of owner. Deleting a missing
+	// sheet is not an error.
+	Delete(owner string) error
+}
+
+// MemoryStore is a Store that keeps sheets in memory.
+// Its contents are lost when the process exits.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	sheets map[string]Sheet
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sheets: make(map[string]Sheet)}
+}
+
+func (m *MemoryStore) Get(owner string) (Sheet, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.sheets[owner]
+	if !ok {
+		return Sheet{}, ErrNotFound
+	}
+	return s.Clone(), nil
+}
+
+func (m *MemoryStore) Put(s Sheet) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sheets[s.Owner] = s.Clone()
+	return nil
+}
+
+func (m *MemoryStore) Delete(owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sheets, owner)
+	return nil
+}
@@ -0,0 +1,77 @@
+package character
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/asset"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	if _, err := store.Get("telegram:1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get on empty store: err = %v; want ErrNotFound", err)
+	}
+
+	s := New("telegram:1", "Kira")
+	s.Mark(Wounded, true)
+	if err := store.Put(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the returned sheet must not change the stored one.
+	got, err := store.Get("telegram:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Debilities[0] = Shaken
+	if again, _ := store.Get("telegram:1"); again.Debilities[0] != Wounded {
+		t.Fatalf("stored sheet was modified through a copy: %v", again.Debilities)
+	}
+
+	invalid := New("telegram:2", "Bad")
+	invalid.Edge = 9
+	if err := store.Put(invalid); err == nil {
+		t.Fatal("expected Put to reject an invalid sheet")
+	}
+
+	if err := store.Delete("telegram:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("telegram:1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreCopiesSheets(t *testing.T) {
+	store := NewMemoryStore()
+
+	s := New("telegram:1", "Kira")
+	s.Assets = []asset.Card{{Asset: "hound", Abilities: []bool{true, false}, Tracks: map[string]int{"health": 4}}}
+	s.Legacies = map[Legacy]LegacyTrack{Bonds: {Ticks: 4}}
+	if err := store.Put(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the sheet after Put must not change the stored one.
+	s.Assets[0].Abilities[1] = true
+	s.Legacies[Bonds] = LegacyTrack{Ticks: 8}
+
+	got, err := store.Get("telegram:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Assets[0].Abilities[0] = false
+	got.Assets[0].Tracks["health"] = 1
+	got.Legacies[Bonds] = LegacyTrack{Ticks: 39}
+
+	again, _ := store.Get("telegram:1")
+	card := again.Assets[0]
+	if !card.Abilities[0] || card.Abilities[1] || card.Tracks["health"] != 4 {
+		t.Fatalf("stored card was modified through a copy: %+v", card)
+	}
+	if again.Legacies[Bonds].Ticks != 4 {
+		t.Fatalf("stored legacies were modified through a copy: %v", again.Legacies)
+	}
+}
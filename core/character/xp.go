@@ -0,0 +1,220 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// Experience costs and rewards.
+const (
+	// AssetCost is the XP spent to take a new asset.
+	AssetCost = 3
+	// UpgradeCost is the XP spent to mark an ability of an asset.
+	UpgradeCost = 2
+
+	// LegacyBoxXP is the XP earned for each box filled on a legacy
+	// track, and CompletedLegacyBoxXP once the track has been filled.
+	LegacyBoxXP          = 2
+	CompletedLegacyBoxXP = 1
+)
+
+// Legacy names a Starforged legacy track.
+type Legacy string
+
+const (
+	Quests      Legacy = "quests"
+	Bonds       Legacy = "bonds"
+	Discoveries Legacy = "discoveries"
+)
+
+// AllLegacies lists the legacy tracks in sheet order.
+var AllLegacies = []Legacy{Quests, Bonds, Discoveries}
+
+// ParseLegacy converts user input such as "Bonds" into a Legacy.
+func ParseLegacy(raw string) (Legacy, bool) {
+	l := Legacy(strings.ToLower(strings.TrimSpace(raw)))
+	for _, legacy := range AllLegacies {
+		if l == legacy {
+			return legacy, true
+		}
+	}
+	return "", false
+}
+
+// LegacyTrack is the progress on one legacy track. Like a progress
+// track it has ten boxes of four ticks. Filling the tenth box clears
+// the track and marks it completed; boxes filled after that earn less
+// XP.
+type LegacyTrack struct {
+	Ticks     int  `json:"ticks"`
+	Completed bool `json:"completed,omitempty"`
+}
+
+// Score returns the number of filled boxes.
+func (t LegacyTrack) Score() int {
+	return t.Ticks / track.TicksPerBox
+}
+
+// VowXP returns the XP classic Ironsworn awards for fulfilling a vow of
+// the given rank: 1 for troublesome up to 5 for epic. A weak hit earns
+// XP as if the vow were one rank lower, and a miss earns none.
+func VowXP(rank track.Rank, o roll.Outcome) int {
+	xp := 0
+	for i, r := range track.AllRanks {
+		if r == rank {
+			xp = i + 1
+		}
+	}
+	return reward(xp, o)
+}
+
+// LegacyTicks returns the ticks Starforged marks on a legacy track for
+// a reward of the given rank, such as a fulfilled vow on the quests
+// track: troublesome 1, dangerous 2, formidable 4 (one box), extreme 8
+// and epic 12. A weak hit marks the reward of one rank lower, and a
+// miss marks nothing.
+func LegacyTicks(rank track.Rank, o roll.Outcome) int {
+	ticks := []int{0, 1, 2, 4, 8, 12}
+	i := 0
+	for n, r := range track.AllRanks {
+		if r == rank {
+			i = n + 1
+		}
+	}
+	return ticks[reward(i, o)]
+}
+
+// reward lowers a reward step by one on a weak hit and to zero on
+// a miss.
+func reward(step int, o roll.Outcome) int {
+	switch o {
+	case roll.CriticalSuccess, roll.Success:
+		return step
+	case roll.PartialSuccess:
+		return max(step-1, 0)
+	default:
+		return 0
+	}
+}
+
+// XP returns the experience available to spend.
+func (s Sheet) XP() int {
+	return s.XPEarned - s.XPSpent
+}
+
+// EarnXP adds earned experience.
+func (s *Sheet) EarnXP(n int) error {
+	if n < 1 {
+		return fmt.Errorf("experience earned must be positive, not %d", n)
+	}
+	s.XPEarned += n
+	return nil
+}
+
+// SpendXP spends available experience.
+func (s *Sheet) SpendXP(n int) error {
+	if n < 1 {
+		return fmt.Errorf("experience spent must be positive, not %d", n)
+	}
+	if n > s.XP() {
+		return fmt.Errorf("%d experience needed, %d available", n, s.XP())
+	}
+	s.XPSpent += n
+	return nil
+}
+
+// BuyAsset spends AssetCost experience on a new asset.
+func (s *Sheet) BuyAsset(a asset.Asset) error {
+	if s.Card(a.ID) != nil {
+		return fmt.Errorf("%s already has %s", s.Name, a.Name)
+	}
+	if err := s.SpendXP(AssetCost); err != nil {
+		return err
+	}
+	return s.AddAsset(a)
+}
+
+// UpgradeAsset spends UpgradeCost experience to mark ability n
+// (counting from 1) of an asset the character has.
+func (s *Sheet) UpgradeAsset(a asset.Asset, n int) error {
+	c := s.Card(a.ID)
+	if c == nil {
+		return fmt.Errorf("%s does not have %s", s.Name, a.Name)
+	}
+	if c.Marked(n - 1) {
+		return fmt.Errorf("%s ability %d is already marked", a.Name, n)
+	}
+	// Check the ability before spending on it.
+	upgraded := *c
+	upgraded.Abilities = append([]bool(nil), c.Abilities...)
+	if err := upgraded.Mark(a, n, true); err != nil {
+		return err
+	}
+	if err := s.SpendXP(UpgradeCost); err != nil {
+		return err
+	}
+	*c = upgraded
+	return nil
+}
+
+// MarkLegacy marks ticks of progress on a legacy track and returns
+// the experience earned for the boxes it fills, which is added to the
+// sheet.
+func (s *Sheet) MarkLegacy(l Legacy, ticks int) int {
+	t := s.Legacies[l]
+	xp := 0
+	for range max(ticks, 0) {
+		t.Ticks++
+		if t.Ticks%track.TicksPerBox == 0 {
+			if t.Completed {
+				xp += CompletedLegacyBoxXP
+			} else {
+				xp += LegacyBoxXP
+			}
+		}
+		if t.Ticks == track.MaxTicks {
+			t = LegacyTrack{Completed: true}
+		}
+	}
+
+	if s.Legacies == nil {
+		s.Legacies = make(map[Legacy]LegacyTrack)
+	}
+	s.Legacies[l] = t
+	s.XPEarned += xp
+	return xp
+}
+
+// FulfillVow rewards fulfilling a vow of the given rank with the
+// outcome of its progress roll and returns the experience earned.
+// Classic Ironsworn awards XP directly (see VowXP). With legacies, as
+// in Starforged, the vow marks the quests legacy track instead (see
+// LegacyTicks) and XP is earned for the boxes it fills.
+func (s *Sheet) FulfillVow(rank track.Rank, o roll.Outcome, legacies bool) int {
+	if legacies {
+		return s.MarkLegacy(Quests, LegacyTicks(rank, o))
+	}
+	xp := VowXP(rank, o)
+	s.XPEarned += xp
+	return xp
+}
+
+// validateXP checks the experience and legacy tracks of the sheet.
+func (s Sheet) validateXP() error {
+	if s.XPEarned < 0 || s.XPSpent < 0 || s.XPSpent > s.XPEarned {
+		return fmt.Errorf("character: experience spent must be between 0 and the %d earned", s.XPEarned)
+	}
+	for l, t := range s.Legacies {
+		if _, ok := ParseLegacy(string(l)); !ok {
+			return fmt.Errorf("character: unknown legacy track %q", l)
+		}
+		if t.Ticks < 0 || t.Ticks >= track.MaxTicks {
+			return fmt.Errorf("character: %s ticks must be between 0 and %d", l, track.MaxTicks-1)
+		}
+	}
+	return nil
+}
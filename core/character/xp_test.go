@@ -0,0 +1,163 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestVowRewards(t *testing.T) {
+	cases := []struct {
+		rank  track.Rank
+		o     roll.Outcome
+		xp    int
+		ticks int
+	}{
+		{track.Troublesome, roll.Success, 1, 1},
+		{track.Troublesome, roll.PartialSuccess, 0, 0},
+		{track.Dangerous, roll.CriticalSuccess, 2, 2},
+		{track.Formidable, roll.Success, 3, 4},
+		{track.Extreme, roll.PartialSuccess, 3, 4},
+		{track.Epic, roll.Success, 5, 12},
+		{track.Epic, roll.Failure, 0, 0},
+		{track.Epic, roll.CriticalFailure, 0, 0},
+	}
+
+	for _, c := range cases {
+		if got := VowXP(c.rank, c.o); got != c.xp {
+			t.Fatalf("VowXP(%s, %s) = %d; want %d", c.rank, c.o, got, c.xp)
+		}
+		if got := LegacyTicks(c.rank, c.o); got != c.ticks {
+			t.Fatalf("LegacyTicks(%s, %s) = %d; want %d", c.rank, c.o, got, c.ticks)
+		}
+	}
+}
+
+func TestEarnAndSpendXP(t *testing.T) {
+	s := New("telegram:1", "Kira")
+	if err := s.EarnXP(0); err == nil {
+		t.Error("expected an error earning no XP")
+	}
+	if err := s.EarnXP(4); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SpendXP(5); err == nil {
+		t.Error("expected an error spending more XP than available")
+	}
+	if err := s.SpendXP(3); err != nil {
+		t.Fatal(err)
+	}
+	if s.XP() != 1 || s.XPEarned != 4 || s.XPSpent != 3 {
+		t.Fatalf("xp = %d (earned %d, spent %d); want 1 (4, 3)", s.XP(), s.XPEarned, s.XPSpent)
+	}
+}
+
+func TestBuyAndUpgradeAsset(t *testing.T) {
+	hound, _ := asset.Builtin().Get("hound")
+	s := New("telegram:1", "Kira")
+
+	if err := s.BuyAsset(hound); err == nil {
+		t.Fatal("expected an error buying without XP")
+	}
+	s.XPEarned = 5
+	if err := s.BuyAsset(hound); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpgradeAsset(hound, 1); err == nil {
+		t.Error("expected an error upgrading a marked ability")
+	}
+	if err := s.UpgradeAsset(hound, 4); err == nil {
+		t.Error("expected an error upgrading a missing ability")
+	}
+	if err := s.UpgradeAsset(hound, 2); err != nil {
+		t.Fatal(err)
+	}
+	if s.XP() != 0 || !s.Card(hound.ID).Marked(1) {
+		t.Fatalf("xp = %d, abilities %v", s.XP(), s.Card(hound.ID).Abilities)
+	}
+	if err := s.UpgradeAsset(hound, 3); err == nil || s.Card(hound.ID).Marked(2) {
+		t.Error("expected an upgrade without XP to fail and mark nothing")
+	}
+}
+
+func TestMarkLegacy(t *testing.T) {
+	s := New("telegram:1", "Kira")
+
+	if xp := s.MarkLegacy(Bonds, 3); xp != 0 {
+		t.Fatalf("3 ticks earned %d XP; want 0", xp)
+	}
+	if xp := s.MarkLegacy(Bonds, 9); xp != 6 {
+		t.Fatalf("3 boxes earned %d XP; want 6", xp)
+	}
+	if got := s.Legacies[Bonds]; got.Ticks != 12 || got.Score() != 3 {
+		t.Fatalf("bonds = %+v; want 12 ticks", got)
+	}
+
+	// Filling the track clears it, and later boxes earn less.
+	if xp := s.MarkLegacy(Bonds, 32); xp != 15 {
+		t.Fatalf("the last 7 boxes and one more earned %d XP; want 14+1", xp)
+	}
+	if got := s.Legacies[Bonds]; got.Ticks != 4 || !got.Completed {
+		t.Fatalf("bonds = %+v; want 4 ticks past completion", got)
+	}
+	if s.XPEarned != 21 {
+		t.Fatalf("earned %d XP; want 21", s.XPEarned)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMarkLegacyOnStoredSheet(t *testing.T) {
+	store := NewMemoryStore()
+	s := New("telegram:1", "Kira")
+	s.MarkLegacy(Bonds, 4)
+	if err := store.Put(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// A legacy marked on a sheet that fails to save is not kept.
+	got, err := store.Get("telegram:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.MarkLegacy(Bonds, 35)
+	got.Edge = 9
+	if err := store.Put(got); err == nil {
+		t.Fatal("expected Put to reject an invalid sheet")
+	}
+
+	again, _ := store.Get("telegram:1")
+	if again.Legacies[Bonds].Ticks != 4 || again.XPEarned != 2 {
+		t.Fatalf("stored sheet = %v, %d XP; want bonds at 4 ticks and 2 XP", again.Legacies, again.XPEarned)
+	}
+}
+
+func TestFulfillVow(t *testing.T) {
+	s := New("telegram:1", "Kira")
+	if xp := s.FulfillVow(track.Formidable, roll.Success, false); xp != 3 || s.XPEarned != 3 {
+		t.Fatalf("classic formidable vow earned %d XP; want 3", xp)
+	}
+
+	s = New("telegram:1", "Kira")
+	if xp := s.FulfillVow(track.Extreme, roll.Success, true); xp != 4 || s.Legacies[Quests].Ticks != 8 {
+		t.Fatalf("starforged extreme vow earned %d XP, quests %+v; want 4 XP and 8 ticks", xp, s.Legacies[Quests])
+	}
+}
+
+func TestValidateXP(t *testing.T) {
+	for name, modify := range map[string]func(*Sheet){
+		"Overspent":     func(s *Sheet) { s.XPEarned, s.XPSpent = 2, 3 },
+		"NegativeXP":    func(s *Sheet) { s.XPEarned = -1 },
+		"UnknownLegacy": func(s *Sheet) { s.Legacies = map[Legacy]LegacyTrack{"fame": {}} },
+		"FullLegacy":    func(s *Sheet) { s.Legacies = map[Legacy]LegacyTrack{Quests: {Ticks: 40}} },
+	} {
+		s := New("telegram:1", "Kira")
+		modify(&s)
+		if err := s.Validate(); err == nil {
+			t.Fatalf("%s: expected a validation error", name)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Limits on a single expression. They keep a roll cheap and its
+// breakdown short enough for a chat message.
+const (
+	MaxDice       = 100   // Dice rolled by the whole expression, before explosions
+	MaxSides      = 1000  // Sides of a single die
+	MaxTerms      = 20    // Terms of the expression, constants included
+	MaxConstant   = 10000 // Value of a single constant term
+	MaxExplosions = 100   // Extra dice a single exploding term may add
+)
+
+// D66 is the sides of a d66: two d6 read as tens and units.
+const D66 = 66
+
+// Selection keeps or drops the highest or lowest dice of a term.
+type Selection string
+
+const (
+	KeepHighest Selection = "kh"
+	KeepLowest  Selection = "kl"
+	DropHighest Selection = "dh"
+	DropLowest  Selection = "dl"
+)
+
+// Term is one term of an expression: a constant when Count is zero,
+// otherwise Count dice with Sides sides each.
+type Term struct {
+	Negative bool // The term is subtracted
+	Constant int  // Value of a constant term
+
+	Count   int
+	Sides   int       // 100 for d%; D66 for a d66
+	Explode bool      // Roll an extra die for every die showing its maximum
+	Select  Selection // Optional; keeps or drops N dice
+	N       int       // Number of dice kept or dropped by Select
+}
+
+// IsConstant reports whether the term is a constant.
+func (t Term) IsConstant() bool {
+	return t.Count == 0
+}
+
+// String renders the term without its sign, e.g. "4d6kh3" or "3d6!".
+func (t Term) String() string {
+	if t.IsConstant() {
+		return strconv.Itoa(t.Constant)
+	}
+	s := fmt.Sprintf("%dd%d", t.Count, t.Sides)
+	if t.Explode {
+		s += "!"
+	}
+	if t.Select != "" {
+		s += string(t.Select) + strconv.Itoa(t.N)
+	}
+	return s
+}
+
+// Expr is a parsed dice expression.
+type Expr struct {
+	Terms []Term
+}
+
+// String renders the expression in normalized notation,
+// e.g. "1d100" for "d%" or "4d6dl1" for "4d6d1".
+func (e Expr) String() string {
+	var b strings.Builder
+	for i, t := range e.Terms {
+		switch {
+		case t.Negative:
+			b.WriteString("-")
+		case i > 0:
+			b.WriteString("+")
+		}
+		b.WriteString(t.String())
+	}
+	return b.String()
+}
+
+// Parse parses a dice expression such as "2d10+1" or "4d6kh3".
+// Letters are case-insensitive, and spaces may separate terms
+// but not split one.
+func Parse(raw string) (Expr, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" {
+		return Expr{}, errors.New("dice: empty expression")
+	}
+
+	p := parser{s: s}
+	var e Expr
+	dice := 0
+	for p.i < len(s) {
+		negative := false
+		switch s[p.i] {
+		case '-':
+			negative = true
+			p.i++
+			p.space()
+		case '+':
+			p.i++
+			p.space()
+		default:
+			if len(e.Terms) > 0 {
+				return Expr{}, p.unexpected()
+			}
+		}
+
+		t, err := p.term()
+		if err != nil {
+			return Expr{}, err
+		}
+		t.Negative = negative
+		e.Terms = append(e.Terms, t)
+		p.space()
+		dice += t.Count
+
+		if len(e.Terms) > MaxTerms {
+			return Expr{}, fmt.Errorf("dice: more than %d terms", MaxTerms)
+		}
+		if dice > MaxDice {
+			return Expr{}, fmt.Errorf("dice: more than %d dice", MaxDice)
+		}
+	}
+
+	if dice == 0 {
+		return Expr{}, errors.New("dice: expression rolls no dice")
+	}
+	return e, nil
+}
+
+// parser reads an expression left to right.
+type parser struct {
+	s string
+	i int
+}
+
+// term parses a constant or a group of dice with its modifiers.
+func (p *parser) term() (Term, error) {
+	n, ok, err := p.number()
+	if err != nil {
+		return Term{}, err
+	}
+	if !p.consume('d') {
+		if !ok {
+			return Term{}, p.unexpected()
+		}
+		if n > MaxConstant {
+			return Term{}, fmt.Errorf("dice: constant %d is above %d", n, MaxConstant)
+		}
+		return Term{Constant: n}, nil
+	}
+
+	t := Term{Count: 1}
+	if ok {
+		t.Count = n
+	}
+	if t.Count < 1 {
+		return Term{}, errors.New("dice: a term must roll at least one die")
+	}
+
+	if p.consume('%') {
+		t.Sides = 100
+	} else {
+		sides, ok, err := p.number()
+		switch {
+		case err != nil:
+			return Term{}, err
+		case !ok:
+			return Term{}, errors.New("dice: missing number of sides")
+		case sides < 1 || sides > MaxSides:
+			return Term{}, fmt.Errorf("dice: sides must be between 1 and %d", MaxSides)
+		}
+		t.Sides = sides
+	}
+
+	if err := p.modifiers(&t); err != nil {
+		return Term{}, err
+	}
+	return t, nil
+}
+
+// modifiers parses the explode and keep/drop modifiers of a dice
+// term, in either order, each at most once.
+func (p *parser) modifiers(t *Term) error {
+	for p.i < len(p.s) {
+		switch {
+		case p.consume('!'):
+			if t.Explode {
+				return errors.New("dice: explode given twice")
+			}
+			if t.Sides < 2 || t.Sides == D66 {
+				return fmt.Errorf("dice: d%d cannot explode", t.Sides)
+			}
+			t.Explode = true
+
+		case p.s[p.i] == 'k' || p.s[p.i] == 'd':
+			if t.Select != "" {
+				return errors.New("dice: keep or drop given twice")
+			}
+			sel := p.selection()
+			t.Select = sel
+
+			n, ok, err := p.number()
+			switch {
+			case err != nil:
+				return err
+			case !ok:
+				return fmt.Errorf("dice: %s needs a number of dice", sel)
+			}
+			t.N = n
+			if err := t.validateSelection(); err != nil {
+				return err
+			}
+
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// selection parses "kh", "kl", "dh" or "dl". A bare "k" keeps the
+// highest and a bare "d" drops the lowest dice.
+func (p *parser) selection() Selection {
+	op := p.s[p.i]
+	p.i++
+	switch {
+	case p.consume('h'):
+		if op == 'k' {
+			return KeepHighest
+		}
+		return DropHighest
+	case p.consume('l'):
+		if op == 'k' {
+			return KeepLowest
+		}
+		return DropLowest
+	case op == 'k':
+		return KeepHighest
+	default:
+		return DropLowest
+	}
+}
+
+// validateSelection checks that a keep or drop leaves at least one die.
+func (t Term) validateSelection() error {
+	switch t.Select {
+	case KeepHighest, KeepLowest:
+		if t.N < 1 || t.N > t.Count {
+			return fmt.Errorf("dice: can keep 1 to %d of %dd%d", t.Count, t.Count, t.Sides)
+		}
+	case DropHighest, DropLowest:
+		if t.N < 1 || t.N >= t.Count {
+			return fmt.Errorf("dice: can drop 1 to %d of %dd%d", t.Count-1, t.Count, t.Sides)
+		}
+	}
+	return nil
+}
+
+// number parses an unsigned decimal number. ok is false when the
+// input does not continue with a digit.
+func (p *parser) number() (n int, ok bool, err error) {
+	start := p.i
+	for p.i < len(p.s) && p.s[p.i] >= '0' && p.s[p.i] <= '9' {
+		p.i++
+	}
+	if p.i == start {
+		return 0, false, nil
+	}
+	// Every limit is below 1e5, so longer numbers are always too large.
+	if p.i-start > 5 {
+		return 0, false, fmt.Errorf("dice: number %s is too large", p.s[start:p.i])
+	}
+	n, _ = strconv.Atoi(p.s[start:p.i])
+	return n, true, nil
+}
+
+// space advances past any spaces.
+func (p *parser) space() {
+	for p.i < len(p.s) && unicode.IsSpace(rune(p.s[p.i])) {
+		p.i++
+	}
+}
+
+// consume advances past c if it is the next character.
+func (p *parser) consume(c byte) bool {
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// unexpected returns the error for the character at the current position.
+func (p *parser) unexpected() error {
+	if p.i >= len(p.s) {
+		return errors.New("dice: unexpected end of expression")
+	}
+	return fmt.Errorf("dice: unexpected %q", p.s[p.i])
+}
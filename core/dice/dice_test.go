@@ -0,0 +1,69 @@
+package dice
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want string
+	}{
+		{"2d10+1", "2d10+1"},
+		{"d100", "1d100"},
+		{"d%", "1d100"},
+		{"d66", "1d66"},
+		{"4d6kh3", "4d6kh3"},
+		{"4d6k3", "4d6kh3"},
+		{"2d20kl1", "2d20kl1"},
+		{"4d6d1", "4d6dl1"},
+		{"5d6dh2", "5d6dh2"},
+		{"3d6!", "3d6!"},
+		{"4d6kh3!", "4d6!kh3"},
+		{" 2D6 - 1 ", "2d6-1"},
+		{"1d20 +5", "1d20+5"},
+		{"-1+d8", "-1+1d8"},
+		{"d6+d8+2", "1d6+1d8+2"},
+	}
+
+	for _, c := range cases {
+		e, err := Parse(c.raw)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.raw, err)
+		}
+		if got := e.String(); got != c.want {
+			t.Fatalf("Parse(%q) = %q, want %q", c.raw, got, c.want)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	cases := []string{
+		"",
+		"5",         // no dice
+		"2d",        // no sides
+		"0d6",       // no dice in the term
+		"d0",        // no sides
+		"d1001",     // too many sides
+		"101d6",     // too many dice
+		"60d6+60d6", // too many dice in total
+		"4d6kh5",    // keeps more than rolled
+		"4d6kh0",    // keeps nothing
+		"4d6dl4",    // drops everything
+		"4d6kh",     // keep without a number
+		"4d6kh1kl1",
+		"d6!!",
+		"d1!",
+		"d66!",
+		"2d6+",
+		"2d6x",
+		"2d6 3",
+		"d6+100000",
+		"d6+10001",
+		"face danger",
+	}
+
+	for _, raw := range cases {
+		if e, err := Parse(raw); err == nil {
+			t.Fatalf("Parse(%q) = %q, want an error", raw, e)
+		}
+	}
+}
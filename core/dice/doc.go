@@ -0,0 +1,22 @@
+// Package dice implements a dice notation roller for rolls outside
+// the Ironsworn mechanic, such as homebrew d6 checks or a d100 rolled
+// without an oracle table.
+//
+// An expression is a sum of terms, each either a constant or a group
+// of dice:
+//
+//	2d10+1     two d10 plus one
+//	d100       one d100 (also d%)
+//	d66        two d6 read as tens and units (11–66)
+//	4d6kh3     four d6, keep the highest three
+//	2d20kl1    two d20, keep the lowest
+//	4d6dl1     four d6, drop the lowest (also 4d6d1)
+//	3d6!       three d6, rolling an extra die for every 6
+//
+// Parse checks the expression and its limits once; Expr.Roll then
+// rolls it as often as needed and returns every die rolled, including
+// the dropped ones, so that adapters can show a full breakdown.
+//
+// Like core/roll, this package contains domain logic only.
+// Randomness is supplied by a *roll.Roller.
+package dice
@@ -0,0 +1,125 @@
+package dice
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Die is a single die rolled for a term.
+type Die struct {
+	Value    int
+	Dropped  bool // Removed by keep or drop; not counted in the total
+	Exploded bool // Showed its maximum and added the next die of the term
+}
+
+// String renders the die value, marked with "!" if it exploded.
+func (d Die) String() string {
+	s := strconv.Itoa(d.Value)
+	if d.Exploded {
+		s += "!"
+	}
+	return s
+}
+
+// TermResult is the rolled value of a term.
+type TermResult struct {
+	Term  Term
+	Dice  []Die // In the order rolled; empty for a constant
+	Value int   // Sum of the kept dice, or the constant; negative if the term is subtracted
+}
+
+// Result is a rolled expression.
+type Result struct {
+	Expr  Expr
+	Terms []TermResult
+	Total int
+}
+
+// Roll rolls the expression with r.
+func (e Expr) Roll(r *roll.Roller) Result {
+	res := Result{Expr: e, Terms: make([]TermResult, len(e.Terms))}
+	for i, t := range e.Terms {
+		tr := TermResult{Term: t}
+		if t.IsConstant() {
+			tr.Value = t.Constant
+		} else {
+			tr.Dice = t.roll(r)
+			for _, d := range tr.Dice {
+				if !d.Dropped {
+					tr.Value += d.Value
+				}
+			}
+		}
+		if t.Negative {
+			tr.Value = -tr.Value
+		}
+		res.Terms[i] = tr
+		res.Total += tr.Value
+	}
+	return res
+}
+
+// roll rolls the dice of a term, explodes them and applies its
+// keep or drop.
+func (t Term) roll(r *roll.Roller) []Die {
+	dice := make([]Die, 0, t.Count)
+	for range t.Count {
+		dice = append(dice, Die{Value: t.die(r)})
+	}
+
+	if t.Explode {
+		extra := 0
+		for i := 0; i < len(dice) && extra < MaxExplosions; i++ {
+			if dice[i].Value == t.Sides {
+				dice[i].Exploded = true
+				dice = append(dice, Die{Value: t.die(r)})
+				extra++
+			}
+		}
+	}
+
+	t.selectDice(dice)
+	return dice
+}
+
+// die rolls a single die of the term. A d66 is two d6 read as
+// tens and units.
+func (t Term) die(r *roll.Roller) int {
+	if t.Sides == D66 {
+		return r.Die(6)*10 + r.Die(6)
+	}
+	return r.Die(t.Sides)
+}
+
+// selectDice marks the dice removed by the term's keep or drop.
+// Among equal values the die rolled first is kept.
+func (t Term) selectDice(dice []Die) {
+	if t.Select == "" {
+		return
+	}
+
+	order := make([]int, len(dice))
+	for i := range order {
+		order[i] = i
+	}
+	highFirst := t.Select == KeepHighest || t.Select == DropHighest
+	sort.SliceStable(order, func(a, b int) bool {
+		if highFirst {
+			return dice[order[a]].Value > dice[order[b]].Value
+		}
+		return dice[order[a]].Value < dice[order[b]].Value
+	})
+
+	// Keeping N of the sorted dice drops the rest;
+	// dropping N drops the first N.
+	for rank, i := range order {
+		switch t.Select {
+		case KeepHighest, KeepLowest:
+			dice[i].Dropped = rank >= t.N
+		case DropHighest, DropLowest:
+			dice[i].Dropped = rank < t.N
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package dice
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// faces is a roll.Source returning the given die faces in order.
+type faces []int
+
+func (f *faces) Intn(n int) int {
+	v := (*f)[0]
+	*f = (*f)[1:]
+	return v - 1
+}
+
+func rollFaces(t *testing.T, raw string, values ...int) Result {
+	t.Helper()
+	e, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", raw, err)
+	}
+	f := faces(values)
+	res := e.Roll(roll.NewRoller(&f))
+	if len(f) != 0 {
+		t.Fatalf("%s left %d faces unrolled", raw, len(f))
+	}
+	return res
+}
+
+func TestRoll(t *testing.T) {
+	cases := []struct {
+		raw   string
+		faces []int
+		dice  [][]Die
+		total int
+	}{
+		{"2d10+1", []int{3, 9}, [][]Die{{{Value: 3}, {Value: 9}}, nil}, 13},
+		{"d6-2", []int{1}, [][]Die{{{Value: 1}}, nil}, -1},
+		{"4d6kh3", []int{2, 6, 2, 5}, [][]Die{{{Value: 2}, {Value: 6}, {Value: 2, Dropped: true}, {Value: 5}}}, 13},
+		{"2d20kl1", []int{15, 4}, [][]Die{{{Value: 15, Dropped: true}, {Value: 4}}}, 4},
+		{"4d6dl1", []int{3, 1, 4, 1}, [][]Die{{{Value: 3}, {Value: 1, Dropped: true}, {Value: 4}, {Value: 1}}}, 8},
+		{"3d6dh1", []int{6, 2, 6}, [][]Die{{{Value: 6, Dropped: true}, {Value: 2}, {Value: 6}}}, 8},
+		{"d66", []int{3, 5}, [][]Die{{{Value: 35}}}, 35},
+		{"2d6!", []int{6, 2, 6, 1}, [][]Die{{{Value: 6, Exploded: true}, {Value: 2}, {Value: 6, Exploded: true}, {Value: 1}}}, 15},
+		{"2d6!kh1", []int{6, 4, 3}, [][]Die{{{Value: 6, Exploded: true}, {Value: 4, Dropped: true}, {Value: 3, Dropped: true}}}, 6},
+		{"d4-d4", []int{3, 4}, [][]Die{{{Value: 3}}, {{Value: 4}}}, -1},
+	}
+
+	for _, c := range cases {
+		res := rollFaces(t, c.raw, c.faces...)
+		if res.Total != c.total {
+			t.Fatalf("%s total = %d, want %d", c.raw, res.Total, c.total)
+		}
+		for i, tr := range res.Terms {
+			if !reflect.DeepEqual(tr.Dice, c.dice[i]) {
+				t.Fatalf("%s term %d dice = %+v, want %+v", c.raw, i, tr.Dice, c.dice[i])
+			}
+		}
+	}
+}
+
+func TestRollExplosionsAreCapped(t *testing.T) {
+	values := make([]int, MaxExplosions+1)
+	for i := range values {
+		values[i] = 2
+	}
+	res := rollFaces(t, "d2!", values...)
+	if got := len(res.Terms[0].Dice); got != MaxExplosions+1 {
+		t.Fatalf("d2! rolled %d dice, want %d", got, MaxExplosions+1)
+	}
+}
+
+func TestRollStaysInRange(t *testing.T) {
+	r := roll.NewRoller(rand.New(rand.NewSource(1)))
+	e, err := Parse("d66+3d8!kh2-d%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 1000 {
+		res := e.Roll(r)
+		for _, d := range res.Terms[0].Dice {
+			if d.Value/10 < 1 || d.Value/10 > 6 || d.Value%10 < 1 || d.Value%10 > 6 {
+				t.Fatalf("d66 rolled %d", d.Value)
+			}
+		}
+		if v := res.Terms[2].Value; v < -100 || v > -1 {
+			t.Fatalf("-d%% = %d", v)
+		}
+	}
+}
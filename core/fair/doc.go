@@ -0,0 +1,30 @@
+// Package fair implements provably fair (commit/reveal) Ironsworn rolls.
+//
+// The server holds a random secret and publishes only its commitment,
+// the hex SHA-256 hash of the secret. Every roll is given a unique nonce,
+// and its dice are drawn from the deterministic byte stream
+//
+//	HMAC-SHA256(secret, nonce || block counter)
+//
+// using the same unbiased rejection sampling as any other roll.Source.
+//
+// While a secret is in force nobody but the server can predict the dice,
+// and the server cannot change the secret without changing the published
+// commitment. When the secret is rotated it is revealed, and anyone can
+// then check that it hashes to the commitment and recompute every roll
+// made under it from the roll ID alone.
+//
+// Roll IDs have the form
+//
+//	<first 12 hex digits of the commitment>-<kind>-<counter>
+//
+// where kind is roll.KindAction or roll.KindProgress. The ID is the
+// nonce. Its commitment prefix only tells which secret a roll belongs
+// to: 48 bits are too few to bind it, since a dishonest server could
+// search for a second secret with the same prefix. Every roll is
+// therefore shown with the full commitment, and a verifier checks the
+// revealed secret against that exact commitment (see Verify).
+//
+// Secrets are kept in memory only: rolls made under a secret that was
+// never revealed before a restart cannot be verified.
+package fair
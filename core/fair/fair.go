@@ -0,0 +1,293 @@
+package fair
+
+import (
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// secretSize is the length of a server secret in bytes.
+const secretSize = 32
+
+// prefixLen is the number of commitment hex digits embedded in roll IDs.
+const prefixLen = 12
+
+// commitmentLen is the number of hex digits of a full commitment.
+const commitmentLen = 2 * sha256.Size
+
+// Errors returned by verification.
+var (
+	ErrInvalidID          = errors.New("fair: invalid roll id")
+	ErrInvalidCommitment  = errors.New("fair: invalid commitment")
+	ErrCommitmentMismatch = errors.New("fair: roll id was not made under the commitment")
+	ErrSecretMismatch     = errors.New("fair: secret does not match roll commitment")
+	ErrNotRevealed        = errors.New("fair: secret not revealed yet")
+)
+
+// Revelation is a secret that has been rotated out and published.
+type Revelation struct {
+	Commitment string    // Hex SHA-256 of the secret
+	Secret     string    // Hex-encoded secret
+	RevealedAt time.Time // When the secret was rotated out
+}
+
+// Prover issues verifiable roll sources under a rotating server secret.
+//
+// It implements roll.Prover and is safe for concurrent use.
+type Prover struct {
+	mu         sync.Mutex
+	secret     []byte
+	commitment string
+	counter    uint64
+	revealed   []Revelation
+}
+
+// NewProver returns a Prover with a fresh random secret.
+func NewProver() (*Prover, error) {
+	p := &Prover{}
+	if err := p.reseed(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// reseed installs a new random secret. The caller must hold p.mu
+// (or own p exclusively).
+func (p *Prover) reseed() error {
+	secret := make([]byte, secretSize)
+	if _, err := cryptorand.Read(secret); err != nil {
+		return fmt.Errorf("fair: generate secret: %w", err)
+	}
+
+	p.secret = secret
+	p.commitment = Commit(secret)
+	p.counter = 0
+	return nil
+}
+
+// Commitment returns the published commitment of the current secret.
+func (p *Prover) Commitment() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.commitment
+}
+
+// Next implements roll.Prover.
+func (p *Prover) Next(kind string) (roll.Source, roll.Proof) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.counter++
+	nonce := fmt.Sprintf("%s-%s-%d", p.commitment[:prefixLen], kind, p.counter)
+
+	return Stream(p.secret, nonce), roll.Proof{
+		Commitment: p.commitment,
+		Nonce:      nonce,
+	}
+}
+
+// Rotate reveals the current secret and replaces it with a new one.
+func (p *Prover) Rotate() (Revelation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rev := Revelation{
+		Commitment: p.commitment,
+		Secret:     hex.EncodeToString(p.secret),
+		RevealedAt: time.Now(),
+	}
+
+	if err := p.reseed(); err != nil {
+		return Revelation{}, err
+	}
+
+	p.revealed = append(p.revealed, rev)
+	return rev, nil
+}
+
+// Revealed returns all secrets rotated out so far, oldest first.
+func (p *Prover) Revealed() []Revelation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]Revelation, len(p.revealed))
+	copy(out, p.revealed)
+	return out
+}
+
+// Lookup verifies a roll ID against the revealed secret whose
+// commitment is exactly commitment, the full commitment shown with the
+// roll when it was made.
+//
+// It returns ErrNotRevealed when the roll was made under the current
+// (still secret) commitment or under a secret this process never knew.
+func (p *Prover) Lookup(commitment, id string) (Replay, error) {
+	commitment, err := checkCommitment(commitment, id)
+	if err != nil {
+		return Replay{}, err
+	}
+
+	for _, rev := range p.Revealed() {
+		if rev.Commitment != commitment {
+			continue
+		}
+		secret, err := hex.DecodeString(rev.Secret)
+		if err != nil {
+			return Replay{}, err
+		}
+		return Verify(secret, commitment, id)
+	}
+
+	return Replay{}, ErrNotRevealed
+}
+
+// Commit returns the public commitment of a secret:
+// its hex-encoded SHA-256 hash.
+func Commit(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return hex.EncodeToString(sum[:])
+}
+
+// Replay is the recomputed outcome of a verifiable roll.
+type Replay struct {
+	ID         string
+	Kind       string // roll.KindAction or roll.KindProgress
+	Commitment string // Hex SHA-256 of Secret
+	Secret     string // Hex-encoded revealed secret
+
+	ActionDie     int    // 0 for progress rolls
+	ChallengeDice [2]int // Both challenge dice
+}
+
+// Verify recomputes the dice of the roll with the given ID from a
+// revealed secret. It fails unless the secret hashes to exactly
+// commitment, the full commitment shown with the roll, and the ID was
+// made under it. The prefix embedded in the ID alone is too short to
+// bind the roll to a secret.
+//
+// Outcomes are not part of the replay: they follow from the dice and
+// the modifier or progress score shown with the original roll.
+func Verify(secret []byte, commitment, id string) (Replay, error) {
+	commitment, err := checkCommitment(commitment, id)
+	if err != nil {
+		return Replay{}, err
+	}
+	if Commit(secret) != commitment {
+		return Replay{}, ErrSecretMismatch
+	}
+	_, kind, _, _ := parseID(id)
+
+	replay := Replay{
+		ID:         id,
+		Kind:       kind,
+		Commitment: commitment,
+		Secret:     hex.EncodeToString(secret),
+	}
+
+	// Replaying through an ordinary Roller guarantees the dice are drawn
+	// exactly as they were for the original roll.
+	roller := roll.NewRoller(Stream(secret, id))
+	switch kind {
+	case roll.KindAction:
+		r := roller.Roll(0)
+		replay.ActionDie = r.ActionDie
+		replay.ChallengeDice = r.ChallengeDice
+	case roll.KindProgress:
+		replay.ChallengeDice = roller.ProgressRoll(0).ChallengeDice
+	}
+
+	return replay, nil
+}
+
+// checkCommitment checks that commitment is a full commitment, in
+// either case, and that the roll ID embeds its prefix. It returns the
+// commitment in lower case.
+func checkCommitment(commitment, id string) (string, error) {
+	prefix, _, _, err := parseID(id)
+	if err != nil {
+		return "", err
+	}
+
+	commitment = strings.ToLower(commitment)
+	if len(commitment) != commitmentLen {
+		return "", ErrInvalidCommitment
+	}
+	if _, err := hex.DecodeString(commitment); err != nil {
+		return "", ErrInvalidCommitment
+	}
+	if !strings.HasPrefix(commitment, strings.ToLower(prefix)) {
+		return "", ErrCommitmentMismatch
+	}
+	return commitment, nil
+}
+
+// parseID splits a roll ID into commitment prefix, kind and counter.
+func parseID(id string) (string, string, uint64, error) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 3 || len(parts[0]) != prefixLen {
+		return "", "", 0, ErrInvalidID
+	}
+	if _, err := hex.DecodeString(parts[0]); err != nil {
+		return "", "", 0, ErrInvalidID
+	}
+	if parts[1] != roll.KindAction && parts[1] != roll.KindProgress {
+		return "", "", 0, ErrInvalidID
+	}
+
+	counter, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return "", "", 0, ErrInvalidID
+	}
+
+	return parts[0], parts[1], counter, nil
+}
+
+// Stream returns the deterministic dice source of one roll.
+func Stream(secret []byte, nonce string) roll.Source {
+	return roll.NewReaderSource(&hmacStream{
+		mac:   hmac.New(sha256.New, secret),
+		nonce: []byte(nonce),
+	})
+}
+
+// hmacStream is an endless byte stream of
+// HMAC-SHA256(secret, nonce || big-endian block counter) blocks.
+type hmacStream struct {
+	mac   hash.Hash
+	nonce []byte
+	block uint64
+	buf   []byte
+}
+
+func (s *hmacStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.buf) == 0 {
+			var ctr [8]byte
+			binary.BigEndian.PutUint64(ctr[:], s.block)
+			s.block++
+
+			s.mac.Reset()
+			s.mac.Write(s.nonce)
+			s.mac.Write(ctr[:])
+			s.buf = s.mac.Sum(nil)
+		}
+
+		c := copy(p[n:], s.buf)
+		s.buf = s.buf[c:]
+		n += c
+	}
+	return n, nil
+}
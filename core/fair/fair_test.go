@@ -0,0 +1,127 @@
+package fair
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestVerifiableRollsReplayAfterRotation(t *testing.T) {
+	p, err := NewProver()
+	if err != nil {
+		t.Fatalf("NewProver: %v", err)
+	}
+	roller := roll.NewVerifiableRoller(p)
+
+	commitment := p.Commitment()
+	action := roller.Roll(2)
+	progress := roller.ProgressRoll(6)
+
+	for _, id := range []string{action.ID, progress.ID} {
+		if !strings.HasPrefix(id, commitment[:prefixLen]) {
+			t.Fatalf("roll id %q does not embed commitment %q", id, commitment)
+		}
+	}
+	if action.Proof == nil || action.Proof.Commitment != commitment || action.Proof.Nonce != action.ID {
+		t.Fatalf("unexpected proof: %+v", action.Proof)
+	}
+
+	// Before rotation the secret is not available.
+	if _, err := p.Lookup(commitment, action.ID); !errors.Is(err, ErrNotRevealed) {
+		t.Fatalf("Lookup before rotation: got %v, want ErrNotRevealed", err)
+	}
+
+	rev, err := p.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if rev.Commitment != commitment || p.Commitment() == commitment {
+		t.Fatalf("rotation did not reveal the old secret and install a new one")
+	}
+
+	got, err := p.Lookup(commitment, action.ID)
+	if err != nil {
+		t.Fatalf("Lookup action: %v", err)
+	}
+	if got.Kind != roll.KindAction || got.ActionDie != action.ActionDie || got.ChallengeDice != action.ChallengeDice {
+		t.Fatalf("action replay mismatch: got %+v, rolled %+v", got, action)
+	}
+
+	got, err = p.Lookup(strings.ToUpper(commitment), progress.ID)
+	if err != nil {
+		t.Fatalf("Lookup progress: %v", err)
+	}
+	if got.Kind != roll.KindProgress || got.ActionDie != 0 || got.ChallengeDice != progress.ChallengeDice {
+		t.Fatalf("progress replay mismatch: got %+v, rolled %+v", got, progress)
+	}
+
+	// The revealed secret must hash to the published commitment.
+	secret, _ := hex.DecodeString(rev.Secret)
+	if Commit(secret) != commitment {
+		t.Fatal("revealed secret does not match commitment")
+	}
+}
+
+func TestVerifyRejectsWrongSecretAndBadIDs(t *testing.T) {
+	p, _ := NewProver()
+	r := roll.NewVerifiableRoller(p).Roll(0)
+
+	commitment := p.Commitment()
+
+	if _, err := Verify([]byte("not the secret"), commitment, r.ID); !errors.Is(err, ErrSecretMismatch) {
+		t.Fatalf("Verify with wrong secret: got %v, want ErrSecretMismatch", err)
+	}
+
+	for _, id := range []string{"", "abc", "zzzzzzzzzzzz-action-1", "0123456789ab-oracle-1", "0123456789ab-action-x"} {
+		if _, err := Verify([]byte("x"), commitment, id); !errors.Is(err, ErrInvalidID) {
+			t.Fatalf("Verify(%q): got %v, want ErrInvalidID", id, err)
+		}
+	}
+
+	for _, c := range []string{"", commitment[:prefixLen], commitment + "00", "zz" + commitment[2:]} {
+		if _, err := Verify([]byte("x"), c, r.ID); !errors.Is(err, ErrInvalidCommitment) {
+			t.Fatalf("Verify with commitment %q: got %v, want ErrInvalidCommitment", c, err)
+		}
+	}
+	other := strings.Repeat("0", commitmentLen)
+	if _, err := Verify([]byte("x"), other, r.ID); !errors.Is(err, ErrCommitmentMismatch) {
+		t.Fatalf("Verify under another commitment: got %v, want ErrCommitmentMismatch", err)
+	}
+}
+
+func TestVerifyRequiresTheFullCommitment(t *testing.T) {
+	p, _ := NewProver()
+	r := roll.NewVerifiableRoller(p).Roll(0)
+	rev, err := p.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	secret, _ := hex.DecodeString(rev.Secret)
+
+	// A commitment sharing only the prefix in the roll ID, as a second
+	// secret ground to match it would, is not accepted.
+	forged := rev.Commitment[:prefixLen] + strings.Repeat("0", commitmentLen-prefixLen)
+	if _, err := Verify(secret, forged, r.ID); !errors.Is(err, ErrSecretMismatch) {
+		t.Fatalf("Verify with a forged commitment: got %v, want ErrSecretMismatch", err)
+	}
+	if _, err := p.Lookup(forged, r.ID); !errors.Is(err, ErrNotRevealed) {
+		t.Fatalf("Lookup with a forged commitment: got %v, want ErrNotRevealed", err)
+	}
+
+	if got, err := Verify(secret, rev.Commitment, r.ID); err != nil || got.ChallengeDice != r.ChallengeDice {
+		t.Fatalf("Verify = %+v, %v; want the rolled dice", got, err)
+	}
+}
+
+func TestStreamIsDeterministic(t *testing.T) {
+	secret := []byte("secret")
+
+	a := roll.NewRoller(Stream(secret, "n-1")).Roll(0)
+	b := roll.NewRoller(Stream(secret, "n-1")).Roll(0)
+	if a.ActionDie != b.ActionDie || a.ChallengeDice != b.ChallengeDice {
+		t.Fatalf("same secret and nonce produced different dice: %+v vs %+v", a, b)
+	}
+}
@@ -0,0 +1,10 @@
+// Package history records the rolls made through the bot.
+//
+// Every action and progress roll is kept as an Entry together with the
+// context it was made in: the platform, the user, the channel or chat,
+// the campaign (see core/campaign) and the move. Entries are added to a
+// Store and queried newest first with a Filter.
+//
+// Ring is a fixed-size in-memory Store that forgets the oldest entries;
+// the store package provides a Store backed by an append-only file.
+package history
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"time"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Platforms an entry can be recorded from.
+const (
+	Telegram = "telegram"
+	Discord  = "discord"
+	HTTP     = "http"
+)
+
+// Entry is one recorded roll.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Platform string    `json:"platform"`
+	User     string    `json:"user,omitempty"`     // Platform user key, e.g. discord:1234
+	Channel  string    `json:"channel,omitempty"`  // Channel or chat ID on the platform
+	Campaign string    `json:"campaign,omitempty"` // Campaign ID
+	Move     string    `json:"move,omitempty"`     // Move ID
+	Stat     string    `json:"stat,omitempty"`
+
+	Kind          string       `json:"kind"` // roll.KindAction or roll.KindProgress
+	ActionDie     int          `json:"action_die,omitempty"`
+	Modifier      int          `json:"modifier,omitempty"`
+	Progress      int          `json:"progress,omitempty"`
+	Momentum      *int         `json:"momentum,omitempty"`
+	ChallengeDice [2]int       `json:"challenge_dice"`
+	Total         int          `json:"total"` // Action score, or the progress score
+	Outcome       roll.Outcome `json:"outcome"`
+	RollID        string       `json:"roll_id,omitempty"` // ID of a verifiable roll
+}
+
+// SetAction records the dice of an action roll in the entry.
+func (e *Entry) SetAction(r roll.Result) {
+	e.Kind = roll.KindAction
+	e.ActionDie = r.ActionDie
+	e.Modifier = r.Modifier
+	e.ChallengeDice = r.ChallengeDice
+	e.Total = r.Total
+	e.Outcome = r.Outcome
+	e.RollID = r.ID
+	e.Momentum = nil
+	if r.Momentum != nil {
+		m := r.Momentum.Value
+		e.Momentum = &m
+	}
+}
+
+// SetProgress records the dice of a progress roll in the entry.
+func (e *Entry) SetProgress(r roll.ProgressResult) {
+	e.Kind = roll.KindProgress
+	e.Progress = r.ProgressScore
+	e.ChallengeDice = r.ChallengeDice
+	e.Total = r.ProgressScore
+	e.Outcome = r.Outcome
+	e.RollID = r.ID
+}
+
+// Rolled reports whether a roll has been recorded in the entry.
+func (e Entry) Rolled() bool {
+	return e.Kind != ""
+}
+
+// Filter selects entries. Empty fields match every entry.
+type Filter struct {
+	Platform string
+	User     string
+	Channel  string
+	Campaign string
+	Move     string
+	Since    time.Time // Entries at or after Since
+	Until    time.Time // Entries before Until
+
+	// Limit caps the number of entries returned; zero means no limit.
+	Limit int
+}
+
+// Match reports whether an entry passes the filter, ignoring Limit.
+func (f Filter) Match(e Entry) bool {
+	switch {
+	case f.Platform != "" && e.Platform != f.Platform:
+		return false
+	case f.User != "" && e.User != f.User:
+		return false
+	case f.Channel != "" && e.Channel != f.Channel:
+		return false
+	case f.Campaign != "" && e.Campaign != f.Campaign:
+		return false
+	case f.Move != "" && e.Move != f.Move:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
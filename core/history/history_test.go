@@ -0,0 +1,98 @@
+package history
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestSetAction(t *testing.T) {
+	r := roll.NewRoller(rand.New(rand.NewSource(1))).RollWithMomentum(2, 5)
+
+	var e Entry
+	if e.Rolled() {
+		t.Fatal("empty entry reports a roll")
+	}
+	e.SetAction(r)
+	if !e.Rolled() || e.Kind != roll.KindAction || e.Total != r.Total || e.Outcome != r.Outcome {
+		t.Fatalf("unexpected entry %+v for %+v", e, r)
+	}
+	if e.Momentum == nil || *e.Momentum != 5 {
+		t.Fatalf("momentum = %v; want 5", e.Momentum)
+	}
+
+	p := roll.NewRoller(rand.New(rand.NewSource(1))).ProgressRoll(7)
+	e.SetProgress(p)
+	if e.Kind != roll.KindProgress || e.Progress != 7 || e.Total != 7 || e.Outcome != p.Outcome {
+		t.Fatalf("unexpected entry %+v for %+v", e, p)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := Entry{Time: now, Platform: Discord, User: "discord:1", Channel: "9", Campaign: "c1", Move: "strike"}
+
+	cases := []struct {
+		f    Filter
+		want bool
+	}{
+		{Filter{}, true},
+		{Filter{Platform: Discord, User: "discord:1", Channel: "9", Campaign: "c1", Move: "strike"}, true},
+		{Filter{Platform: Telegram}, false},
+		{Filter{User: "discord:2"}, false},
+		{Filter{Channel: "8"}, false},
+		{Filter{Campaign: "c2"}, false},
+		{Filter{Move: "face_danger"}, false},
+		{Filter{Since: now}, true},
+		{Filter{Since: now.Add(time.Second)}, false},
+		{Filter{Until: now}, false},
+		{Filter{Until: now.Add(time.Second)}, true},
+	}
+
+	for i, c := range cases {
+		if got := c.f.Match(e); got != c.want {
+			t.Fatalf("case %d: Match = %v; want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := NewRing(3)
+	for i := 1; i <= 5; i++ {
+		user := "a"
+		if i%2 == 0 {
+			user = "b"
+		}
+		if err := r.Add(Entry{User: user, Total: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, _ := r.Query(Filter{})
+	if len(all) != 3 || all[0].Total != 5 || all[2].Total != 3 {
+		t.Fatalf("Query = %+v; want totals 5, 4, 3", all)
+	}
+
+	a, _ := r.Query(Filter{User: "a"})
+	if len(a) != 2 || a[0].Total != 5 || a[1].Total != 3 {
+		t.Fatalf("Query(user a) = %+v; want totals 5, 3", a)
+	}
+
+	last, _ := r.Query(Filter{Limit: 1})
+	if len(last) != 1 || last[0].Total != 5 {
+		t.Fatalf("Query(limit 1) = %+v; want total 5", last)
+	}
+}
+
+func TestRingNotFull(t *testing.T) {
+	r := NewRing(0)
+	_ = r.Add(Entry{Total: 1})
+	_ = r.Add(Entry{Total: 2})
+
+	got, _ := r.Query(Filter{})
+	if len(got) != 2 || got[0].Total != 2 {
+		t.Fatalf("Query = %+v; want totals 2, 1", got)
+	}
+}
@@ -0,0 +1,70 @@
+package history
+
+import "sync"
+
+// DefaultCapacity is the number of entries a Ring keeps by default.
+const DefaultCapacity = 1000
+
+// Store keeps recorded rolls.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Add records an entry.
+	Add(e Entry) error
+
+	// Query returns the entries matching f, newest first.
+	Query(f Filter) ([]Entry, error)
+}
+
+// Ring is a Store that keeps the most recent entries in memory.
+// Once full, every new entry replaces the oldest one.
+type Ring struct {
+	mu      sync.RWMutex
+	entries []Entry
+	next    int // Index the next entry is written to
+	full    bool
+}
+
+// NewRing returns an empty Ring holding up to capacity entries,
+// or DefaultCapacity if capacity is not positive.
+func NewRing(capacity int) *Ring {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Ring{entries: make([]Entry, capacity)}
+}
+
+func (r *Ring) Add(e Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+func (r *Ring) Query(f Filter) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+
+	var out []Entry
+	for i := 1; i <= n; i++ {
+		e := r.entries[(r.next-i+len(r.entries))%len(r.entries)]
+		if !f.Match(e) {
+			continue
+		}
+		out = append(out, e)
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,195 @@
+package move
+
+// Classic Ironsworn moves that are resolved with a roll.
+//
+// The move text is condensed from Ironsworn by Shawn Tomkin,
+// licensed under CC BY 4.0 (https://creativecommons.org/licenses/by/4.0/).
+
+// Builtin returns a catalog holding the core Ironsworn moves.
+func Builtin() *Catalog {
+	c, err := NewCatalog(classicMoves()...)
+	if err != nil {
+		// The built-in moves are covered by tests; an invalid one is a bug.
+		panic(err)
+	}
+	return c
+}
+
+// stats lists the five stats, for moves that may roll with any of them.
+var stats = []Stat{Edge, Heart, Iron, Shadow, Wits}
+
+// classicMoves returns the core Ironsworn moves that involve a roll.
+func classicMoves() []Move {
+	return []Move{
+		// Adventure moves
+		{
+			ID: "classic/moves/adventure/face_danger", Key: "face_danger", Name: "Face Danger",
+			Stats:     stats,
+			StrongHit: "You are successful. Take +1 momentum.",
+			WeakHit:   "You succeed, but face a troublesome cost. Choose one: suffer -1 momentum, -1 health, -1 spirit or -1 supply, or face a new danger.",
+			Miss:      "You fail, or your progress is undermined by a dramatic and costly turn of events. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/secure_an_advantage", Key: "secure_an_advantage", Name: "Secure an Advantage",
+			Stats:     stats,
+			StrongHit: "You gain advantage. Choose one: take control (make another move now and add +1) or prepare to act (take +2 momentum).",
+			WeakHit:   "Your advantage is short-lived. Take +1 momentum.",
+			Miss:      "You fail or your assumptions betray you. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/gather_information", Key: "gather_information", Name: "Gather Information",
+			Stats:     []Stat{Wits},
+			StrongHit: "You discover something helpful and specific. The path you must follow or action you must take is made clear. Take +2 momentum.",
+			WeakHit:   "The information complicates your quest or introduces a new danger. Take +1 momentum.",
+			Miss:      "Your investigation unearths a dire threat or reveals an unwelcome truth that undermines your quest. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/heal", Key: "heal", Name: "Heal",
+			Stats:     []Stat{Wits, Iron},
+			StrongHit: "Your care is helpful. If the wounded condition is marked, you may clear it. Then take or give up to +2 health.",
+			WeakHit:   "As above, but you must suffer -1 supply or -1 momentum (your choice).",
+			Miss:      "Your aid is ineffective. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/resupply", Key: "resupply", Name: "Resupply",
+			Stats:     []Stat{Wits},
+			StrongHit: "You bolster your resources. Take +2 supply.",
+			WeakHit:   "Take up to +2 supply, but suffer -1 momentum for each.",
+			Miss:      "You find nothing helpful. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/make_camp", Key: "make_camp", Name: "Make Camp",
+			Stats:     []Stat{Supply},
+			StrongHit: "You and your allies may each choose two: recuperate, partake, relax, focus or prepare.",
+			WeakHit:   "Choose one: recuperate, partake, relax, focus or prepare.",
+			Miss:      "You take no comfort. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/undertake_a_journey", Key: "undertake_a_journey", Name: "Undertake a Journey",
+			Stats:     []Stat{Wits},
+			StrongHit: "You reach a waypoint. Choose one: make use of resources (take +1 supply) or keep a good pace (take +1 momentum). Then mark progress.",
+			WeakHit:   "You reach a waypoint and mark progress, but suffer -1 supply.",
+			Miss:      "You are waylaid by a perilous event. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/adventure/reach_your_destination", Key: "reach_your_destination", Name: "Reach Your Destination",
+			Progress:  true,
+			StrongHit: "The situation at your destination favors you. Choose one: make another move now and add +1, or take +1 momentum.",
+			WeakHit:   "You arrive but face an unforeseen hazard or complication.",
+			Miss:      "You have gone hopelessly astray, your objective is lost to you, or you were misled about your destination. If your journey continues, clear all but one filled progress and raise the journey's rank by one.",
+		},
+
+		// Relationship moves
+		{
+			ID: "classic/moves/relationship/compel", Key: "compel", Name: "Compel",
+			Stats:     []Stat{Heart, Iron, Shadow},
+			StrongHit: "They'll do what you want or share what they know. Take +1 momentum.",
+			WeakHit:   "As above, but they ask something of you in return.",
+			Miss:      "They refuse or make a demand which costs you greatly. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/relationship/sojourn", Key: "sojourn", Name: "Sojourn",
+			Stats:     []Stat{Heart},
+			StrongHit: "You and your allies may each choose two recover or provision actions. Focus on a need of the community for one more.",
+			WeakHit:   "Choose one recover or provision action. Focus on a need of the community for one more.",
+			Miss:      "You find no help here. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/relationship/draw_the_circle", Key: "draw_the_circle", Name: "Draw the Circle",
+			Stats:     []Stat{Heart},
+			StrongHit: "Take +1 momentum. You may also choose up to two boasts and take +1 momentum for each.",
+			WeakHit:   "You may choose one boast in exchange for +1 momentum.",
+			Miss:      "You begin the duel at a disadvantage. Your foe has initiative. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/relationship/forge_a_bond", Key: "forge_a_bond", Name: "Forge a Bond",
+			Stats:     []Stat{Heart},
+			StrongHit: "Make note of the bond and mark a tick on your bonds track. Choose one: take +1 spirit or take +2 momentum.",
+			WeakHit:   "They ask something more of you first. Do it (or Swear an Iron Vow) and mark the bond. If you decline or fail, Pay the Price.",
+			Miss:      "They reject you. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/relationship/test_your_bond", Key: "test_your_bond", Name: "Test Your Bond",
+			Stats:     []Stat{Heart},
+			StrongHit: "This test has strengthened your bond. Choose one: take +1 spirit or take +2 momentum.",
+			WeakHit:   "Your bond is fragile and you must prove your loyalty. Do what they ask, or clear the bond.",
+			Miss:      "Your bond is cleared. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/relationship/write_your_epilogue", Key: "write_your_epilogue", Name: "Write Your Epilogue",
+			Progress:  true,
+			StrongHit: "Things come to pass as you hoped.",
+			WeakHit:   "Your life takes an unexpected turn, but not necessarily for the worse.",
+			Miss:      "Your fears are realized.",
+		},
+
+		// Combat moves
+		{
+			ID: "classic/moves/combat/enter_the_fray", Key: "enter_the_fray", Name: "Enter the Fray",
+			Stats:     []Stat{Heart, Shadow, Wits},
+			StrongHit: "Take +2 momentum. You have initiative.",
+			WeakHit:   "Choose one: bolster your position (take +2 momentum) or prepare to act (take initiative).",
+			Miss:      "Combat begins with you at a disadvantage. Pay the Price. Your foe has initiative.",
+		},
+		{
+			ID: "classic/moves/combat/strike", Key: "strike", Name: "Strike",
+			Stats:     []Stat{Iron, Edge},
+			StrongHit: "Inflict +1 harm. You retain initiative.",
+			WeakHit:   "Inflict your harm and lose initiative.",
+			Miss:      "Your attack fails and you must Pay the Price. Your foe has initiative.",
+		},
+		{
+			ID: "classic/moves/combat/clash", Key: "clash", Name: "Clash",
+			Stats:     []Stat{Iron, Edge},
+			StrongHit: "Inflict your harm and choose one: bolster your position (take +1 momentum) or find an opening (inflict +1 harm). You have initiative.",
+			WeakHit:   "Inflict your harm, but then Pay the Price. Your foe has initiative.",
+			Miss:      "You are outmatched. Pay the Price. Your foe has initiative.",
+		},
+		{
+			ID: "classic/moves/combat/end_the_fight", Key: "end_the_fight", Name: "End the Fight",
+			Progress:  true,
+			StrongHit: "This foe is no longer in the fight. They are killed, out of action, flee, or surrender as appropriate.",
+			WeakHit:   "As above, but choose one: it's worse than you thought, others are in danger, it's not over, or you suffer a cost.",
+			Miss:      "You have lost this fight. Pay the Price.",
+		},
+		{
+			ID: "classic/moves/combat/battle", Key: "battle", Name: "Battle",
+			Stats:     stats,
+			StrongHit: "You achieve your objective unconditionally. You and any allies may take +2 momentum.",
+			WeakHit:   "You achieve your objective, but not without cost. Pay the Price.",
+			Miss:      "You are defeated or your objective is lost. Pay the Price.",
+		},
+
+		// Suffer moves
+		{
+			ID: "classic/moves/suffer/endure_harm", Key: "endure_harm", Name: "Endure Harm",
+			Stats:     []Stat{Health, Iron},
+			StrongHit: "Choose one: shake it off (if not wounded, suffer -1 momentum and take +1 health) or embrace the pain (take +1 momentum).",
+			WeakHit:   "You press on.",
+			Miss:      "Suffer -1 momentum. If you are at 0 health, you must mark wounded or maimed, or roll on the Endure Harm table.",
+		},
+		{
+			ID: "classic/moves/suffer/endure_stress", Key: "endure_stress", Name: "Endure Stress",
+			Stats:     []Stat{Spirit, Heart},
+			StrongHit: "Choose one: shake it off (if not shaken, suffer -1 momentum and take +1 spirit) or embrace the darkness (take +1 momentum).",
+			WeakHit:   "You press on.",
+			Miss:      "Suffer -1 momentum. If you are at 0 spirit, you must mark shaken or corrupted, or roll on the Endure Stress table.",
+		},
+
+		// Quest moves
+		{
+			ID: "classic/moves/quest/swear_an_iron_vow", Key: "swear_an_iron_vow", Name: "Swear an Iron Vow",
+			Stats:     []Stat{Heart},
+			StrongHit: "You are emboldened and it is clear what you must do next. Take +2 momentum.",
+			WeakHit:   "You are determined but begin your quest with more questions than answers. Take +1 momentum.",
+			Miss:      "You face a significant obstacle before you can begin your quest. Choose one: press on (suffer -2 momentum) or give up (Forsake Your Vow).",
+		},
+		{
+			ID: "classic/moves/quest/fulfill_your_vow", Key: "fulfill_your_vow", Name: "Fulfill Your Vow",
+			Progress:  true,
+			StrongHit: "Your quest is complete. Mark experience (troublesome 1, dangerous 2, formidable 3, extreme 4, epic 5).",
+			WeakHit:   "There is more to be done or you realize the truth of your quest. Mark experience (troublesome 0, dangerous 1, formidable 2, extreme 3, epic 4). You may Swear an Iron Vow to set things right.",
+			Miss:      "Your vow is undone through an unexpected complication or realization. Choose one: recommit (clear all but one filled progress and raise the rank by one) or give up (Forsake Your Vow).",
+		},
+	}
+}
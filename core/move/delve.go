@@ -0,0 +1,51 @@
+package move
+
+// Ironsworn: Delve moves that are resolved with a roll. Delve is an
+// expansion: it is played together with the classic moves.
+//
+// The move text is condensed from Ironsworn: Delve by Shawn Tomkin,
+// licensed under CC BY 4.0 (https://creativecommons.org/licenses/by/4.0/).
+
+// BuiltinDelve returns a catalog holding the Delve moves only.
+func BuiltinDelve() *Catalog {
+	c, err := NewCatalog(delveMoves()...)
+	if err != nil {
+		// The built-in moves are covered by tests; an invalid one is a bug.
+		panic(err)
+	}
+	return c
+}
+
+// delveMoves returns the Delve moves that involve a roll.
+func delveMoves() []Move {
+	return []Move{
+		{
+			ID: "delve/moves/delve/delve_the_depths", Key: "delve_the_depths", Name: "Delve the Depths",
+			Stats:     []Stat{Edge, Shadow, Wits},
+			StrongHit: "You delve deeper. Mark progress and Find an Opportunity.",
+			WeakHit:   "Roll on the weak hit table: you may mark progress, but face a cost or a hard choice.",
+			Miss:      "Reveal a Danger.",
+		},
+		{
+			ID: "delve/moves/delve/locate_your_objective", Key: "locate_your_objective", Name: "Locate Your Objective",
+			Progress:  true,
+			StrongHit: "You locate your objective and the situation favors you. Choose one: make another move now and add +1, or take +1 momentum.",
+			WeakHit:   "You locate your objective but face an unforeseen hazard or complication.",
+			Miss:      "Your objective falls out of reach, or you were misled. Pay the Price. To continue, clear all but one filled progress and raise the site's rank by one.",
+		},
+		{
+			ID: "delve/moves/delve/escape_the_depths", Key: "escape_the_depths", Name: "Escape the Depths",
+			Stats:     stats,
+			StrongHit: "You make your way safely out of the site. Take +1 momentum.",
+			WeakHit:   "You find your way out, but this place exacts its price. Choose one: suffer -1 health, -1 spirit or -2 supply.",
+			Miss:      "A dire threat or imposing obstacle stands in your way. Reveal a Danger. If you survive, you may make your escape.",
+		},
+		{
+			ID: "delve/moves/delve/check_your_gear", Key: "check_your_gear", Name: "Check Your Gear",
+			Stats:     []Stat{Supply},
+			StrongHit: "You have it, and are ready to act. Take +1 momentum.",
+			WeakHit:   "You have it, but must choose: suffer -1 supply or suffer -1 momentum.",
+			Miss:      "You don't have it and the situation grows more perilous. Pay the Price.",
+		},
+	}
+}
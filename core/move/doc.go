@@ -0,0 +1,12 @@
+// Package move models Ironsworn moves on top of core/roll.
+//
+// A move names a situation ("Face Danger"), the stats or condition
+// meters it may be rolled with, and what each outcome means. It does
+// not roll dice itself: an adapter rolls with core/roll, then asks the
+// move for the text of the outcome it got.
+//
+// Like oracle tables, moves are identified by a Datasworn-style ID
+// ("classic/moves/adventure/face_danger") and a short key
+// ("face_danger"). A Catalog indexes moves by both and can find a
+// move named at the start of free text such as "face danger +2".
+package move
@@ -0,0 +1,218 @@
+package move
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Stat is a character stat or condition meter a move can roll with.
+type Stat string
+
+const (
+	Edge   Stat = "edge"
+	Heart  Stat = "heart"
+	Iron   Stat = "iron"
+	Shadow Stat = "shadow"
+	Wits   Stat = "wits"
+
+	Health Stat = "health"
+	Spirit Stat = "spirit"
+	Supply Stat = "supply"
+)
+
+// AllStats lists the five stats followed by the three condition meters.
+var AllStats = []Stat{Edge, Heart, Iron, Shadow, Wits, Health, Spirit, Supply}
+
+// ParseStat converts user input such as "Wits" or "+wits" into a Stat.
+func ParseStat(raw string) (Stat, bool) {
+	s := Stat(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(raw), "+")))
+	for _, stat := range AllStats {
+		if s == stat {
+			return stat, true
+		}
+	}
+	return "", false
+}
+
+// Move is a single Ironsworn move.
+type Move struct {
+	ID   string // Datasworn-style ID, e.g. "classic/moves/adventure/face_danger"
+	Key  string // Short lookup key, e.g. "face_danger"
+	Name string // Display name, e.g. "Face Danger"
+
+	// Stats the move may be rolled with. Empty for progress moves.
+	Stats []Stat
+
+	// Progress is true for progress moves, which are rolled against
+	// a progress score instead of an action score.
+	Progress bool
+
+	StrongHit string // What a strong hit means
+	WeakHit   string // What a weak hit means
+	Miss      string // What a miss means
+}
+
+// Allows reports whether the move may be rolled with the given stat.
+func (m Move) Allows(s Stat) bool {
+	for _, stat := range m.Stats {
+		if stat == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckStat returns a descriptive error unless the move
+// may be rolled with the given stat.
+func (m Move) CheckStat(s Stat) error {
+	if m.Progress {
+		return fmt.Errorf("%s is a progress move and is not rolled with a stat", m.Name)
+	}
+	if m.Allows(s) {
+		return nil
+	}
+
+	names := make([]string, len(m.Stats))
+	for i, stat := range m.Stats {
+		names[i] = string(stat)
+	}
+	list := names[len(names)-1]
+	if len(names) > 1 {
+		list = strings.Join(names[:len(names)-1], ", ") + " or " + list
+	}
+	return fmt.Errorf("%s is rolled with %s, not %s", m.Name, list, s)
+}
+
+// OutcomeText returns the move text for a roll outcome.
+// Critical outcomes use the strong hit and miss texts.
+func (m Move) OutcomeText(o roll.Outcome) string {
+	switch o {
+	case roll.CriticalSuccess, roll.Success:
+		return m.StrongHit
+	case roll.PartialSuccess:
+		return m.WeakHit
+	default:
+		return m.Miss
+	}
+}
+
+// Catalog indexes moves by ID and by key.
+//
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	mu    sync.RWMutex
+	byID  map[string]Move
+	byKey map[string]string // key -> ID
+	ids   []string          // Sorted IDs, the order names are searched in
+}
+
+// NewCatalog returns a catalog holding the given moves.
+func NewCatalog(moves ...Move) (*Catalog, error) {
+	c := &Catalog{
+		byID:  make(map[string]Move),
+		byKey: make(map[string]string),
+	}
+
+	for _, m := range moves {
+		if err := c.Add(m); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Add adds a move to the catalog, replacing any move with the same ID.
+// A key already used by a different move is reassigned to the new move.
+func (c *Catalog) Add(m Move) error {
+	if m.ID == "" || m.Key == "" || m.Name == "" {
+		return fmt.Errorf("move: %q: id, key and name are required", m.ID)
+	}
+	if !m.Progress && len(m.Stats) == 0 {
+		return fmt.Errorf("move: %q: action moves need at least one stat", m.ID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The old key is freed only if the replaced move still owns it.
+	if old, ok := c.byID[m.ID]; ok && c.byKey[normalizeKey(old.Key)] == m.ID {
+		delete(c.byKey, normalizeKey(old.Key))
+	}
+	if _, ok := c.byID[m.ID]; !ok {
+		i, _ := slices.BinarySearch(c.ids, m.ID)
+		c.ids = slices.Insert(c.ids, i, m.ID)
+	}
+	c.byID[m.ID] = m
+	c.byKey[normalizeKey(m.Key)] = m.ID
+	return nil
+}
+
+// Get finds a move by ID, key or name, in that order. Keys and names
+// are matched case-insensitively, with spaces, dashes and underscores
+// treated alike, so "Face Danger" and "face-danger" find
+// "face_danger". When several moves share a name, the one with the
+// smallest ID is found.
+func (c *Catalog) Get(name string) (Move, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if m, ok := c.byID[name]; ok {
+		return m, true
+	}
+	return c.find(normalizeKey(name))
+}
+
+// Match finds the move named at the start of free text, such as
+// "face danger +2", and returns it with the remaining text ("+2").
+// When several names match, the longest one wins; moves are then
+// found as by Get.
+func (c *Catalog) Match(text string) (Move, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	words := strings.Fields(text)
+	for n := len(words); n > 0; n-- {
+		if m, ok := c.find(normalizeKey(strings.Join(words[:n], " "))); ok {
+			return m, strings.Join(words[n:], " "), true
+		}
+	}
+	return Move{}, text, false
+}
+
+// find returns the move with the normalized key, or else the first
+// move in ID order with that normalized name. The caller must hold
+// c.mu.
+func (c *Catalog) find(key string) (Move, bool) {
+	if id, ok := c.byKey[key]; ok {
+		return c.byID[id], true
+	}
+	for _, id := range c.ids {
+		if m := c.byID[id]; normalizeKey(m.Name) == key {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// Moves returns every move in the catalog ordered by ID.
+func (c *Catalog) Moves() []Move {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]Move, 0, len(c.ids))
+	for _, id := range c.ids {
+		out = append(out, c.byID[id])
+	}
+	return out
+}
+
+// normalizeKey folds a user-supplied move name into its canonical form.
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	key = strings.NewReplacer("-", " ", "_", " ").Replace(key)
+	return strings.Join(strings.Fields(key), "_")
+}
@@ -0,0 +1,225 @@
+package move
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestBuiltin(t *testing.T) {
+	c := Builtin()
+
+	moves := c.Moves()
+	if len(moves) != len(classicMoves()) {
+		t.Fatalf("expected %d moves, got %d", len(classicMoves()), len(moves))
+	}
+
+	for _, m := range moves {
+		if m.StrongHit == "" || m.WeakHit == "" || m.Miss == "" {
+			t.Fatalf("%s: missing outcome text", m.ID)
+		}
+		if m.Progress && len(m.Stats) != 0 {
+			t.Fatalf("%s: progress move lists stats", m.ID)
+		}
+	}
+}
+
+func TestBuiltinExpansions(t *testing.T) {
+	for name, c := range map[string]*Catalog{
+		"delve":      BuiltinDelve(),
+		"starforged": BuiltinStarforged(),
+	} {
+		for _, m := range c.Moves() {
+			if !strings.HasPrefix(m.ID, name+"/moves/") {
+				t.Fatalf("%s: %s: unexpected ID", name, m.ID)
+			}
+			if m.StrongHit == "" || m.WeakHit == "" || m.Miss == "" {
+				t.Fatalf("%s: missing outcome text", m.ID)
+			}
+			if m.Progress && len(m.Stats) != 0 {
+				t.Fatalf("%s: progress move lists stats", m.ID)
+			}
+		}
+	}
+
+	if _, ok := BuiltinStarforged().Get("take decisive action"); !ok {
+		t.Error("expected Take Decisive Action in the Starforged moves")
+	}
+}
+
+func TestParseStat(t *testing.T) {
+	cases := []struct {
+		input string
+		want  Stat
+		ok    bool
+	}{
+		{"edge", Edge, true},
+		{"+Wits", Wits, true},
+		{" supply ", Supply, true},
+		{"luck", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := ParseStat(c.input)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("ParseStat(%q) = %q, %v; want %q, %v", c.input, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestCatalogGet(t *testing.T) {
+	c := Builtin()
+
+	for _, name := range []string{
+		"face_danger",
+		"Face Danger",
+		"face-danger",
+		"classic/moves/adventure/face_danger",
+	} {
+		m, ok := c.Get(name)
+		if !ok || m.Key != "face_danger" {
+			t.Fatalf("Get(%q) = %q, %v; want face_danger", name, m.Key, ok)
+		}
+	}
+
+	if _, ok := c.Get("face"); ok {
+		t.Error("expected partial name not to match")
+	}
+}
+
+func TestCatalogMatch(t *testing.T) {
+	catalog := Builtin()
+
+	cases := []struct {
+		input string
+		key   string
+		rest  string
+		ok    bool
+	}{
+		{"face danger +2", "face_danger", "+2", true},
+		{"Face Danger", "face_danger", "", true},
+		{"secure an advantage +3 m5", "secure_an_advantage", "+3 m5", true},
+		{"strike", "strike", "", true},
+		{"fulfill your vow 7", "fulfill_your_vow", "7", true},
+		{"+2", "", "+2", false},
+		{"face +2", "", "face +2", false},
+	}
+
+	for _, c := range cases {
+		m, rest, ok := catalog.Match(c.input)
+		if ok != c.ok || m.Key != c.key || rest != c.rest {
+			t.Fatalf("Match(%q) = %q, %q, %v; want %q, %q, %v",
+				c.input, m.Key, rest, ok, c.key, c.rest, c.ok)
+		}
+	}
+}
+
+func TestCatalogSharedNames(t *testing.T) {
+	// Moves from several sources may share a name; lookups by name must
+	// not depend on map order.
+	c, err := NewCatalog(
+		Move{ID: "z/strike", Key: "z_strike", Name: "Strike", Stats: []Stat{Iron}},
+		Move{ID: "a/strike", Key: "a_strike", Name: "Strike", Stats: []Stat{Edge}},
+		Move{ID: "m/clash", Key: "strike", Name: "Clash", Stats: []Stat{Iron}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 20 {
+		if m, ok := c.Get("STRIKE"); !ok || m.ID != "m/clash" {
+			t.Fatalf("Get(STRIKE) = %q, %v; want the move keyed strike", m.ID, ok)
+		}
+		if m, _, ok := c.Match("strike +2"); !ok || m.ID != "m/clash" {
+			t.Fatalf("Match(strike +2) = %q, %v; want the move keyed strike", m.ID, ok)
+		}
+	}
+
+	c, _ = NewCatalog(
+		Move{ID: "z/strike", Key: "z_strike", Name: "Strike", Stats: []Stat{Iron}},
+		Move{ID: "a/strike", Key: "a_strike", Name: "Strike", Stats: []Stat{Edge}},
+	)
+	for range 20 {
+		if m, ok := c.Get("Strike"); !ok || m.ID != "a/strike" {
+			t.Fatalf("Get(Strike) = %q, %v; want the smallest ID", m.ID, ok)
+		}
+		if m, _, ok := c.Match("strike +2"); !ok || m.ID != "a/strike" {
+			t.Fatalf("Match(strike +2) = %q, %v; want the smallest ID", m.ID, ok)
+		}
+	}
+}
+
+func TestCatalogAdd(t *testing.T) {
+	c, err := NewCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Add(Move{ID: "x", Key: "x", Name: "X"}); err == nil {
+		t.Error("expected an action move without stats to be rejected")
+	}
+
+	if err := c.Add(Move{ID: "a", Key: "old", Name: "A", Stats: []Stat{Edge}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(Move{ID: "a", Key: "new", Name: "A", Stats: []Stat{Edge}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("old"); ok {
+		t.Error("expected the replaced key to be removed")
+	}
+	if _, ok := c.Get("new"); !ok {
+		t.Error("expected the new key to be registered")
+	}
+
+	// Replacing a move whose key was taken over keeps the new owner.
+	if err := c.Add(Move{ID: "b", Key: "new", Name: "B", Stats: []Stat{Edge}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(Move{ID: "a", Key: "other", Name: "A", Stats: []Stat{Edge}}); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := c.Get("new"); !ok || m.ID != "b" {
+		t.Fatalf("Get(new) = %q, %v; want the move that took the key over", m.ID, ok)
+	}
+}
+
+func TestCheckStat(t *testing.T) {
+	c := Builtin()
+
+	strike, _ := c.Get("strike")
+	if err := strike.CheckStat(Iron); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := strike.CheckStat(Heart); err == nil {
+		t.Error("expected Strike +heart to be rejected")
+	}
+
+	vow, _ := c.Get("fulfill_your_vow")
+	if err := vow.CheckStat(Heart); err == nil {
+		t.Error("expected a progress move to reject stats")
+	}
+}
+
+func TestOutcomeText(t *testing.T) {
+	m := Move{StrongHit: "strong", WeakHit: "weak", Miss: "miss"}
+
+	cases := []struct {
+		outcome roll.Outcome
+		want    string
+	}{
+		{roll.CriticalSuccess, "strong"},
+		{roll.Success, "strong"},
+		{roll.PartialSuccess, "weak"},
+		{roll.Failure, "miss"},
+		{roll.CriticalFailure, "miss"},
+	}
+
+	for _, c := range cases {
+		if got := m.OutcomeText(c.outcome); got != c.want {
+			t.Fatalf("OutcomeText(%v) = %q; want %q", c.outcome, got, c.want)
+		}
+	}
+}
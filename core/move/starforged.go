@@ -0,0 +1,182 @@
+package move
+
+// Ironsworn: Starforged moves that are resolved with a roll.
+//
+// The move text is condensed from Ironsworn: Starforged by Shawn Tomkin,
+// licensed under CC BY 4.0 (https://creativecommons.org/licenses/by/4.0/).
+
+// BuiltinStarforged returns a catalog holding the core Starforged moves.
+func BuiltinStarforged() *Catalog {
+	c, err := NewCatalog(starforgedMoves()...)
+	if err != nil {
+		// The built-in moves are covered by tests; an invalid one is a bug.
+		panic(err)
+	}
+	return c
+}
+
+// starforgedMoves returns the core Starforged moves that involve a roll.
+func starforgedMoves() []Move {
+	return []Move{
+		// Adventure moves
+		{
+			ID: "starforged/moves/adventure/face_danger", Key: "face_danger", Name: "Face Danger",
+			Stats:     stats,
+			StrongHit: "You are successful. Take +1 momentum.",
+			WeakHit:   "You succeed, but face a troublesome cost. Make a suffer move (-1).",
+			Miss:      "You fail, or a momentary success is undermined by a dire turn of events. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/adventure/secure_an_advantage", Key: "secure_an_advantage", Name: "Secure an Advantage",
+			Stats:     stats,
+			StrongHit: "You gain the upper hand. Take both: +2 momentum, and add +1 on your next move (not a progress move).",
+			WeakHit:   "Choose one: take +2 momentum, or add +1 on your next move (not a progress move).",
+			Miss:      "Your effort is thwarted. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/adventure/gather_information", Key: "gather_information", Name: "Gather Information",
+			Stats:     []Stat{Wits},
+			StrongHit: "You discover something helpful and specific. The path you must follow or action you must take is made clear. Take +2 momentum.",
+			WeakHit:   "The information complicates your quest or introduces a new danger. Take +1 momentum.",
+			Miss:      "Your investigation unearths a dire threat or reveals an unwelcome truth that undermines your quest. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/adventure/compel", Key: "compel", Name: "Compel",
+			Stats:     []Stat{Heart, Iron, Shadow},
+			StrongHit: "They'll do what you want or share what they know. Take +1 momentum.",
+			WeakHit:   "As above, but they ask something of you in return.",
+			Miss:      "They refuse or make a demand which costs you greatly. Pay the Price.",
+		},
+
+		// Quest moves
+		{
+			ID: "starforged/moves/quest/swear_an_iron_vow", Key: "swear_an_iron_vow", Name: "Swear an Iron Vow",
+			Stats:     []Stat{Heart},
+			StrongHit: "You are emboldened and it is clear what you must do next. Take +2 momentum.",
+			WeakHit:   "You are determined but begin your quest with more questions than answers. Take +1 momentum.",
+			Miss:      "You face a significant obstacle before you can begin your quest. Envision what stands in your way, and choose one: press on (suffer -2 momentum) or give up (Forsake Your Vow).",
+		},
+		{
+			ID: "starforged/moves/quest/fulfill_your_vow", Key: "fulfill_your_vow", Name: "Fulfill Your Vow",
+			Progress:  true,
+			StrongHit: "Your quest is complete. Mark a reward on your quests legacy track (troublesome 1 tick, dangerous 2, formidable 1 box, extreme 2 boxes, epic 3 boxes).",
+			WeakHit:   "There is more to be done or you realize the truth of your quest. Mark a reward on your quests legacy track one rank lower. You may Swear an Iron Vow to set things right.",
+			Miss:      "Your vow is undone through an unexpected complication or realization. Choose one: recommit (roll both challenge dice, take the lowest and clear that many boxes; raise the rank by one) or give up (Forsake Your Vow).",
+		},
+
+		// Connection moves
+		{
+			ID: "starforged/moves/connection/make_a_connection", Key: "make_a_connection", Name: "Make a Connection",
+			Stats:     []Stat{Heart},
+			StrongHit: "You make a connection. Give them a role and rank, and note them on a progress track.",
+			WeakHit:   "As above, but this connection comes with a complication or cost. Envision what they reveal or demand.",
+			Miss:      "You don't make a connection and the situation worsens. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/connection/forge_a_bond", Key: "forge_a_bond", Name: "Forge a Bond",
+			Progress:  true,
+			StrongHit: "You forge a bond. Mark a reward on your bonds legacy track by the connection's rank, and choose one: bolster their aid or expand their influence.",
+			WeakHit:   "They ask something more of you first. Do it (or Swear an Iron Vow) and forge the bond.",
+			Miss:      "They reveal a motivation or background that puts you at odds. Recommit by raising the rank by one, or give up on the connection.",
+		},
+
+		// Exploration moves
+		{
+			ID: "starforged/moves/exploration/undertake_an_expedition", Key: "undertake_an_expedition", Name: "Undertake an Expedition",
+			Stats:     []Stat{Edge, Shadow, Wits},
+			StrongHit: "You reach a waypoint. Envision the location and mark progress.",
+			WeakHit:   "You reach a waypoint and mark progress, but suffer a cost: make a suffer move (-2), or two suffer moves (-1).",
+			Miss:      "You are waylaid by a perilous event. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/exploration/finish_an_expedition", Key: "finish_an_expedition", Name: "Finish an Expedition",
+			Progress:  true,
+			StrongHit: "You reach your destination or complete your survey. Mark a reward on your discoveries legacy track by the expedition's rank.",
+			WeakHit:   "As above, but you face an unforeseen hazard or complication. Mark a reward one rank lower.",
+			Miss:      "Your destination is lost to you, or you come to understand the true nature of the expedition. Pay the Price, or continue and raise the rank by one.",
+		},
+
+		// Combat moves
+		{
+			ID: "starforged/moves/combat/enter_the_fray", Key: "enter_the_fray", Name: "Enter the Fray",
+			Stats:     []Stat{Heart, Shadow, Wits},
+			StrongHit: "You have the initiative. Take +2 momentum and you are in control.",
+			WeakHit:   "Choose one: take +2 momentum, or you are in control.",
+			Miss:      "The fight begins with you in a bad spot.",
+		},
+		{
+			ID: "starforged/moves/combat/gain_ground", Key: "gain_ground", Name: "Gain Ground",
+			Stats:     stats,
+			StrongHit: "You stay in control. Choose two: mark progress, take +2 momentum, or add +1 on your next move (not a progress move).",
+			WeakHit:   "You stay in control. Choose one: mark progress, take +2 momentum, or add +1 on your next move (not a progress move).",
+			Miss:      "Your foe gains the upper hand, or the situation turns against you. You are in a bad spot. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/combat/strike", Key: "strike", Name: "Strike",
+			Stats:     []Stat{Iron, Edge},
+			StrongHit: "Mark progress twice. You dominate your foe and stay in control.",
+			WeakHit:   "Mark progress twice, but you expose yourself to danger. You are in a bad spot.",
+			Miss:      "Your attack fails and you must Pay the Price. You are in a bad spot.",
+		},
+		{
+			ID: "starforged/moves/combat/clash", Key: "clash", Name: "Clash",
+			Stats:     []Stat{Iron, Edge},
+			StrongHit: "Mark progress twice. You overwhelm your foe and are in control.",
+			WeakHit:   "Mark progress, but you are still in a bad spot.",
+			Miss:      "You are outmatched and your foe has the upper hand. Pay the Price. You stay in a bad spot.",
+		},
+		{
+			ID: "starforged/moves/combat/react_under_fire", Key: "react_under_fire", Name: "React Under Fire",
+			Stats:     stats,
+			StrongHit: "You succeed and are in control. Take +1 momentum.",
+			WeakHit:   "You avoid the worst of the danger or overcome the obstacle, but not without a cost. Make a suffer move (-1). You stay in a bad spot.",
+			Miss:      "Things go from bad to worse. Pay the Price. You stay in a bad spot.",
+		},
+		{
+			ID: "starforged/moves/combat/take_decisive_action", Key: "take_decisive_action", Name: "Take Decisive Action",
+			Progress:  true,
+			StrongHit: "You prevail. Take +1 momentum. If in a bad spot, you may envision how the situation shifts in your favor.",
+			WeakHit:   "You achieve your objective, but not without cost. Choose one: it's worse than you thought, others are in danger, it's not over, or you suffer a cost.",
+			Miss:      "You are defeated or your objective is lost. Pay the Price.",
+		},
+
+		// Suffer moves
+		{
+			ID: "starforged/moves/suffer/endure_harm", Key: "endure_harm", Name: "Endure Harm",
+			Stats:     []Stat{Health, Iron},
+			StrongHit: "Choose one: shake it off (if not wounded, suffer -1 momentum and take +1 health) or embrace the pain (take +1 momentum).",
+			WeakHit:   "You press on.",
+			Miss:      "Also suffer -1 momentum. If you are at 0 health, mark wounded or permanently harmed, or roll on the Endure Harm table.",
+		},
+		{
+			ID: "starforged/moves/suffer/endure_stress", Key: "endure_stress", Name: "Endure Stress",
+			Stats:     []Stat{Spirit, Heart},
+			StrongHit: "Choose one: shake it off (if not shaken, suffer -1 momentum and take +1 spirit) or embrace the darkness (take +1 momentum).",
+			WeakHit:   "You press on.",
+			Miss:      "Also suffer -1 momentum. If you are at 0 spirit, mark shaken or traumatized, or roll on the Endure Stress table.",
+		},
+
+		// Recover moves
+		{
+			ID: "starforged/moves/recover/heal", Key: "heal", Name: "Heal",
+			Stats:     []Stat{Iron, Wits},
+			StrongHit: "Your care is helpful. If the wounded impact is marked, you may clear it. Then take or give up to +2 health.",
+			WeakHit:   "As above, but the recovery costs extra time or resources. Choose one: lose momentum or suffer -1 supply.",
+			Miss:      "Your aid is ineffective. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/recover/resupply", Key: "resupply", Name: "Resupply",
+			Stats:     []Stat{Wits},
+			StrongHit: "You bolster your resources. Take +2 supply.",
+			WeakHit:   "Take up to +2 supply, but suffer -1 momentum for each.",
+			Miss:      "You find nothing helpful. Pay the Price.",
+		},
+		{
+			ID: "starforged/moves/recover/sojourn", Key: "sojourn", Name: "Sojourn",
+			Stats:     []Stat{Heart},
+			StrongHit: "You and your allies may each choose two recover actions. Focus on a need of the community for one more.",
+			WeakHit:   "Choose one recover action. Focus on a need of the community for one more.",
+			Miss:      "You find no help here. Pay the Price.",
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package oracle
+
+import (
+	"fmt"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Odds is the likelihood chosen when asking the oracle a yes/no question.
+type Odds string
+
+const (
+	AlmostCertain Odds = "Almost Certain"
+	Likely        Odds = "Likely"
+	FiftyFifty    Odds = "50/50"
+	Unlikely      Odds = "Unlikely"
+	SmallChance   Odds = "Small Chance"
+)
+
+// AllOdds lists the odds levels from most to least likely.
+var AllOdds = []Odds{AlmostCertain, Likely, FiftyFifty, Unlikely, SmallChance}
+
+// yesThreshold maps each odds level to the lowest d100 roll
+// that answers "yes":
+//
+//	Almost Certain => 11+
+//	Likely         => 26+
+//	50/50          => 51+
+//	Unlikely       => 76+
+//	Small Chance   => 91+
+var yesThreshold = map[Odds]int{
+	AlmostCertain: 11,
+	Likely:        26,
+	FiftyFifty:    51,
+	Unlikely:      76,
+	SmallChance:   91,
+}
+
+// ParseOdds converts user input such as "likely", "almost certain",
+// "50-50" or "small_chance" into an Odds level.
+func ParseOdds(raw string) (Odds, bool) {
+	key := normalizeKey(raw)
+
+	switch key {
+	case "almost_certain", "certain":
+		return AlmostCertain, true
+	case "likely":
+		return Likely, true
+	case "50/50", "50_50", "5050", "fifty_fifty", "even":
+		return FiftyFifty, true
+	case "unlikely":
+		return Unlikely, true
+	case "small_chance", "small":
+		return SmallChance, true
+	default:
+		return "", false
+	}
+}
+
+// Answer is the outcome of asking the oracle a yes/no question.
+type Answer struct {
+	Odds  Odds
+	Roll  int  // The d100 roll (1–100)
+	Yes   bool // Whether the answer is yes
+	Match bool // Both d100 digits match (11, 22, ... 99, 100): an extreme result or twist
+}
+
+// String renders the answer as "Yes", "No", "Extreme Yes" or "Extreme No".
+func (a Answer) String() string {
+	s := "No"
+	if a.Yes {
+		s = "Yes"
+	}
+	if a.Match {
+		s = "Extreme " + s
+	}
+	return s
+}
+
+// Ask asks the oracle a yes/no question with the given odds.
+func Ask(r *roll.Roller, odds Odds) (Answer, error) {
+	if _, ok := yesThreshold[odds]; !ok {
+		return Answer{}, fmt.Errorf("oracle: unknown odds %q", odds)
+	}
+	return determineAnswer(odds, r.Die(MaxRoll)), nil
+}
+
+// determineAnswer calculates the answer to a yes/no question
+// from the odds and the d100 roll.
+//
+// This function contains no randomness and no side effects.
+// The d100 is read as two d10s (tens and units, with 100 as "00"),
+// so a match is any roll whose two digits are equal.
+func determineAnswer(odds Odds, n int) Answer {
+	return Answer{
+		Odds:  odds,
+		Roll:  n,
+		Yes:   n >= yesThreshold[odds],
+		Match: n == MaxRoll || (n%11 == 0 && n < MaxRoll),
+	}
+}
@@ -0,0 +1,93 @@
+package oracle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestDetermineAnswerThresholds(t *testing.T) {
+	cases := []struct {
+		odds  Odds
+		roll  int
+		yes   bool
+		match bool
+	}{
+		{AlmostCertain, 10, false, false},
+		{AlmostCertain, 11, true, true},
+		{Likely, 25, false, false},
+		{Likely, 26, true, false},
+		{FiftyFifty, 50, false, false},
+		{FiftyFifty, 51, true, false},
+		{Unlikely, 75, false, false},
+		{Unlikely, 76, true, false},
+		{Unlikely, 77, true, true},
+		{SmallChance, 90, false, false},
+		{SmallChance, 91, true, false},
+		{SmallChance, 100, true, true},
+		{SmallChance, 1, false, false},
+		{FiftyFifty, 44, false, true},
+	}
+
+	for _, c := range cases {
+		got := determineAnswer(c.odds, c.roll)
+		if got.Yes != c.yes || got.Match != c.match {
+			t.Fatalf("determineAnswer(%q, %d) = yes:%v match:%v, want yes:%v match:%v",
+				c.odds, c.roll, got.Yes, got.Match, c.yes, c.match)
+		}
+	}
+}
+
+func TestAnswerString(t *testing.T) {
+	cases := map[Answer]string{
+		{Yes: true}:              "Yes",
+		{Yes: false}:             "No",
+		{Yes: true, Match: true}: "Extreme Yes",
+		{Match: true}:            "Extreme No",
+	}
+
+	for a, want := range cases {
+		if got := a.String(); got != want {
+			t.Fatalf("%+v.String() = %q, want %q", a, got, want)
+		}
+	}
+}
+
+func TestParseOdds(t *testing.T) {
+	cases := map[string]Odds{
+		"likely":         Likely,
+		"Almost Certain": AlmostCertain,
+		"almost_certain": AlmostCertain,
+		"50/50":          FiftyFifty,
+		"50-50":          FiftyFifty,
+		" unlikely ":     Unlikely,
+		"small chance":   SmallChance,
+	}
+
+	for in, want := range cases {
+		if got, ok := ParseOdds(in); !ok || got != want {
+			t.Fatalf("ParseOdds(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+
+	if _, ok := ParseOdds("maybe"); ok {
+		t.Fatal("ParseOdds should reject unknown odds")
+	}
+}
+
+func TestAsk(t *testing.T) {
+	roller := roll.NewRoller(rand.New(rand.NewSource(8)))
+
+	a, err := Ask(roller, Likely)
+	if err != nil {
+		t.Fatalf("Ask: %v", err)
+	}
+	if a.Roll < MinRoll || a.Roll > MaxRoll || a.Odds != Likely {
+		t.Fatalf("unexpected answer: %+v", a)
+	}
+
+	if _, err := Ask(roller, Odds("maybe")); err == nil {
+		t.Fatal("Ask should reject unknown odds")
+	}
+}
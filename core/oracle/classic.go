@@ -0,0 +1,297 @@
+package oracle
+
+// Classic Ironsworn oracle tables.
+//
+// The table content is from Ironsworn by Shawn Tomkin,
+// licensed under CC BY 4.0 (https://creativecommons.org/licenses/by/4.0/).
+
+// Builtin returns a registry holding the core Ironsworn oracles.
+func Builtin() *Registry {
+	r, err := NewRegistry(classicTables()...)
+	if err != nil {
+		// The built-in tables are covered by tests; an invalid one is a bug.
+		panic(err)
+	}
+	return r
+}
+
+// classicTables returns the core Ironsworn oracle tables.
+func classicTables() []Table {
+	return []Table{
+		actionTable,
+		themeTable,
+		regionTable,
+		locationTable,
+		coastalWatersTable,
+		settlementNameTable,
+		characterRoleTable,
+		characterGoalTable,
+		characterDescriptorTable,
+	}
+}
+
+// singles builds rows for a table where every result
+// occupies exactly one value, in roll order.
+func singles(results ...string) []Row {
+	rows := make([]Row, len(results))
+	for i, r := range results {
+		rows[i] = Row{Min: i + 1, Max: i + 1, Result: r}
+	}
+	return rows
+}
+
+// actionTable answers "what does this person, faction or force do?";
+// it is usually paired with themeTable.
+var actionTable = Table{
+	ID:   "classic/oracles/action_and_theme/action",
+	Key:  "action",
+	Name: "Action",
+	Rows: singles(
+		"Scheme", "Clash", "Weaken", "Initiate", "Create", "Swear", "Avenge",
+		"Guard", "Defeat", "Control", "Break", "Risk", "Surrender", "Inspect",
+		"Raid", "Evade", "Assault", "Deflect", "Threaten", "Attack", "Leave",
+		"Preserve", "Manipulate", "Remove", "Eliminate", "Withdraw", "Abandon",
+		"Investigate", "Hold", "Focus", "Uncover", "Breach", "Aid", "Uphold",
+		"Falter", "Suppress", "Hunt", "Share", "Destroy", "Avoid", "Reject",
+		"Demand", "Explore", "Bolster", "Seize", "Mourn", "Reveal", "Gather",
+		"Defy", "Transform", "Persevere", "Serve", "Begin", "Move",
+		"Coordinate", "Resist", "Await", "Impress", "Take", "Oppose",
+		"Capture", "Overwhelm", "Challenge", "Acquire", "Protect", "Finish",
+		"Strengthen", "Restore", "Advance", "Command", "Refuse", "Find",
+		"Deliver", "Hide", "Fortify", "Betray", "Secure", "Arrive", "Affect",
+		"Change", "Defend", "Debate", "Support", "Follow", "Construct",
+		"Locate", "Endure", "Release", "Lose", "Reduce", "Escalate",
+		"Distract", "Journey", "Escort", "Learn", "Communicate", "Depart",
+		"Search", "Charge", "Summon",
+	),
+}
+
+// themeTable gives the subject of an Action roll.
+var themeTable = Table{
+	ID:   "classic/oracles/action_and_theme/theme",
+	Key:  "theme",
+	Name: "Theme",
+	Rows: singles(
+		"Risk", "Ability", "Price", "Ally", "Battle", "Safety", "Survival",
+		"Weapon", "Wound", "Shelter", "Leader", "Fear", "Time", "Duty",
+		"Secret", "Innocence", "Renown", "Direction", "Death", "Honor",
+		"Labor", "Solution", "Tool", "Balance", "Love", "Barrier", "Creation",
+		"Decay", "Trade", "Bond", "Hope", "Superstition", "Peace", "Deception",
+		"History", "World", "Vow", "Protection", "Nature", "Opinion", "Burden",
+		"Vengeance", "Opportunity", "Faction", "Danger", "Corruption",
+		"Freedom", "Debt", "Hate", "Possession", "Stranger", "Passage", "Land",
+		"Creature", "Disease", "Advantage", "Blood", "Language", "Rumor",
+		"Weakness", "Greed", "Family", "Resource", "Structure", "Dream",
+		"Community", "War", "Portent", "Prize", "Destiny", "Momentum", "Power",
+		"Memory", "Ruin", "Mysticism", "Rival", "Problem", "Idea", "Revenge",
+		"Health", "Fellowship", "Enemy", "Religion", "Spirit", "Fame",
+		"Desolation", "Strength", "Knowledge", "Truth", "Quest", "Pride",
+		"Loss", "Law", "Path", "Warning", "Relationship", "Wealth", "Home",
+		"Strategy", "Supply",
+	),
+}
+
+// regionTable picks where in the Ironlands something lies.
+var regionTable = Table{
+	ID:   "classic/oracles/place/region",
+	Key:  "region",
+	Name: "Region",
+	Rows: []Row{
+		{1, 12, "Barrier Islands"},
+		{13, 24, "Ragged Coast"},
+		{25, 34, "Deep Wilds"},
+		{35, 46, "Flooded Lands"},
+		{47, 60, "Havens"},
+		{61, 72, "Hinterlands"},
+		{73, 84, "Tempest Hills"},
+		{85, 94, "Veiled Mountains"},
+		{95, 99, "Shattered Wastes"},
+		{100, 100, "Elsewhere"},
+	},
+}
+
+// locationTable picks a place of interest in the wilds.
+var locationTable = Table{
+	ID:   "classic/oracles/place/location",
+	Key:  "location",
+	Name: "Location",
+	Rows: []Row{
+		{1, 5, "Hideout"},
+		{6, 10, "Ruin"},
+		{11, 15, "Mine"},
+		{16, 20, "Waste"},
+		{21, 25, "Mystical site"},
+		{26, 30, "Path"},
+		{31, 35, "Outpost"},
+		{36, 40, "Wall"},
+		{41, 45, "Battlefield"},
+		{46, 50, "Hovel"},
+		{51, 55, "Spring"},
+		{56, 60, "Lair"},
+		{61, 63, "Fort"},
+		{64, 66, "Bridge"},
+		{67, 69, "Camp"},
+		{70, 72, "Cairn/grave"},
+		{73, 74, "Caravan"},
+		{75, 76, "Waterfall"},
+		{77, 78, "Cave"},
+		{79, 80, "Swamp"},
+		{81, 82, "Fen"},
+		{83, 84, "Ravine"},
+		{85, 86, "Road"},
+		{87, 88, "Tree"},
+		{89, 90, "Pond"},
+		{91, 92, "Fields"},
+		{93, 94, "Marsh"},
+		{95, 96, "Steading"},
+		{97, 98, "Rapids"},
+		{99, 100, "Pass"},
+	},
+}
+
+// coastalWatersTable picks a place of interest at sea or along the shore.
+var coastalWatersTable = Table{
+	ID:   "classic/oracles/place/coastal_waters_location",
+	Key:  "coastal_waters",
+	Name: "Coastal Waters Location",
+	Rows: []Row{
+		{1, 10, "Fleet"},
+		{11, 20, "Sea cave"},
+		{21, 30, "Misty isle"},
+		{31, 40, "Wreck"},
+		{41, 50, "Harbor"},
+		{51, 58, "Ship"},
+		{59, 66, "Rocky reef"},
+		{67, 74, "Fjord"},
+		{75, 82, "Estuary"},
+		{83, 90, "Cove"},
+		{91, 95, "Bay"},
+		{96, 98, "Ice floe"},
+		{99, 100, "Sea stack"},
+	},
+}
+
+// settlementNameTable picks what a settlement is named for.
+var settlementNameTable = Table{
+	ID:   "classic/oracles/settlement/name",
+	Key:  "settlement_name",
+	Name: "Settlement Name",
+	Rows: []Row{
+		{1, 15, "A feature of the landscape (e.g. Highmount, Brackwater, Frostwood)"},
+		{16, 30, "A manmade edifice (e.g. Whitebridge, Lonefort, Highcairn)"},
+		{31, 45, "A creature (e.g. Ravencliff, Bearmark, Wolfden)"},
+		{46, 60, "A historical event (e.g. Swordbreak, Firstmeet, Marrow's Fall)"},
+		{61, 75, "A word in an Old World language (e.g. Abon, Kazeera, Vallia)"},
+		{76, 90, "A season or environmental aspect (e.g. Winterhome, Mistvale, Summerfield)"},
+		{91, 100, "Something else (e.g. Ironhold, Stoneroot, Hope)"},
+	},
+}
+
+// characterRoleTable picks what a person does.
+var characterRoleTable = Table{
+	ID:   "classic/oracles/character/role",
+	Key:  "character_role",
+	Name: "Character Role",
+	Rows: []Row{
+		{1, 2, "Criminal"},
+		{3, 4, "Healer"},
+		{5, 6, "Bandit"},
+		{7, 9, "Guide"},
+		{10, 12, "Performer"},
+		{13, 15, "Miner"},
+		{16, 18, "Mercenary"},
+		{19, 21, "Outcast"},
+		{22, 24, "Vagrant"},
+		{25, 27, "Forester"},
+		{28, 30, "Traveler"},
+		{31, 33, "Mystic"},
+		{34, 36, "Priest"},
+		{37, 39, "Sailor"},
+		{40, 42, "Pilgrim"},
+		{43, 45, "Thief"},
+		{46, 48, "Adventurer"},
+		{49, 51, "Forager"},
+		{52, 54, "Leader"},
+		{55, 58, "Guard"},
+		{59, 62, "Artisan"},
+		{63, 66, "Scout"},
+		{67, 70, "Herder"},
+		{71, 74, "Fisher"},
+		{75, 79, "Warrior"},
+		{80, 84, "Hunter"},
+		{85, 89, "Raider"},
+		{90, 94, "Trader"},
+		{95, 99, "Farmer"},
+		{100, 100, "Unusual role"},
+	},
+}
+
+// characterGoalTable picks what a person wants.
+var characterGoalTable = Table{
+	ID:   "classic/oracles/character/goal",
+	Key:  "character_goal",
+	Name: "Character Goal",
+	Rows: []Row{
+		{1, 3, "Obtain an object"},
+		{4, 6, "Make an agreement"},
+		{7, 9, "Build a relationship"},
+		{10, 12, "Undermine a relationship"},
+		{13, 15, "Seek a truth"},
+		{16, 18, "Pay a debt"},
+		{19, 21, "Refute a falsehood"},
+		{22, 24, "Harm a rival"},
+		{25, 27, "Cure an ill"},
+		{28, 30, "Find a person"},
+		{31, 33, "Find a home"},
+		{34, 36, "Seize power"},
+		{37, 39, "Restore a relationship"},
+		{40, 42, "Create an item"},
+		{43, 45, "Travel to a place"},
+		{46, 48, "Secure provisions"},
+		{49, 51, "Rebel against power"},
+		{52, 54, "Collect a debt"},
+		{55, 57, "Protect a secret"},
+		{58, 60, "Spread faith"},
+		{61, 63, "Enrich themselves"},
+		{64, 66, "Protect a person"},
+		{67, 69, "Protect the status quo"},
+		{70, 72, "Advance status"},
+		{73, 75, "Defend a place"},
+		{76, 78, "Avenge a wrong"},
+		{79, 81, "Fulfill a duty"},
+		{82, 84, "Gain knowledge"},
+		{85, 87, "Prove worthiness"},
+		{88, 90, "Find redemption"},
+		{91, 92, "Escape from something"},
+		{93, 95, "Repair a wrong"},
+		{96, 98, "Earn respect"},
+		{99, 100, "Roll twice"},
+	},
+}
+
+// characterDescriptorTable picks a defining trait.
+var characterDescriptorTable = Table{
+	ID:   "classic/oracles/character/descriptor",
+	Key:  "character_descriptor",
+	Name: "Character Descriptor",
+	Rows: singles(
+		"Stoic", "Attractive", "Passive", "Aloof", "Affectionate", "Generous",
+		"Smug", "Armed", "Clever", "Brave", "Ugly", "Sociable", "Doomed",
+		"Connected", "Bold", "Jealous", "Angry", "Active", "Suspicious",
+		"Hostile", "Hardhearted", "Successful", "Talented", "Experienced",
+		"Deceitful", "Ambitious", "Aggressive", "Conceited", "Proud", "Stern",
+		"Dependent", "Wary", "Strong", "Insightful", "Dangerous", "Quirky",
+		"Cheery", "Disfigured", "Intolerant", "Skilled", "Stingy", "Timid",
+		"Insensitive", "Wild", "Bitter", "Cunning", "Remorseful", "Kind",
+		"Charming", "Oblivious", "Critical", "Cautious", "Resourceful",
+		"Weary", "Wounded", "Anxious", "Powerful", "Athletic", "Driven",
+		"Cruel", "Quiet", "Honest", "Infamous", "Dying", "Reclusive",
+		"Artistic", "Disabled", "Confused", "Manipulative", "Relaxed",
+		"Stealthy", "Confident", "Weak", "Friendly", "Wise", "Influential",
+		"Young", "Adventurous", "Oppressed", "Vengeful", "Cooperative",
+		"Armored", "Apathetic", "Determined", "Loyal", "Sick", "Religious",
+		"Selfish", "Old", "Fervent", "Violent", "Agreeable", "Hot-tempered",
+		"Stubborn", "Incompetent", "Greedy", "Cowardly", "Obsessed",
+		"Careless", "Ironsworn",
+	),
+}
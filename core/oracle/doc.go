@@ -0,0 +1,14 @@
+// Package oracle implements Ironsworn oracle tables.
+//
+// An oracle table maps ranges of a d100 roll (1–100) to results.
+// Every table must cover each value from 1 to 100 exactly once;
+// Validate enforces this so that a lookup can never fall through.
+//
+// Tables are identified by a Datasworn-style ID such as
+// "classic/oracles/action_and_theme/action" and by a short key
+// ("action") convenient for chat commands. A Registry indexes
+// tables by both.
+//
+// Like core/roll, this package contains domain logic only.
+// Randomness is supplied by a *roll.Roller.
+package oracle
@@ -0,0 +1,177 @@
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Oracle tables are rolled with a d100.
+const (
+	MinRoll = 1
+	MaxRoll = 100
+)
+
+// Row is a single entry of an oracle table.
+// It matches every roll from Min to Max inclusive.
+type Row struct {
+	Min    int
+	Max    int
+	Result string
+}
+
+// Table is a d100 oracle table.
+type Table struct {
+	ID   string // Datasworn-style ID, e.g. "classic/oracles/action_and_theme/action"
+	Key  string // Short lookup key, e.g. "action"
+	Name string // Display name, e.g. "Action"
+	Rows []Row  // Rows in ascending roll order
+}
+
+// Validate reports whether the table is well formed: it must have an ID,
+// a key and a name, and its rows must cover 1–100 in ascending order
+// without gaps or overlaps.
+func (t Table) Validate() error {
+	if t.ID == "" || t.Key == "" || t.Name == "" {
+		return fmt.Errorf("oracle: table %q: id, key and name are required", t.ID)
+	}
+
+	next := MinRoll
+	for i, row := range t.Rows {
+		if row.Min != next {
+			return fmt.Errorf("oracle: table %q: row %d starts at %d, want %d", t.ID, i, row.Min, next)
+		}
+		if row.Max < row.Min {
+			return fmt.Errorf("oracle: table %q: row %d ends at %d before it starts at %d", t.ID, i, row.Max, row.Min)
+		}
+		if row.Result == "" {
+			return fmt.Errorf("oracle: table %q: row %d has no result", t.ID, i)
+		}
+		next = row.Max + 1
+	}
+
+	if next != MaxRoll+1 {
+		return fmt.Errorf("oracle: table %q: rows end at %d, want %d", t.ID, next-1, MaxRoll)
+	}
+	return nil
+}
+
+// Lookup returns the row matching a d100 roll.
+// It fails if n is outside 1–100 or the table does not cover n.
+func (t Table) Lookup(n int) (Row, error) {
+	if n < MinRoll || n > MaxRoll {
+		return Row{}, fmt.Errorf("oracle: roll %d out of range %d-%d", n, MinRoll, MaxRoll)
+	}
+
+	// Rows are sorted, so a binary search finds the first row
+	// whose upper bound is not below n.
+	i := sort.Search(len(t.Rows), func(i int) bool { return t.Rows[i].Max >= n })
+	if i == len(t.Rows) || t.Rows[i].Min > n {
+		return Row{}, fmt.Errorf("oracle: table %q has no row for %d", t.ID, n)
+	}
+	return t.Rows[i], nil
+}
+
+// Result is the outcome of rolling on an oracle table.
+type Result struct {
+	Table  Table // The table rolled on
+	Roll   int   // The d100 roll (1–100)
+	Result string
+}
+
+// Roll rolls a d100 with the given Roller and looks up the result.
+//
+// The table is expected to be valid (see Validate); tables obtained
+// from a Registry always are.
+func Roll(r *roll.Roller, t Table) (Result, error) {
+	n := r.Die(MaxRoll)
+
+	row, err := t.Lookup(n)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{Table: t, Roll: n, Result: row.Result}, nil
+}
+
+// Registry indexes oracle tables by ID and by key.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	byID  map[string]Table
+	byKey map[string]string // key -> ID
+}
+
+// NewRegistry returns a registry holding the given tables.
+func NewRegistry(tables ...Table) (*Registry, error) {
+	r := &Registry{
+		byID:  make(map[string]Table),
+		byKey: make(map[string]string),
+	}
+
+	for _, t := range tables {
+		if err := r.Add(t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Add validates a table and adds it to the registry. A table with the
+// same ID replaces the existing one; a key already used by a different
+// table is reassigned to the new table.
+func (r *Registry) Add(t Table) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The old key is freed only if the replaced table still owns it.
+	if old, ok := r.byID[t.ID]; ok && r.byKey[normalizeKey(old.Key)] == t.ID {
+		delete(r.byKey, normalizeKey(old.Key))
+	}
+	r.byID[t.ID] = t
+	r.byKey[normalizeKey(t.Key)] = t.ID
+	return nil
+}
+
+// Get finds a table by ID or by key. Keys are matched case-insensitively
+// and spaces or dashes may be used in place of underscores, so
+// "Coastal Waters" finds the "coastal_waters" table.
+func (r *Registry) Get(name string) (Table, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t, ok := r.byID[name]; ok {
+		return t, true
+	}
+	if id, ok := r.byKey[normalizeKey(name)]; ok {
+		return r.byID[id], true
+	}
+	return Table{}, false
+}
+
+// Tables returns every table in the registry ordered by ID.
+func (r *Registry) Tables() []Table {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Table, 0, len(r.byID))
+	for _, t := range r.byID {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// normalizeKey folds a user-supplied table key into its canonical form.
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+}
@@ -0,0 +1,163 @@
+package oracle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestBuiltinTablesAreValid(t *testing.T) {
+	for _, table := range classicTables() {
+		if err := table.Validate(); err != nil {
+			t.Fatalf("built-in table invalid: %v", err)
+		}
+	}
+
+	if got := len(Builtin().Tables()); got != len(classicTables()) {
+		t.Fatalf("Builtin registry holds %d tables, want %d", got, len(classicTables()))
+	}
+}
+
+func TestLookupTableBoundaries(t *testing.T) {
+	reg := Builtin()
+
+	cases := []struct {
+		table string
+		roll  int
+		want  string
+	}{
+		{"action", 1, "Scheme"},
+		{"action", 100, "Summon"},
+		{"theme", 1, "Risk"},
+		{"theme", 100, "Supply"},
+		{"region", 12, "Barrier Islands"},
+		{"region", 13, "Ragged Coast"},
+		{"region", 99, "Shattered Wastes"},
+		{"region", 100, "Elsewhere"},
+		{"location", 60, "Lair"},
+		{"location", 61, "Fort"},
+		{"coastal_waters", 10, "Fleet"},
+		{"coastal_waters", 11, "Sea cave"},
+		{"settlement_name", 15, "A feature of the landscape (e.g. Highmount, Brackwater, Frostwood)"},
+		{"character_role", 99, "Farmer"},
+		{"character_role", 100, "Unusual role"},
+		{"character_goal", 98, "Earn respect"},
+		{"character_goal", 99, "Roll twice"},
+		{"character_descriptor", 100, "Ironsworn"},
+	}
+
+	for _, c := range cases {
+		table, ok := reg.Get(c.table)
+		if !ok {
+			t.Fatalf("table %q not found", c.table)
+		}
+		row, err := table.Lookup(c.roll)
+		if err != nil {
+			t.Fatalf("%s.Lookup(%d): %v", c.table, c.roll, err)
+		}
+		if row.Result != c.want {
+			t.Fatalf("%s.Lookup(%d) = %q, want %q", c.table, c.roll, row.Result, c.want)
+		}
+	}
+}
+
+func TestLookupOutOfRange(t *testing.T) {
+	for _, n := range []int{0, 101, -5} {
+		if _, err := actionTable.Lookup(n); err == nil {
+			t.Fatalf("Lookup(%d) should fail", n)
+		}
+	}
+}
+
+func TestValidateRejectsMalformedTables(t *testing.T) {
+	cases := map[string][]Row{
+		"Gap":         {{1, 50, "a"}, {52, 100, "b"}},
+		"Overlap":     {{1, 50, "a"}, {50, 100, "b"}},
+		"Short":       {{1, 99, "a"}},
+		"Inverted":    {{1, 50, "a"}, {60, 51, "b"}, {61, 100, "c"}},
+		"EmptyResult": {{1, 100, ""}},
+		"NoRows":      nil,
+	}
+
+	for name, rows := range cases {
+		table := Table{ID: "test/" + name, Key: name, Name: name, Rows: rows}
+		if err := table.Validate(); err == nil {
+			t.Fatalf("%s: Validate should fail", name)
+		}
+	}
+
+	if err := (Table{Rows: []Row{{1, 100, "a"}}}).Validate(); err == nil {
+		t.Fatal("Validate should require id, key and name")
+	}
+}
+
+func TestRegistryGetByIDAndKey(t *testing.T) {
+	reg := Builtin()
+
+	for _, name := range []string{
+		"classic/oracles/place/coastal_waters_location",
+		"coastal_waters",
+		"Coastal Waters",
+		"coastal-waters",
+	} {
+		table, ok := reg.Get(name)
+		if !ok || table.Key != "coastal_waters" {
+			t.Fatalf("Get(%q) = %q, %v; want coastal_waters", name, table.Key, ok)
+		}
+	}
+
+	if _, ok := reg.Get("nope"); ok {
+		t.Fatal("Get should not find unknown tables")
+	}
+}
+
+func TestRegistryAddReplacesByID(t *testing.T) {
+	reg, err := NewRegistry(actionTable)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	custom := Table{ID: actionTable.ID, Key: "act", Name: "Custom Action", Rows: []Row{{1, 100, "Wait"}}}
+	if err := reg.Add(custom); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, ok := reg.Get("action"); ok {
+		t.Fatal("old key should no longer resolve after replacement")
+	}
+	if table, ok := reg.Get("act"); !ok || table.Name != "Custom Action" {
+		t.Fatalf("replacement not found by new key: %+v", table)
+	}
+
+	// Replacing a table whose key was taken over keeps the new owner.
+	other := Table{ID: "other", Key: "act", Name: "Other", Rows: []Row{{1, 100, "Go"}}}
+	if err := reg.Add(other); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	custom.Key = "deed"
+	if err := reg.Add(custom); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if table, ok := reg.Get("act"); !ok || table.ID != "other" {
+		t.Fatalf("key taken over by another table lost: %+v, %v", table, ok)
+	}
+	if err := reg.Add(Table{ID: "bad", Key: "bad", Name: "Bad"}); err == nil {
+		t.Fatal("Add should reject invalid tables")
+	}
+}
+
+func TestRollMatchesLookup(t *testing.T) {
+	roller := roll.NewRoller(rand.New(rand.NewSource(6)))
+
+	for i := 0; i < 200; i++ {
+		res, err := Roll(roller, regionTable)
+		if err != nil {
+			t.Fatalf("Roll: %v", err)
+		}
+		row, _ := regionTable.Lookup(res.Roll)
+		if res.Result != row.Result {
+			t.Fatalf("Roll result %q does not match lookup %q for %d", res.Result, row.Result, res.Roll)
+		}
+	}
+}
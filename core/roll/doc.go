@@ -27,6 +27,31 @@
 //   - Critical Success: 2 wins AND both challenge dice show the same value
 //   - Critical Failure: 0 wins AND both challenge dice show the same value
 //
+// Momentum:
+//
+// An action roll may be made with the character's current momentum.
+//   - Negative momentum: if the action die equals |momentum|, the action
+//     die is cancelled and the action score is the modifier alone.
+//   - Burning momentum: positive momentum may replace the action score.
+//     The roll reports whether burning would beat more challenge dice,
+//     and the outcome it would produce. Burning is never automatic.
+//
+// Progress rolls:
+//
+// A progress roll (fulfilling a vow, ending a fight, reaching a
+// destination) uses the progress score (0–10) in place of the action
+// score. No action die is rolled and no modifier applies; the score is
+// compared against the two challenge dice with exactly the same rules
+// and outcomes as an action roll.
+//
+// Verifiable rolls:
+//
+// A Roller created with NewVerifiableRoller asks a Prover for a fresh
+// Source for every roll. Each Result then carries a roll ID and a Proof
+// (the published commitment and the per-roll nonce), so the dice can be
+// recomputed by anyone once the server secret is revealed. The Prover
+// itself lives outside this package (see core/fair).
+//
 // This package is intentionally small, explicit, and heavily documented.
 // It is designed to be auditable and educational.
 package roll
@@ -0,0 +1,69 @@
+package roll
+
+// Odds holds the exact outcome probabilities of a roll, counted over
+// every equally likely combination of dice.
+//
+// Critical Success and Critical Failure are the strong hits and misses
+// with matching challenge dice; Success and Failure are those without.
+type Odds struct {
+	Combinations int             // Number of equally likely dice combinations
+	Counts       map[Outcome]int // Combinations producing each outcome
+}
+
+// Probability returns the exact probability of an outcome.
+func (o Odds) Probability(outcome Outcome) float64 {
+	if o.Combinations == 0 {
+		return 0
+	}
+	return float64(o.Counts[outcome]) / float64(o.Combinations)
+}
+
+// StrongHit returns the probability of a strong hit, with or without
+// a match.
+func (o Odds) StrongHit() float64 {
+	return o.Probability(Success) + o.Probability(CriticalSuccess)
+}
+
+// WeakHit returns the probability of a weak hit.
+func (o Odds) WeakHit() float64 {
+	return o.Probability(PartialSuccess)
+}
+
+// Miss returns the probability of a miss, with or without a match.
+func (o Odds) Miss() float64 {
+	return o.Probability(Failure) + o.Probability(CriticalFailure)
+}
+
+// ActionOdds enumerates all 6×10×10 dice combinations of an action
+// roll with the given modifier and returns the exact outcome odds.
+//
+// This function contains no randomness and no side effects.
+func ActionOdds(modifier int) Odds {
+	odds := Odds{Counts: make(map[Outcome]int)}
+	for die := 1; die <= 6; die++ {
+		odds.add(die + modifier)
+	}
+	return odds
+}
+
+// ProgressOdds enumerates all 10×10 challenge dice combinations of a
+// progress roll against the given score and returns the exact outcome
+// odds. Scores outside 0–10 are clamped, as in ProgressRoll.
+//
+// This function contains no randomness and no side effects.
+func ProgressOdds(score int) Odds {
+	odds := Odds{Counts: make(map[Outcome]int)}
+	odds.add(clampProgress(score))
+	return odds
+}
+
+// add counts the outcomes of an action score against every pair of
+// challenge dice.
+func (o *Odds) add(total int) {
+	for c1 := 1; c1 <= 10; c1++ {
+		for c2 := 1; c2 <= 10; c2++ {
+			o.Counts[determineOutcome(total, [2]int{c1, c2})]++
+			o.Combinations++
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package roll
+
+import (
+	"math"
+	"testing"
+)
+
+func TestActionOdds(t *testing.T) {
+	// Counts out of 600, from an independent enumeration.
+	cases := []struct {
+		modifier         int
+		cs, s, ps, f, cf int
+	}{
+		{0, 15, 40, 190, 310, 45},
+		{2, 27, 112, 262, 166, 33},
+		{3, 33, 166, 262, 112, 27},
+		{4, 39, 232, 238, 70, 21},
+		{9, 59, 522, 18, 0, 1},
+	}
+
+	for _, c := range cases {
+		o := ActionOdds(c.modifier)
+		if o.Combinations != 600 {
+			t.Fatalf("+%d: %d combinations; want 600", c.modifier, o.Combinations)
+		}
+		want := map[Outcome]int{
+			CriticalSuccess: c.cs,
+			Success:         c.s,
+			PartialSuccess:  c.ps,
+			Failure:         c.f,
+			CriticalFailure: c.cf,
+		}
+		for outcome, n := range want {
+			if o.Counts[outcome] != n {
+				t.Fatalf("+%d: %s in %d combinations; want %d", c.modifier, outcome, o.Counts[outcome], n)
+			}
+		}
+	}
+
+	o := ActionOdds(3)
+	if math.Abs(o.StrongHit()-199.0/600) > 1e-12 || math.Abs(o.WeakHit()-262.0/600) > 1e-12 || math.Abs(o.Miss()-139.0/600) > 1e-12 {
+		t.Fatalf("+3: strong %v weak %v miss %v", o.StrongHit(), o.WeakHit(), o.Miss())
+	}
+}
+
+func TestOddsSumToOne(t *testing.T) {
+	for m := -3; m <= 12; m++ {
+		o := ActionOdds(m)
+		sum := 0
+		for _, n := range o.Counts {
+			sum += n
+		}
+		if sum != o.Combinations {
+			t.Fatalf("+%d: counts sum to %d of %d", m, sum, o.Combinations)
+		}
+		if p := o.StrongHit() + o.WeakHit() + o.Miss(); math.Abs(p-1) > 1e-12 {
+			t.Fatalf("+%d: probabilities sum to %v", m, p)
+		}
+	}
+}
+
+func TestProgressOdds(t *testing.T) {
+	o := ProgressOdds(7)
+	if o.Combinations != 100 {
+		t.Fatalf("%d combinations; want 100", o.Combinations)
+	}
+	// Beating both dice needs both below 7: 6×6 = 36 pairs, 6 of them matches.
+	if o.Counts[Success] != 30 || o.Counts[CriticalSuccess] != 6 {
+		t.Fatalf("unexpected strong hits: %v", o.Counts)
+	}
+	// Matches on 7–10 are the only critical failures.
+	if o.Counts[CriticalFailure] != 4 || o.Counts[Failure] != 12 {
+		t.Fatalf("unexpected misses: %v", o.Counts)
+	}
+
+	// Only a double ten beats a full track.
+	if ProgressOdds(10).Miss() != 0.01 || ProgressOdds(0).StrongHit() != 0 {
+		t.Fatal("unexpected odds at the ends of the progress range")
+	}
+	if ProgressOdds(12).Counts[CriticalSuccess] != ProgressOdds(10).Counts[CriticalSuccess] {
+		t.Fatal("progress score not clamped")
+	}
+}
+
+func TestZeroOdds(t *testing.T) {
+	if p := (Odds{}).Probability(Success); p != 0 {
+		t.Fatalf("empty odds probability = %v", p)
+	}
+}
@@ -39,6 +39,19 @@ var outcomeTable = map[outcomeKey]Outcome{
 // This function contains no randomness and no side effects.
 // It implements the Ironsworn comparison rules exactly.
 func determineOutcome(total int, challenge [2]int) Outcome {
+	isDouble := challenge[0] == challenge[1]
+
+	key := outcomeKey{
+		wins:     countWins(total, challenge),
+		isDouble: isDouble,
+	}
+
+	// The table lookup is guaranteed to succeed for all valid states.
+	return outcomeTable[key]
+}
+
+// countWins reports how many challenge dice the action score beats.
+func countWins(total int, challenge [2]int) int {
 	wins := 0
 
 	// Compare the action score against each challenge die.
@@ -49,13 +62,5 @@ func determineOutcome(total int, challenge [2]int) Outcome {
 		}
 	}
 
-	isDouble := challenge[0] == challenge[1]
-
-	key := outcomeKey{
-		wins:     wins,
-		isDouble: isDouble,
-	}
-
-	// The table lookup is guaranteed to succeed for all valid states.
-	return outcomeTable[key]
+	return wins
 }
@@ -0,0 +1,226 @@
+package roll
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DieSlot names one die of an action roll.
+type DieSlot string
+
+const (
+	SlotAction     DieSlot = "action"
+	SlotChallenge1 DieSlot = "challenge1"
+	SlotChallenge2 DieSlot = "challenge2"
+)
+
+// AllSlots lists the dice of an action roll in display order.
+var AllSlots = []DieSlot{SlotAction, SlotChallenge1, SlotChallenge2}
+
+// Reroll is one entry of a roll's reroll audit trail.
+type Reroll struct {
+	Die         DieSlot
+	Original    int // Value before the reroll
+	Replacement int // Value rolled in its place
+}
+
+// Rerolled reports whether the die has been rerolled at least once.
+func (r Result) Rerolled(die DieSlot) bool {
+	for _, rr := range r.Rerolls {
+		if rr.Die == die {
+			return true
+		}
+	}
+	return false
+}
+
+// die returns a pointer to the value of the named die and its sides.
+func (r *Result) die(slot DieSlot) (*int, int, bool) {
+	switch slot {
+	case SlotAction:
+		return &r.ActionDie, 6, true
+	case SlotChallenge1:
+		return &r.ChallengeDice[0], 10, true
+	case SlotChallenge2:
+		return &r.ChallengeDice[1], 10, true
+	}
+	return nil, 0, false
+}
+
+// Reroll rerolls the named dice of an action roll, as some assets
+// allow, and returns the updated result. Each replaced value is added
+// to the result's Rerolls, and the total, outcome and momentum effect
+// are recomputed from the new dice.
+//
+// The rerolled dice carry no proof: on a verifiable roll, ID and Proof
+// still identify the original dice, which Rerolls preserves.
+//
+// It returns an error for an unknown die or a die named twice.
+func (r *Roller) Reroll(res Result, dice ...DieSlot) (Result, error) {
+	for i, slot := range dice {
+		if _, _, ok := res.die(slot); !ok {
+			return Result{}, fmt.Errorf("roll: unknown die %q", slot)
+		}
+		if slices.Contains(dice[:i], slot) {
+			return Result{}, fmt.Errorf("roll: die %q named twice", slot)
+		}
+	}
+
+	// Never share the audit trail with the caller's result.
+	res.Rerolls = slices.Clone(res.Rerolls)
+
+	r.mu.Lock()
+	src, _ := r.source(KindReroll)
+	for _, slot := range dice {
+		v, sides, _ := res.die(slot)
+		rr := Reroll{Die: slot, Original: *v, Replacement: src.Intn(sides) + 1}
+		*v = rr.Replacement
+		res.Rerolls = append(res.Rerolls, rr)
+	}
+	r.mu.Unlock()
+
+	return Rescore(res), nil
+}
+
+// Rescore recomputes the total, outcome and momentum effect of an
+// action roll from its dice, e.g. for a roll restored from a record
+// of its dice. The roll ID is kept.
+//
+// This function contains no randomness and no side effects.
+func Rescore(r Result) Result {
+	r.Total = r.ActionDie + r.Modifier
+	r.Outcome = determineOutcome(r.Total, r.ChallengeDice)
+	if r.Momentum != nil {
+		r = applyMomentum(r, r.Momentum.Value)
+	}
+	return r
+}
+
+// slotCodes are the one-letter codes of the dice in EncodeDice.
+var slotCodes = map[DieSlot]string{SlotAction: "a", SlotChallenge1: "1", SlotChallenge2: "2"}
+
+// EncodeDice encodes the dice, modifier, momentum and rerolls of an
+// action roll in a compact form short enough for the data of a chat
+// button, such as "4,2,3,7" or "4,2,3,7,m5|a1>4". DecodeDice restores
+// the roll. The roll ID and proof are not included.
+func EncodeDice(r Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d,%d,%d,%d", r.ActionDie, r.Modifier, r.ChallengeDice[0], r.ChallengeDice[1])
+	if r.Momentum != nil {
+		fmt.Fprintf(&b, ",m%d", r.Momentum.Value)
+	}
+	for _, rr := range r.Rerolls {
+		fmt.Fprintf(&b, "|%s%d>%d", slotCodes[rr.Die], rr.Original, rr.Replacement)
+	}
+	return b.String()
+}
+
+// DecodeDice restores an action roll encoded by EncodeDice,
+// recomputing its total, outcome and momentum effect.
+func DecodeDice(s string) (Result, error) {
+	errInvalid := errors.New("roll: invalid encoded dice")
+
+	fields := strings.Split(s, "|")
+	parts := strings.Split(fields[0], ",")
+	if len(parts) != 4 && len(parts) != 5 {
+		return Result{}, errInvalid
+	}
+
+	var r Result
+	for i, p := range []*int{&r.ActionDie, &r.Modifier, &r.ChallengeDice[0], &r.ChallengeDice[1]} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return Result{}, errInvalid
+		}
+		*p = n
+	}
+	if !validDie(r.ActionDie, 6) || !validDie(r.ChallengeDice[0], 10) || !validDie(r.ChallengeDice[1], 10) {
+		return Result{}, errInvalid
+	}
+	if len(parts) == 5 {
+		raw, ok := strings.CutPrefix(parts[4], "m")
+		m, err := strconv.Atoi(raw)
+		if !ok || err != nil || m < MinMomentum || m > MaxMomentum {
+			return Result{}, errInvalid
+		}
+		r.Momentum = &MomentumEffect{Value: m}
+	}
+
+	for _, f := range fields[1:] {
+		rr, ok := decodeReroll(f)
+		if !ok {
+			return Result{}, errInvalid
+		}
+		r.Rerolls = append(r.Rerolls, rr)
+	}
+	return Rescore(r), nil
+}
+
+// EncodeProgress encodes the score and challenge dice of a progress
+// roll in the compact form of EncodeDice, such as "7,3,9".
+// DecodeProgress restores the roll. The roll ID and proof are not
+// included.
+func EncodeProgress(r ProgressResult) string {
+	return fmt.Sprintf("%d,%d,%d", r.ProgressScore, r.ChallengeDice[0], r.ChallengeDice[1])
+}
+
+// DecodeProgress restores a progress roll encoded by EncodeProgress,
+// recomputing its outcome.
+func DecodeProgress(s string) (ProgressResult, error) {
+	errInvalid := errors.New("roll: invalid encoded progress roll")
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return ProgressResult{}, errInvalid
+	}
+	var r ProgressResult
+	for i, p := range []*int{&r.ProgressScore, &r.ChallengeDice[0], &r.ChallengeDice[1]} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return ProgressResult{}, errInvalid
+		}
+		*p = n
+	}
+	if clampProgress(r.ProgressScore) != r.ProgressScore || !validDie(r.ChallengeDice[0], 10) || !validDie(r.ChallengeDice[1], 10) {
+		return ProgressResult{}, errInvalid
+	}
+	r.Outcome = determineOutcome(r.ProgressScore, r.ChallengeDice)
+	return r, nil
+}
+
+// decodeReroll decodes one audit trail entry such as "a1>4".
+func decodeReroll(s string) (Reroll, bool) {
+	if s == "" {
+		return Reroll{}, false
+	}
+	var rr Reroll
+	for slot, code := range slotCodes {
+		if code == s[:1] {
+			rr.Die = slot
+		}
+	}
+	before, after, ok := strings.Cut(s[1:], ">")
+	if rr.Die == "" || !ok {
+		return Reroll{}, false
+	}
+
+	var err1, err2 error
+	rr.Original, err1 = strconv.Atoi(before)
+	rr.Replacement, err2 = strconv.Atoi(after)
+	if err1 != nil || err2 != nil {
+		return Reroll{}, false
+	}
+	sides := 10
+	if rr.Die == SlotAction {
+		sides = 6
+	}
+	return rr, validDie(rr.Original, sides) && validDie(rr.Replacement, sides)
+}
+
+// validDie reports whether v is a face of a die with the given sides.
+func validDie(v, sides int) bool {
+	return v >= 1 && v <= sides
+}
@@ -0,0 +1,118 @@
+package roll
+
+import (
+	"reflect"
+	"testing"
+)
+
+// faces is a Source returning the given die faces in order.
+type faces []int
+
+func (f *faces) Intn(n int) int {
+	v := (*f)[0]
+	*f = (*f)[1:]
+	return v - 1
+}
+
+func TestReroll(t *testing.T) {
+	f := faces{2, 5, 5, 6, 1}
+	r := NewRoller(&f)
+
+	// Action 2+1 against 5 and 5: a Miss with a Match.
+	res := r.Roll(1)
+	if res.Outcome != CriticalFailure {
+		t.Fatalf("Roll = %+v, want a critical failure", res)
+	}
+
+	res, err := r.Reroll(res, SlotAction, SlotChallenge2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Reroll{{SlotAction, 2, 6}, {SlotChallenge2, 5, 1}}
+	if res.ActionDie != 6 || res.ChallengeDice != [2]int{5, 1} || res.Total != 7 || res.Outcome != Success {
+		t.Fatalf("Reroll = %+v, want 6+1 against 5 and 1", res)
+	}
+	if !reflect.DeepEqual(res.Rerolls, want) {
+		t.Fatalf("Rerolls = %+v, want %+v", res.Rerolls, want)
+	}
+	if !res.Rerolled(SlotAction) || res.Rerolled(SlotChallenge1) {
+		t.Fatalf("Rerolled reports the wrong dice: %+v", res.Rerolls)
+	}
+
+	if _, err := r.Reroll(res, "burn"); err == nil {
+		t.Fatal("Reroll accepted an unknown die")
+	}
+	if _, err := r.Reroll(res, SlotAction, SlotAction); err == nil {
+		t.Fatal("Reroll accepted a die named twice")
+	}
+}
+
+func TestRerollKeepsMomentum(t *testing.T) {
+	f := faces{3, 4, 8, 3}
+	r := NewRoller(&f)
+
+	// Momentum -3 cancels the action die of 3.
+	res := r.RollWithMomentum(2, -3)
+	if !res.Momentum.Cancelled || res.Total != 2 {
+		t.Fatalf("RollWithMomentum = %+v, want a cancelled action die", res)
+	}
+
+	// Rerolling into another 3 cancels it again.
+	res, err := r.Reroll(res, SlotAction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Momentum.Cancelled || res.Total != 2 || res.Outcome != Failure {
+		t.Fatalf("Reroll = %+v, want the action die cancelled again", res)
+	}
+}
+
+func TestEncodeDice(t *testing.T) {
+	results := []Result{
+		Rescore(Result{ActionDie: 4, Modifier: 2, ChallengeDice: [2]int{3, 7}}),
+		Rescore(Result{ActionDie: 1, Modifier: -1, ChallengeDice: [2]int{10, 10}, Momentum: &MomentumEffect{Value: -6}}),
+		Rescore(Result{
+			ActionDie: 6, Modifier: 3, ChallengeDice: [2]int{2, 9},
+			Momentum: &MomentumEffect{Value: 10},
+			Rerolls:  []Reroll{{SlotAction, 1, 6}, {SlotChallenge1, 10, 2}},
+		}),
+	}
+
+	for _, want := range results {
+		s := EncodeDice(want)
+		got, err := DecodeDice(s)
+		if err != nil {
+			t.Fatalf("DecodeDice(%q): %v", s, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("DecodeDice(%q) = %+v, want %+v", s, got, want)
+		}
+	}
+
+	if s := EncodeDice(results[2]); s != "6,3,2,9,m10|a1>6|110>2" {
+		t.Fatalf("EncodeDice = %q", s)
+	}
+
+	for _, s := range []string{"", "4,2,3", "7,2,3,7", "4,2,3,11", "4,x,3,7", "4,2,3,7,5", "4,2,3,7,m11", "4,2,3,7|a1", "4,2,3,7|c1>2", "4,2,3,7|a7>1"} {
+		if _, err := DecodeDice(s); err == nil {
+			t.Fatalf("DecodeDice(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestEncodeProgress(t *testing.T) {
+	want := ProgressResult{ProgressScore: 7, ChallengeDice: [2]int{3, 9}, Outcome: PartialSuccess}
+	s := EncodeProgress(want)
+	if s != "7,3,9" {
+		t.Fatalf("EncodeProgress = %q", s)
+	}
+	if got, err := DecodeProgress(s); err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("DecodeProgress(%q) = %+v, %v; want %+v", s, got, err, want)
+	}
+
+	for _, s := range []string{"", "7,3", "11,3,9", "-1,3,9", "7,0,9", "7,3,x", "7,3,9,1"} {
+		if _, err := DecodeProgress(s); err == nil {
+			t.Fatalf("DecodeProgress(%q) succeeded, want an error", s)
+		}
+	}
+}
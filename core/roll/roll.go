@@ -1,32 +1,115 @@
 package roll
 
-import "math/rand"
+import (
+	"errors"
+	"math/rand"
+	"sync"
+)
 
-// Package-level `intn` function is used for randomness and can be
-// replaced in tests via `SetRand`/`ResetRand` to make behavior
-// deterministic. By default it delegates to the global `rand.Intn`.
-var intn = rand.Intn
+// Source is the randomness used by a Roller.
+//
+// Intn must return a uniformly distributed integer in [0, n).
+// *rand.Rand satisfies this interface.
+type Source interface {
+	Intn(n int) int
+}
+
+// Roller performs Ironsworn rolls using an injected Source.
+//
+// A Roller is safe for concurrent use by multiple goroutines, even when
+// its Source is not (such as *rand.Rand). All dice of a single roll are
+// drawn while holding the lock, so a roll always consumes a contiguous
+// run of the source and a seeded Roller is fully reproducible.
+type Roller struct {
+	mu     sync.Mutex
+	src    Source
+	prover Prover
+}
+
+// NewRoller returns a Roller that draws dice from src.
+func NewRoller(src Source) *Roller {
+	return &Roller{src: src}
+}
+
+// Roll kinds passed to Prover.Next. A verifier needs the kind to know
+// which dice (and in which order) were drawn from the roll's Source.
+const (
+	KindAction   = "action"
+	KindProgress = "progress"
+	KindDie      = "die"    // a single Die call; carries no proof
+	KindReroll   = "reroll" // a Reroll call; carries no proof
+)
+
+// Prover supplies per-roll randomness for verifiable rolls.
+type Prover interface {
+	// Next returns a Source dedicated to a single roll of the given
+	// kind, and the proof that lets the roll be recomputed later.
+	Next(kind string) (Source, Proof)
+}
+
+// NewVerifiableRoller returns a Roller whose every roll draws its dice
+// from a fresh Prover source and carries the matching ID and Proof.
+func NewVerifiableRoller(p Prover) *Roller {
+	return &Roller{prover: p}
+}
+
+// source returns the Source for the next roll and, for verifiable
+// rollers, its proof. The caller must hold r.mu.
+func (r *Roller) source(kind string) (Source, *Proof) {
+	if r.prover == nil {
+		return r.src, nil
+	}
+
+	src, proof := r.prover.Next(kind)
+	return src, &proof
+}
+
+// stamp returns the ID recorded on a roll with the given proof.
+func stamp(proof *Proof) string {
+	if proof == nil {
+		return ""
+	}
+	return proof.Nonce
+}
 
-// SetRand replaces the package RNG with the provided *rand.Rand.
-// This makes `Roll` deterministic when the same seed is used.
-func SetRand(r *rand.Rand) {
-	intn = r.Intn
+// globalSource delegates to the goroutine-safe top-level math/rand
+// functions. It is the source of the default Roller.
+type globalSource struct{}
+
+func (globalSource) Intn(n int) int { return rand.Intn(n) }
+
+// defaultRoller backs the package-level Roll functions.
+var defaultRoller = NewRoller(globalSource{})
+
+// Default returns the Roller used by the package-level Roll functions.
+func Default() *Roller {
+	return defaultRoller
+}
+
+// Die rolls a single die with the given number of sides
+// using the default Roller.
+func Die(sides int) int {
+	return defaultRoller.Die(sides)
 }
 
-// ResetRand restores the default global RNG behavior.
-func ResetRand() {
-	intn = rand.Intn
+// Roll performs a single Ironsworn roll with the default Roller.
+func Roll(modifier int) Result {
+	return defaultRoller.Roll(modifier)
+}
+
+// RollWithMomentum performs a momentum-aware roll with the default Roller.
+func RollWithMomentum(modifier, momentum int) Result {
+	return defaultRoller.RollWithMomentum(modifier, momentum)
 }
 
-// Seed is a convenience that sets the RNG to a new source derived
-// from the provided seed.
-func Seed(seed int64) {
-	SetRand(rand.New(rand.NewSource(seed)))
+// ProgressRoll performs a progress roll with the default Roller.
+func ProgressRoll(score int) ProgressResult {
+	return defaultRoller.ProgressRoll(score)
 }
 
 // Roll performs a single Ironsworn roll with the given modifier.
 //
-// This function is pure from the caller's perspective:
+// This method is pure from the caller's perspective:
 //   - it does not panic
 //   - it does not log
 //   - it does not allocate unnecessary resources
@@ -34,14 +117,19 @@ func Seed(seed int64) {
 //
 // The returned Result contains the full dice breakdown
 // and the final outcome category.
-func Roll(modifier int) Result {
+func (r *Roller) Roll(modifier int) Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	src, proof := r.source(KindAction)
+
 	// Roll the action die (1d6).
-	actionDie := intn(6) + 1
+	actionDie := src.Intn(6) + 1
 
 	// Roll the two challenge dice (1d10 each).
 	challenge := [2]int{
-		intn(10) + 1,
-		intn(10) + 1,
+		src.Intn(10) + 1,
+		src.Intn(10) + 1,
 	}
 
 	// Calculate the action score.
@@ -56,5 +144,150 @@ func Roll(modifier int) Result {
 		ChallengeDice: challenge,
 		Total:         total,
 		Outcome:       outcome,
+		ID:            stamp(proof),
+		Proof:         proof,
+	}
+}
+
+// Die rolls a single die with the given number of sides and returns
+// a value in [1, sides]. It is the building block for rolls outside
+// the action roll, such as d100 oracle lookups.
+//
+// Die results carry no proof, even on a verifiable Roller.
+// It panics if sides <= 0.
+func (r *Roller) Die(sides int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	src, _ := r.source(KindDie)
+	return src.Intn(sides) + 1
+}
+
+// RollWithMomentum performs a single Ironsworn roll for a character
+// with the given current momentum.
+//
+// Momentum is clamped to the -6..+10 range. The returned Result carries
+// a MomentumEffect describing whether negative momentum cancelled the
+// action die and whether burning momentum would improve the outcome.
+func (r *Roller) RollWithMomentum(modifier, momentum int) Result {
+	return applyMomentum(r.Roll(modifier), momentum)
+}
+
+// applyMomentum applies the momentum rules to an already rolled action roll.
+//
+// This function contains no randomness and no side effects.
+func applyMomentum(r Result, momentum int) Result {
+	momentum = clampMomentum(momentum)
+	effect := &MomentumEffect{Value: momentum}
+
+	// Negative momentum: when the action die equals |momentum|,
+	// the action die is cancelled and the action score is the
+	// modifier alone.
+	if momentum < 0 && r.ActionDie == -momentum {
+		effect.Cancelled = true
+		r.Total = r.Modifier
+		r.Outcome = determineOutcome(r.Total, r.ChallengeDice)
+	}
+
+	// Burning momentum replaces the action score with the momentum
+	// value. It is only worth offering when it beats more challenge
+	// dice than the current action score does.
+	if momentum > 0 && countWins(momentum, r.ChallengeDice) > countWins(r.Total, r.ChallengeDice) {
+		effect.CanBurn = true
+		effect.BurnOutcome = determineOutcome(momentum, r.ChallengeDice)
+	}
+
+	r.Momentum = effect
+	return r
+}
+
+// ErrCannotBurn is returned by Burn for a roll that burning momentum
+// would not improve.
+var ErrCannotBurn = errors.New("roll: burning momentum would not improve the roll")
+
+// Burn burns momentum on an action roll whose MomentumEffect reports
+// CanBurn: the momentum value replaces the action score and the
+// outcome is recomputed. Resetting the character's momentum afterwards
+// is left to the caller.
+//
+// This function contains no randomness and no side effects.
+func Burn(r Result) (Result, error) {
+	if r.Momentum == nil || !r.Momentum.CanBurn {
+		return r, ErrCannotBurn
+	}
+
+	effect := *r.Momentum
+	effect.CanBurn = false
+	effect.BurnOutcome = ""
+	effect.Burned = true
+
+	r.Total = effect.Value
+	r.Outcome = determineOutcome(r.Total, r.ChallengeDice)
+	r.Momentum = &effect
+	return r, nil
+}
+
+// Momentum bounds as defined by the Ironsworn rules.
+const (
+	MinMomentum = -6
+	MaxMomentum = 10
+)
+
+// clampMomentum limits a momentum value to the valid -6..+10 range.
+func clampMomentum(momentum int) int {
+	if momentum < MinMomentum {
+		return MinMomentum
+	}
+	if momentum > MaxMomentum {
+		return MaxMomentum
+	}
+	return momentum
+}
+
+// Progress score bounds. A progress track has ten boxes,
+// so the progress score is always between 0 and 10.
+const (
+	MinProgressScore = 0
+	MaxProgressScore = 10
+)
+
+// ProgressRoll performs a single Ironsworn progress roll.
+//
+// The progress score replaces the action score entirely: no action die
+// is rolled and no modifier applies. Scores outside 0–10 are clamped
+// to that range, so this method never panics on bad input.
+//
+// Momentum never affects a progress roll.
+func (r *Roller) ProgressRoll(score int) ProgressResult {
+	score = clampProgress(score)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	src, proof := r.source(KindProgress)
+
+	// Roll the two challenge dice (1d10 each).
+	challenge := [2]int{
+		src.Intn(10) + 1,
+		src.Intn(10) + 1,
+	}
+
+	return ProgressResult{
+		ProgressScore: score,
+		ChallengeDice: challenge,
+		Outcome:       determineOutcome(score, challenge),
+		ID:            stamp(proof),
+		Proof:         proof,
+	}
+}
+
+// clampProgress limits a progress score to the valid 0–10 range.
+func clampProgress(score int) int {
+	if score < MinProgressScore {
+		return MinProgressScore
+	}
+	if score > MaxProgressScore {
+		return MaxProgressScore
 	}
+	return score
 }
@@ -31,14 +31,17 @@ func TestDetermineOutcomeTable(t *testing.T) {
 	}
 }
 
+// seeded returns a deterministic Roller for tests.
+func seeded(seed int64) *Roller {
+	return NewRoller(rand.New(rand.NewSource(seed)))
+}
+
 func TestRollProducesConsistentResult(t *testing.T) {
-	// Use the package-level controllable RNG to make this deterministic.
-	rsrc := rand.New(rand.NewSource(42))
-	SetRand(rsrc)
-	defer ResetRand()
+	// Use a seeded Roller to make this deterministic.
+	roller := seeded(42)
 
 	modifier := 2
-	r := Roll(modifier)
+	r := roller.Roll(modifier)
 
 	if r.Total != r.ActionDie+r.Modifier {
 		t.Fatalf("Total mismatch: got %d, want %d", r.Total, r.ActionDie+r.Modifier)
@@ -62,25 +65,163 @@ func TestRollProducesConsistentResult(t *testing.T) {
 }
 
 func TestRollDeterministicRepeatable(t *testing.T) {
-	// Create two RNGs with the same seed and ensure sequences match
-	r1 := rand.New(rand.NewSource(12345))
-	r2 := rand.New(rand.NewSource(12345))
-
-	SetRand(r1)
-	defer ResetRand()
+	// Create two Rollers with the same seed and ensure sequences match
+	r1 := seeded(12345)
+	r2 := seeded(12345)
 
 	var seq1 []Result
 	for i := 0; i < 20; i++ {
-		seq1 = append(seq1, Roll(i%4-2)) // some varying modifiers
+		seq1 = append(seq1, r1.Roll(i%4-2)) // some varying modifiers
 	}
 
-	SetRand(r2)
 	var seq2 []Result
 	for i := 0; i < 20; i++ {
-		seq2 = append(seq2, Roll(i%4-2))
+		seq2 = append(seq2, r2.Roll(i%4-2))
 	}
 
 	if !reflect.DeepEqual(seq1, seq2) {
 		t.Fatalf("deterministic sequences do not match")
 	}
 }
+
+func TestProgressRollUsesScoreAsActionScore(t *testing.T) {
+	roller := seeded(99)
+
+	for score := 0; score <= 10; score++ {
+		r := roller.ProgressRoll(score)
+
+		if r.ProgressScore != score {
+			t.Fatalf("ProgressScore mismatch: got %d, want %d", r.ProgressScore, score)
+		}
+		for i, c := range r.ChallengeDice {
+			if c < 1 || c > 10 {
+				t.Fatalf("ChallengeDice[%d] out of range: %d", i, c)
+			}
+		}
+		if want := determineOutcome(score, r.ChallengeDice); r.Outcome != want {
+			t.Fatalf("Outcome mismatch for score %d: got %q, want %q", score, r.Outcome, want)
+		}
+	}
+}
+
+func TestProgressRollClampsScore(t *testing.T) {
+	cases := map[int]int{
+		-3: 0,
+		0:  0,
+		10: 10,
+		14: 10,
+	}
+
+	for in, want := range cases {
+		if got := ProgressRoll(in).ProgressScore; got != want {
+			t.Fatalf("ProgressRoll(%d).ProgressScore = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestApplyMomentum(t *testing.T) {
+	cases := []struct {
+		name        string
+		roll        Result
+		momentum    int
+		wantTotal   int
+		wantOutcome Outcome
+		cancelled   bool
+		canBurn     bool
+		burnOutcome Outcome
+	}{
+		{
+			name:        "BurnUpgradesMissToStrongHit",
+			roll:        Result{ActionDie: 2, Modifier: 1, ChallengeDice: [2]int{6, 7}, Total: 3, Outcome: Failure},
+			momentum:    8,
+			wantTotal:   3,
+			wantOutcome: Failure,
+			canBurn:     true,
+			burnOutcome: Success,
+		},
+		{
+			name:        "BurnKeepsMatch",
+			roll:        Result{ActionDie: 5, Modifier: 2, ChallengeDice: [2]int{7, 7}, Total: 7, Outcome: CriticalFailure},
+			momentum:    9,
+			wantTotal:   7,
+			wantOutcome: CriticalFailure,
+			canBurn:     true,
+			burnOutcome: CriticalSuccess,
+		},
+		{
+			name:        "NoBurnWhenNotBetter",
+			roll:        Result{ActionDie: 6, Modifier: 2, ChallengeDice: [2]int{3, 9}, Total: 8, Outcome: PartialSuccess},
+			momentum:    5,
+			wantTotal:   8,
+			wantOutcome: PartialSuccess,
+		},
+		{
+			name:        "NegativeMomentumCancelsActionDie",
+			roll:        Result{ActionDie: 3, Modifier: 2, ChallengeDice: [2]int{2, 4}, Total: 5, Outcome: Success},
+			momentum:    -3,
+			wantTotal:   2,
+			wantOutcome: Failure,
+			cancelled:   true,
+		},
+		{
+			name:        "NegativeMomentumOtherDieUntouched",
+			roll:        Result{ActionDie: 4, Modifier: 2, ChallengeDice: [2]int{2, 4}, Total: 6, Outcome: Success},
+			momentum:    -3,
+			wantTotal:   6,
+			wantOutcome: Success,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := applyMomentum(c.roll, c.momentum)
+			m := got.Momentum
+			if m == nil {
+				t.Fatal("Momentum effect missing")
+			}
+			if got.Total != c.wantTotal || got.Outcome != c.wantOutcome {
+				t.Fatalf("got total %d outcome %q, want %d %q", got.Total, got.Outcome, c.wantTotal, c.wantOutcome)
+			}
+			if m.Cancelled != c.cancelled || m.CanBurn != c.canBurn || m.BurnOutcome != c.burnOutcome {
+				t.Fatalf("got effect %+v, want cancelled=%v canBurn=%v burn=%q", *m, c.cancelled, c.canBurn, c.burnOutcome)
+			}
+		})
+	}
+}
+
+func TestBurn(t *testing.T) {
+	r := applyMomentum(Result{ActionDie: 2, Modifier: 1, ChallengeDice: [2]int{6, 7}, Total: 3, Outcome: Failure}, 8)
+
+	burned, err := Burn(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if burned.Total != 8 || burned.Outcome != Success {
+		t.Fatalf("Burn = total %d outcome %q, want 8 %q", burned.Total, burned.Outcome, Success)
+	}
+	if m := burned.Momentum; !m.Burned || m.CanBurn || m.Value != 8 {
+		t.Fatalf("Burn effect = %+v", *m)
+	}
+	if r.Momentum.Burned || r.Total != 3 {
+		t.Fatal("Burn modified the original roll")
+	}
+
+	// Momentum can be burned only when it helps, and only once.
+	for _, r := range []Result{burned, Roll(2), applyMomentum(Result{ActionDie: 6, Modifier: 2, ChallengeDice: [2]int{3, 9}, Total: 8}, 5)} {
+		if _, err := Burn(r); err != ErrCannotBurn {
+			t.Fatalf("Burn(%+v) error = %v, want ErrCannotBurn", r, err)
+		}
+	}
+}
+
+func TestRollWithMomentumClampsValue(t *testing.T) {
+	if got := RollWithMomentum(0, 15).Momentum.Value; got != MaxMomentum {
+		t.Fatalf("momentum not clamped to max: got %d", got)
+	}
+	if got := RollWithMomentum(0, -9).Momentum.Value; got != MinMomentum {
+		t.Fatalf("momentum not clamped to min: got %d", got)
+	}
+	if Roll(0).Momentum != nil {
+		t.Fatal("Roll without momentum must not carry a momentum effect")
+	}
+}
@@ -2,24 +2,55 @@ package roll
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 )
 
-func TestSeedProducesRepeatableSequences(t *testing.T) {
-	// Seed twice with same value and ensure sequences repeat
-	Seed(2024)
-	a := Roll(0)
-	b := Roll(1)
+func TestSeededRollersProduceRepeatableSequences(t *testing.T) {
+	// Two Rollers with the same seed must produce the same sequence
+	a := seeded(2024)
+	b := seeded(2024)
 
-	Seed(2024)
-	a2 := Roll(0)
-	b2 := Roll(1)
-
-	if !reflect.DeepEqual(a, a2) || !reflect.DeepEqual(b, b2) {
-		t.Fatalf("Seed did not produce repeatable sequences: got %v,%v vs %v,%v", a, b, a2, b2)
+	if x, y := a.Roll(0), b.Roll(0); !reflect.DeepEqual(x, y) {
+		t.Fatalf("same seed did not produce repeatable rolls: %v vs %v", x, y)
+	}
+	if x, y := a.ProgressRoll(5), b.ProgressRoll(5); !reflect.DeepEqual(x, y) {
+		t.Fatalf("same seed did not produce repeatable progress rolls: %v vs %v", x, y)
 	}
 
-	// Reset to default RNG and ensure calls still succeed
-	ResetRand()
+	// The default Roller must still work
 	_ = Roll(0)
 }
+
+func TestRollerConcurrentUse(t *testing.T) {
+	// A single seeded Roller shared by many goroutines must stay
+	// consistent; run with -race to verify there is no data race.
+	roller := seeded(7)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				r := roller.Roll(1)
+				if r.ActionDie < 1 || r.ActionDie > 6 {
+					t.Errorf("ActionDie out of range: %d", r.ActionDie)
+					return
+				}
+				_ = roller.ProgressRoll(i % 11)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDieRange(t *testing.T) {
+	roller := seeded(1)
+
+	for i := 0; i < 500; i++ {
+		if d := roller.Die(100); d < 1 || d > 100 {
+			t.Fatalf("Die(100) out of range: %d", d)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package roll
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// readerSource turns a stream of uniformly random bytes into
+// uniformly distributed integers using rejection sampling.
+//
+// Taking a random byte modulo n would favour the low values whenever
+// n does not divide 256 (for a d6: 256 = 42*6 + 4, so faces 1–4 would
+// come up slightly more often). Rejection sampling discards the
+// incomplete top range instead, so every face is exactly equally likely.
+type readerSource struct {
+	r io.Reader
+}
+
+// NewReaderSource returns a Source that draws from a stream of random
+// bytes. The reader must never fail; a read error panics, since a dice
+// source has no way to report it.
+func NewReaderSource(r io.Reader) Source {
+	return readerSource{r: r}
+}
+
+// CryptoSource returns a Source backed directly by crypto/rand.
+//
+// Rolls from this source cannot be predicted from earlier rolls or
+// from any seed, at the cost of being impossible to replay.
+// It is safe for concurrent use.
+func CryptoSource() Source {
+	return NewReaderSource(cryptorand.Reader)
+}
+
+// Intn returns a uniformly distributed integer in [0, n).
+// It panics if n <= 0, like math/rand.
+func (s readerSource) Intn(n int) int {
+	if n <= 0 {
+		panic("roll: invalid argument to Intn")
+	}
+
+	// Dice never have more than 256 faces in practice, so a single
+	// byte per attempt is enough and keeps rejection rare.
+	if n <= 1<<8 {
+		limit := 1<<8 - (1<<8)%n
+		var b [1]byte
+		for {
+			s.read(b[:])
+			if v := int(b[0]); v < limit {
+				return v % n
+			}
+		}
+	}
+
+	limit := uint64(1<<32) - uint64(1<<32)%uint64(n)
+	var b [4]byte
+	for {
+		s.read(b[:])
+		if v := uint64(binary.BigEndian.Uint32(b[:])); v < limit {
+			return int(v % uint64(n))
+		}
+	}
+}
+
+func (s readerSource) read(b []byte) {
+	if _, err := io.ReadFull(s.r, b); err != nil {
+		panic("roll: random source failed: " + err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package roll
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReaderSourceRejectsBiasedBytes(t *testing.T) {
+	// For a d6 the top 4 byte values (252–255) must be rejected.
+	src := NewReaderSource(bytes.NewReader([]byte{255, 252, 7}))
+
+	if got := src.Intn(6); got != 1 {
+		t.Fatalf("Intn(6) = %d, want 1 (7 %% 6 after two rejections)", got)
+	}
+}
+
+func TestReaderSourceLargeRange(t *testing.T) {
+	src := NewReaderSource(bytes.NewReader([]byte{0, 0, 1, 44}))
+
+	if got := src.Intn(1000); got != 300 {
+		t.Fatalf("Intn(1000) = %d, want 300", got)
+	}
+}
+
+func TestCryptoSourceCoversAllFaces(t *testing.T) {
+	roller := NewRoller(CryptoSource())
+
+	seen := map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		r := roller.Roll(0)
+		if r.ActionDie < 1 || r.ActionDie > 6 {
+			t.Fatalf("ActionDie out of range: %d", r.ActionDie)
+		}
+		seen[r.ActionDie] = true
+	}
+	if len(seen) != 6 {
+		t.Fatalf("expected all six action die faces, got %v", seen)
+	}
+}
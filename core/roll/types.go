@@ -25,6 +25,55 @@ type Result struct {
 	ActionDie     int     // Result of the 1d6 action die
 	Modifier      int     // Applied modifier (Z)
 	ChallengeDice [2]int  // Results of the two 1d10 challenge dice
-	Total         int     // ActionDie + Modifier
+	Total         int     // ActionDie + Modifier (Modifier alone if the action die was cancelled)
 	Outcome       Outcome // Final outcome category
+
+	// Momentum describes how the character's momentum affects this roll.
+	// It is nil when the roll was made without a momentum value.
+	Momentum *MomentumEffect
+
+	// Rerolls is the audit trail of dice rerolled after the roll,
+	// oldest first (see Roller.Reroll). It is empty for a fresh roll.
+	Rerolls []Reroll
+
+	// ID and Proof identify a verifiable roll (see Prover).
+	// They are empty for rolls made by an ordinary Roller.
+	ID    string
+	Proof *Proof
+}
+
+// MomentumEffect is the momentum breakdown attached to an action roll.
+//
+// It only reports what momentum does or could do; burning momentum is
+// always the player's choice and is never applied automatically
+// (see Burn).
+type MomentumEffect struct {
+	Value       int     // Current momentum (-6 to +10)
+	Cancelled   bool    // Action die was cancelled by negative momentum
+	CanBurn     bool    // Burning momentum would improve the outcome
+	BurnOutcome Outcome // Outcome after burning momentum (only set when CanBurn)
+	Burned      bool    // Momentum was burned and replaced the action score
+}
+
+// ProgressResult is the complete, explicit outcome of a single progress roll.
+//
+// A progress roll has no action die and no modifier: the progress score
+// itself (0–10) is compared against the two challenge dice using the
+// same rules as an action roll, including match detection.
+type ProgressResult struct {
+	ProgressScore int     // Progress score (0–10) used as the action score
+	ChallengeDice [2]int  // Results of the two 1d10 challenge dice
+	Outcome       Outcome // Final outcome category
+
+	// ID and Proof identify a verifiable roll (see Prover).
+	// They are empty for rolls made by an ordinary Roller.
+	ID    string
+	Proof *Proof
+}
+
+// Proof is the public material that lets anyone recompute
+// the dice of a verifiable roll once the server secret is revealed.
+type Proof struct {
+	Commitment string // Published hash of the server secret used for the roll
+	Nonce      string // Per-roll nonce mixed with the secret; doubles as the roll ID
 }
@@ -0,0 +1,21 @@
+// Package ruleset selects the game a roll is played under: classic
+// Ironsworn, Ironsworn: Delve or Ironsworn: Starforged.
+//
+// All three share the action and progress rolls of core/roll. They
+// differ in what things are called and in their content:
+//
+//   - Terms names the outcomes of a roll, e.g. "Miss (Match)" in
+//     classic Ironsworn and "Miss with a Match" in Starforged.
+//   - Moves and Oracles hold the ruleset's moves and oracle tables.
+//     Delve is an expansion and extends classic Ironsworn through Base.
+//   - ProgressMoves names the move rolled against each kind of
+//     progress track, e.g. Starforged's Take Decisive Action in
+//     place of End the Fight.
+//
+// A ruleset ID is also the first segment of the Datasworn IDs of its
+// content ("starforged/moves/combat/strike"), so imported content can
+// be routed to its ruleset with Of.
+//
+// A campaign records its ruleset (see core/campaign); rolls outside a
+// campaign use classic Ironsworn unless the request names another.
+package ruleset
@@ -0,0 +1,147 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// Registry holds the rulesets a bot can play, one of which is the
+// default for rolls that do not name one.
+//
+// A Registry is not changed after construction and is safe for
+// concurrent use.
+type Registry struct {
+	byID  map[ID]*Ruleset
+	order []*Ruleset
+}
+
+// NewRegistry returns a registry holding the given rulesets.
+// The first one is the default.
+func NewRegistry(rulesets ...*Ruleset) *Registry {
+	r := &Registry{byID: make(map[ID]*Ruleset)}
+	for _, rs := range rulesets {
+		if _, ok := r.byID[rs.ID]; ok {
+			continue
+		}
+		r.byID[rs.ID] = rs
+		r.order = append(r.order, rs)
+	}
+	return r
+}
+
+// Builtin returns the built-in rulesets, with classic Ironsworn as the
+// default.
+//
+// Classic plays the given oracles and moves and the built-in classic
+// assets. Delve adds its moves on top of Classic. Starforged has its
+// own moves; none of its oracle tables or assets are built in, so they
+// must be imported from Datasworn.
+func Builtin(oracles *oracle.Registry, moves *move.Catalog) *Registry {
+	classic := &Ruleset{
+		ID:      Classic,
+		Name:    "Ironsworn",
+		Terms:   ClassicTerms,
+		Moves:   moves,
+		Oracles: oracles,
+		Assets:  asset.Builtin(),
+	}
+	delve := &Ruleset{
+		ID:      Delve,
+		Name:    "Ironsworn: Delve",
+		Terms:   ClassicTerms,
+		Moves:   move.BuiltinDelve(),
+		Oracles: emptyOracles(),
+		Assets:  emptyAssets(),
+		Base:    classic,
+	}
+	starforged := &Ruleset{
+		ID:      Starforged,
+		Name:    "Ironsworn: Starforged",
+		Terms:   StarforgedTerms,
+		Moves:   move.BuiltinStarforged(),
+		Oracles: emptyOracles(),
+		Assets:  emptyAssets(),
+		ProgressMoves: map[track.Kind]string{
+			track.Journey: "finish_an_expedition",
+			track.Combat:  "take_decisive_action",
+		},
+		Legacies: true,
+	}
+	return NewRegistry(classic, delve, starforged)
+}
+
+// emptyOracles returns a registry without tables.
+func emptyOracles() *oracle.Registry {
+	// A registry without tables cannot fail validation.
+	r, _ := oracle.NewRegistry()
+	return r
+}
+
+// emptyAssets returns a catalog without assets.
+func emptyAssets() *asset.Catalog {
+	// A catalog without assets cannot fail validation.
+	c, _ := asset.NewCatalog()
+	return c
+}
+
+// Get returns the ruleset with the given ID.
+func (r *Registry) Get(id ID) (*Ruleset, bool) {
+	rs, ok := r.byID[id]
+	return rs, ok
+}
+
+// Default returns the ruleset used when none is selected.
+func (r *Registry) Default() *Ruleset {
+	return r.order[0]
+}
+
+// Select returns the ruleset with the given ID, or the default ruleset
+// when id is empty or unknown. It suits IDs stored earlier, such as
+// a campaign's, which should not break a roll if the ruleset is gone.
+func (r *Registry) Select(id string) *Ruleset {
+	if rs, ok := r.byID[ID(id)]; ok {
+		return rs
+	}
+	return r.Default()
+}
+
+// Lookup finds a ruleset named in user input such as "Starforged" or
+// "sf" (see Parse), or by its ID.
+func (r *Registry) Lookup(raw string) (*Ruleset, bool) {
+	id, ok := Parse(raw)
+	if !ok {
+		id = ID(strings.ToLower(strings.TrimSpace(raw)))
+	}
+	return r.Get(id)
+}
+
+// Rulesets returns every ruleset, the default first.
+func (r *Registry) Rulesets() []*Ruleset {
+	return append([]*Ruleset(nil), r.order...)
+}
+
+// Move finds a move in any ruleset, trying the default first.
+// It suits looking up the move of a recorded roll by its ID.
+func (r *Registry) Move(name string) (move.Move, bool) {
+	for _, rs := range r.order {
+		if m, ok := rs.Move(name); ok {
+			return m, true
+		}
+	}
+	return move.Move{}, false
+}
+
+// Asset finds an asset in any ruleset, trying the default first.
+// It suits looking up the asset of a character's card by its ID.
+func (r *Registry) Asset(name string) (asset.Asset, bool) {
+	for _, rs := range r.order {
+		if a, ok := rs.Asset(name); ok {
+			return a, true
+		}
+	}
+	return asset.Asset{}, false
+}
@@ -0,0 +1,183 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// ID identifies a ruleset.
+type ID string
+
+const (
+	Classic    ID = "classic"
+	Delve      ID = "delve"
+	Starforged ID = "starforged"
+)
+
+// All lists the built-in rulesets.
+var All = []ID{Classic, Delve, Starforged}
+
+// Parse converts user input such as "Starforged" or "sf" into an ID.
+func Parse(raw string) (ID, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "classic", "ironsworn":
+		return Classic, true
+	case "delve":
+		return Delve, true
+	case "starforged", "sf":
+		return Starforged, true
+	default:
+		return "", false
+	}
+}
+
+// Of returns the built-in ruleset a Datasworn ID belongs to, e.g.
+// Starforged for "starforged/oracles/core/action".
+func Of(dataswornID string) (ID, bool) {
+	prefix, _, _ := strings.Cut(dataswornID, "/")
+	for _, id := range All {
+		if ID(prefix) == id {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Terms names the outcomes of a roll the way a rulebook does.
+type Terms struct {
+	StrongHit      string // Success
+	StrongHitMatch string // Critical Success
+	WeakHit        string // Partial Success
+	Miss           string // Failure
+	MissMatch      string // Critical Failure
+}
+
+// ClassicTerms are the outcome names of classic Ironsworn and Delve.
+var ClassicTerms = Terms{
+	StrongHit:      "Strong Hit",
+	StrongHitMatch: "Strong Hit (Match)",
+	WeakHit:        "Weak Hit",
+	Miss:           "Miss",
+	MissMatch:      "Miss (Match)",
+}
+
+// StarforgedTerms are the outcome names of Starforged, which calls out
+// matches as opportunities and complications.
+var StarforgedTerms = Terms{
+	StrongHit:      "Strong Hit",
+	StrongHitMatch: "Strong Hit with a Match",
+	WeakHit:        "Weak Hit",
+	Miss:           "Miss",
+	MissMatch:      "Miss with a Match",
+}
+
+// Outcome returns the name of a roll outcome.
+func (t Terms) Outcome(o roll.Outcome) string {
+	switch o {
+	case roll.CriticalSuccess:
+		return t.StrongHitMatch
+	case roll.Success:
+		return t.StrongHit
+	case roll.PartialSuccess:
+		return t.WeakHit
+	case roll.Failure:
+		return t.Miss
+	case roll.CriticalFailure:
+		return t.MissMatch
+	default:
+		return string(o)
+	}
+}
+
+// Ruleset is the terminology and content of one game.
+//
+// The Moves, Oracles and Assets catalogs are safe for concurrent use; the other
+// fields must not be changed once the ruleset is in use.
+type Ruleset struct {
+	ID    ID
+	Name  string // Display name, e.g. "Ironsworn: Starforged"
+	Terms Terms
+
+	Moves   *move.Catalog
+	Oracles *oracle.Registry
+	Assets  *asset.Catalog
+
+	// Base is the ruleset this one extends, or nil. Moves, oracle
+	// tables and assets not found in this ruleset are looked up in Base.
+	Base *Ruleset
+
+	// ProgressMoves maps a kind of track to the key of the move rolled
+	// against it, where it differs from track.Kind.ProgressMove.
+	ProgressMoves map[track.Kind]string
+
+	// Legacies is true when experience is earned on legacy tracks, as
+	// in Starforged, rather than directly for fulfilled vows.
+	Legacies bool
+}
+
+// Move finds a move by ID, key or name (see move.Catalog.Get).
+func (r *Ruleset) Move(name string) (move.Move, bool) {
+	if m, ok := r.Moves.Get(name); ok {
+		return m, true
+	}
+	if r.Base != nil {
+		return r.Base.Move(name)
+	}
+	return move.Move{}, false
+}
+
+// MatchMove finds the move named at the start of free text (see
+// move.Catalog.Match). When this ruleset and its base both match,
+// the longer name wins, and this ruleset's move on a tie.
+func (r *Ruleset) MatchMove(text string) (move.Move, string, bool) {
+	m, rest, ok := r.Moves.Match(text)
+	if r.Base == nil {
+		return m, rest, ok
+	}
+
+	bm, brest, bok := r.Base.MatchMove(text)
+	if bok && (!ok || len(strings.Fields(brest)) < len(strings.Fields(rest))) {
+		return bm, brest, true
+	}
+	return m, rest, ok
+}
+
+// Oracle finds an oracle table by ID or key (see oracle.Registry.Get).
+func (r *Ruleset) Oracle(name string) (oracle.Table, bool) {
+	if t, ok := r.Oracles.Get(name); ok {
+		return t, true
+	}
+	if r.Base != nil {
+		return r.Base.Oracle(name)
+	}
+	return oracle.Table{}, false
+}
+
+// Asset finds an asset by ID, key or name (see asset.Catalog.Get).
+func (r *Ruleset) Asset(name string) (asset.Asset, bool) {
+	if a, ok := r.Assets.Get(name); ok {
+		return a, true
+	}
+	if r.Base != nil {
+		return r.Base.Asset(name)
+	}
+	return asset.Asset{}, false
+}
+
+// ProgressMove returns the move rolled against a track of the given
+// kind, reporting false when there is none.
+func (r *Ruleset) ProgressMove(k track.Kind) (move.Move, bool) {
+	key, ok := r.ProgressMoves[k]
+	if !ok {
+		key = k.ProgressMove()
+	}
+	if key == "" {
+		return move.Move{}, false
+	}
+	return r.Move(key)
+}
@@ -0,0 +1,169 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+	"github.com/mtzvd/ironroll/core/roll"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input string
+		want  ID
+		ok    bool
+	}{
+		{"classic", Classic, true},
+		{"Ironsworn", Classic, true},
+		{" delve ", Delve, true},
+		{"Starforged", Starforged, true},
+		{"sf", Starforged, true},
+		{"sundered isles", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := Parse(c.input)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("Parse(%q) = %q, %v; want %q, %v", c.input, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestOf(t *testing.T) {
+	if id, ok := Of("starforged/oracles/core/action"); !ok || id != Starforged {
+		t.Fatalf("Of(starforged/...) = %q, %v", id, ok)
+	}
+	if id, ok := Of("delve/moves/delve/delve_the_depths"); !ok || id != Delve {
+		t.Fatalf("Of(delve/...) = %q, %v", id, ok)
+	}
+	if _, ok := Of("homebrew/moves/x"); ok {
+		t.Error("expected no ruleset for an unknown prefix")
+	}
+}
+
+func TestTerms(t *testing.T) {
+	cases := []struct {
+		terms Terms
+		o     roll.Outcome
+		want  string
+	}{
+		{ClassicTerms, roll.CriticalSuccess, "Strong Hit (Match)"},
+		{ClassicTerms, roll.PartialSuccess, "Weak Hit"},
+		{ClassicTerms, roll.CriticalFailure, "Miss (Match)"},
+		{StarforgedTerms, roll.CriticalFailure, "Miss with a Match"},
+		{StarforgedTerms, roll.Failure, "Miss"},
+	}
+
+	for _, c := range cases {
+		if got := c.terms.Outcome(c.o); got != c.want {
+			t.Fatalf("Outcome(%q) = %q; want %q", c.o, got, c.want)
+		}
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	r := Builtin(oracle.Builtin(), move.Builtin())
+
+	if r.Default().ID != Classic || len(r.Rulesets()) != 3 {
+		t.Fatalf("unexpected rulesets: %v", r.Rulesets())
+	}
+
+	delve, _ := r.Get(Delve)
+	if m, ok := delve.Move("delve the depths"); !ok || m.ID != "delve/moves/delve/delve_the_depths" {
+		t.Fatalf("Delve: delve the depths = %q, %v", m.ID, ok)
+	}
+	if m, ok := delve.Move("face danger"); !ok || m.ID != "classic/moves/adventure/face_danger" {
+		t.Fatalf("Delve should fall back to classic moves, got %q, %v", m.ID, ok)
+	}
+	if _, ok := delve.Oracle("action"); !ok {
+		t.Error("Delve should fall back to classic oracles")
+	}
+
+	sf, _ := r.Get(Starforged)
+	if m, ok := sf.Move("face danger"); !ok || m.ID != "starforged/moves/adventure/face_danger" {
+		t.Fatalf("Starforged: face danger = %q, %v", m.ID, ok)
+	}
+	if _, ok := sf.Move("end the fight"); ok {
+		t.Error("Starforged should not know classic-only moves")
+	}
+	if _, ok := sf.Oracle("action"); ok {
+		t.Error("Starforged has no built-in oracle tables")
+	}
+
+	if _, ok := delve.Asset("hound"); !ok {
+		t.Error("Delve should fall back to classic assets")
+	}
+	if _, ok := sf.Asset("hound"); ok {
+		t.Error("Starforged has no built-in assets")
+	}
+	if a, ok := r.Asset("classic/assets/companion/hound"); !ok || a.Name != "Hound" {
+		t.Fatalf("Registry.Asset = %q, %v", a.Name, ok)
+	}
+}
+
+func TestProgressMove(t *testing.T) {
+	r := Builtin(oracle.Builtin(), move.Builtin())
+
+	cases := []struct {
+		id   ID
+		kind track.Kind
+		want string
+	}{
+		{Classic, track.Combat, "end_the_fight"},
+		{Delve, track.Journey, "reach_your_destination"},
+		{Starforged, track.Combat, "take_decisive_action"},
+		{Starforged, track.Journey, "finish_an_expedition"},
+		{Starforged, track.Vow, "fulfill_your_vow"},
+	}
+
+	for _, c := range cases {
+		rs, _ := r.Get(c.id)
+		m, ok := rs.ProgressMove(c.kind)
+		if !ok || m.Key != c.want {
+			t.Fatalf("%s: ProgressMove(%s) = %q, %v; want %q", c.id, c.kind, m.Key, ok, c.want)
+		}
+	}
+
+	rs, _ := r.Get(Starforged)
+	if _, ok := rs.ProgressMove(track.Other); ok {
+		t.Error("expected no progress move for other tracks")
+	}
+}
+
+func TestMatchMove(t *testing.T) {
+	delve, _ := Builtin(oracle.Builtin(), move.Builtin()).Get(Delve)
+
+	m, rest, ok := delve.MatchMove("check your gear +1")
+	if !ok || m.Key != "check_your_gear" || rest != "+1" {
+		t.Fatalf("MatchMove(check your gear +1) = %q, %q, %v", m.Key, rest, ok)
+	}
+	m, rest, ok = delve.MatchMove("strike iron +2")
+	if !ok || m.ID != "classic/moves/combat/strike" || rest != "iron +2" {
+		t.Fatalf("MatchMove(strike iron +2) = %q, %q, %v", m.ID, rest, ok)
+	}
+	if _, _, ok := delve.MatchMove("+2"); ok {
+		t.Error("expected no move in +2")
+	}
+}
+
+func TestRegistrySelect(t *testing.T) {
+	r := Builtin(oracle.Builtin(), move.Builtin())
+
+	if got := r.Select("starforged").ID; got != Starforged {
+		t.Fatalf("Select(starforged) = %q", got)
+	}
+	if got := r.Select("").ID; got != Classic {
+		t.Fatalf("Select(\"\") = %q", got)
+	}
+	if got := r.Select("gone").ID; got != Classic {
+		t.Fatalf("Select(gone) = %q", got)
+	}
+	if rs, ok := r.Lookup("SF"); !ok || rs.ID != Starforged {
+		t.Fatalf("Lookup(SF) failed")
+	}
+	if m, ok := r.Move("starforged/moves/combat/take_decisive_action"); !ok || m.Name != "Take Decisive Action" {
+		t.Fatalf("Move() across rulesets = %q, %v", m.Name, ok)
+	}
+}
@@ -0,0 +1,98 @@
+package stats
+
+import "math"
+
+// minExpected is the smallest expected count per face for which the
+// chi-square approximation is considered reliable.
+const minExpected = 5
+
+// Fit is the result of a chi-square goodness-of-fit test.
+type Fit struct {
+	Statistic float64 // Pearson's chi-square statistic
+	DF        int     // Degrees of freedom
+	PValue    float64 // Probability of a statistic at least this large from fair dice
+	Reliable  bool    // Whether every face was expected at least five times
+}
+
+// Uniform tests observed counts against the uniform distribution.
+// An empty sample has a p-value of 1.
+func Uniform(observed []int) Fit {
+	total := 0
+	for _, n := range observed {
+		total += n
+	}
+
+	fit := Fit{DF: len(observed) - 1, PValue: 1}
+	if total == 0 || fit.DF < 1 {
+		return fit
+	}
+
+	expected := float64(total) / float64(len(observed))
+	for _, n := range observed {
+		d := float64(n) - expected
+		fit.Statistic += d * d / expected
+	}
+	fit.PValue = chiSquareSurvival(fit.Statistic, fit.DF)
+	fit.Reliable = expected >= minExpected
+	return fit
+}
+
+// chiSquareSurvival returns P(X >= x) for a chi-square distribution
+// with df degrees of freedom.
+func chiSquareSurvival(x float64, df int) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return upperGamma(float64(df)/2, x/2)
+}
+
+// upperGamma returns the regularized upper incomplete gamma function
+// Q(a, x), using the series expansion of P(a, x) for x < a+1 and a
+// continued fraction otherwise (Numerical Recipes, §6.2).
+func upperGamma(a, x float64) float64 {
+	const (
+		eps   = 1e-14
+		iters = 500
+		tiny  = 1e-300
+	)
+
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < iters; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*eps {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+
+	// Modified Lentz's method.
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < iters; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < eps {
+			break
+		}
+	}
+	return prefix * h
+}
@@ -0,0 +1,14 @@
+// Package stats summarizes recorded rolls (see core/history) and tests
+// whether the dice behind them look fair.
+//
+// A Report counts outcomes, challenge die matches and the faces shown
+// by the action die and the challenge dice. Each die histogram is
+// checked with Pearson's chi-square goodness-of-fit test against the
+// uniform distribution a fair die should produce.
+//
+// A small p-value means the observed faces would be unlikely from fair
+// dice. With many reports some small p-values are expected by chance
+// alone: at p < 0.05, one fair sample in twenty is flagged. The test is
+// also unreliable while any face is expected fewer than five times, so
+// reports carry a Reliable flag.
+package stats
@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Die face counts.
+const (
+	ActionFaces    = 6
+	ChallengeFaces = 10
+)
+
+// Outcomes lists every outcome from best to worst,
+// the order in which reports present them.
+var Outcomes = []roll.Outcome{
+	roll.CriticalSuccess,
+	roll.Success,
+	roll.PartialSuccess,
+	roll.Failure,
+	roll.CriticalFailure,
+}
+
+// Report summarizes a set of recorded rolls.
+type Report struct {
+	Rolls    int // All rolls
+	Action   int // Action rolls
+	Progress int // Progress rolls
+
+	Outcomes map[roll.Outcome]int
+
+	// Matches counts rolls whose challenge dice show the same value.
+	// Fair dice match one roll in ten.
+	Matches int
+
+	// ActionDie[i] counts action dice showing i+1, and ChallengeDie[i]
+	// counts challenge dice (two per roll) showing i+1.
+	ActionDie    [ActionFaces]int
+	ChallengeDie [ChallengeFaces]int
+
+	ActionFit    Fit
+	ChallengeFit Fit
+}
+
+// MatchRate returns the share of rolls with matching challenge dice,
+// or 0 without rolls.
+func (r Report) MatchRate() float64 {
+	if r.Rolls == 0 {
+		return 0
+	}
+	return float64(r.Matches) / float64(r.Rolls)
+}
+
+// Compute builds the report of a set of entries. Entries without a
+// roll, or with dice out of range, are skipped.
+func Compute(entries []history.Entry) Report {
+	rep := Report{Outcomes: make(map[roll.Outcome]int)}
+
+	for _, e := range entries {
+		c0, c1 := e.ChallengeDice[0], e.ChallengeDice[1]
+		if !inRange(c0, ChallengeFaces) || !inRange(c1, ChallengeFaces) {
+			continue
+		}
+
+		switch e.Kind {
+		case roll.KindAction:
+			if !inRange(e.ActionDie, ActionFaces) {
+				continue
+			}
+			rep.Action++
+			rep.ActionDie[e.ActionDie-1]++
+		case roll.KindProgress:
+			rep.Progress++
+		default:
+			continue
+		}
+
+		rep.Rolls++
+		rep.Outcomes[e.Outcome]++
+		rep.ChallengeDie[c0-1]++
+		rep.ChallengeDie[c1-1]++
+		if c0 == c1 {
+			rep.Matches++
+		}
+	}
+
+	rep.ActionFit = Uniform(rep.ActionDie[:])
+	rep.ChallengeFit = Uniform(rep.ChallengeDie[:])
+	return rep
+}
+
+func inRange(face, faces int) bool {
+	return face >= 1 && face <= faces
+}
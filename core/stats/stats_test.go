@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/history"
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestChiSquareSurvival(t *testing.T) {
+	// Reference values from standard chi-square tables.
+	cases := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{0, 5, 1},
+		{3.841, 1, 0.05},
+		{11.070, 5, 0.05},
+		{16.919, 9, 0.05},
+		{21.666, 9, 0.01},
+		{4.351, 5, 0.5},
+		{1.145, 5, 0.95},
+	}
+
+	for _, c := range cases {
+		if got := chiSquareSurvival(c.x, c.df); math.Abs(got-c.want) > 5e-4 {
+			t.Fatalf("chiSquareSurvival(%v, %d) = %.5f; want %.3f", c.x, c.df, got, c.want)
+		}
+	}
+}
+
+func TestUniform(t *testing.T) {
+	fit := Uniform([]int{10, 10, 10, 10, 10, 10})
+	if fit.Statistic != 0 || fit.PValue != 1 || fit.DF != 5 || !fit.Reliable {
+		t.Fatalf("perfectly even counts: %+v", fit)
+	}
+
+	// A die that only ever shows six.
+	fit = Uniform([]int{0, 0, 0, 0, 0, 60})
+	if fit.Statistic != 300 || fit.PValue > 1e-10 {
+		t.Fatalf("loaded die: %+v", fit)
+	}
+
+	if fit := Uniform([]int{1, 0, 2, 0, 1, 0}); fit.Reliable {
+		t.Fatalf("small sample reported reliable: %+v", fit)
+	}
+	if fit := Uniform(make([]int, 6)); fit.PValue != 1 || fit.Reliable {
+		t.Fatalf("empty sample: %+v", fit)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	entries := []history.Entry{
+		{Kind: roll.KindAction, ActionDie: 6, ChallengeDice: [2]int{3, 3}, Outcome: roll.CriticalSuccess},
+		{Kind: roll.KindAction, ActionDie: 1, ChallengeDice: [2]int{9, 2}, Outcome: roll.Failure},
+		{Kind: roll.KindProgress, Progress: 7, ChallengeDice: [2]int{10, 4}, Outcome: roll.PartialSuccess},
+		{Kind: roll.KindAction, ActionDie: 7, ChallengeDice: [2]int{1, 1}}, // invalid die
+		{}, // no roll
+	}
+
+	rep := Compute(entries)
+	if rep.Rolls != 3 || rep.Action != 2 || rep.Progress != 1 || rep.Matches != 1 {
+		t.Fatalf("unexpected counts: %+v", rep)
+	}
+	if rep.ActionDie != [6]int{1, 0, 0, 0, 0, 1} {
+		t.Fatalf("ActionDie = %v", rep.ActionDie)
+	}
+	if rep.ChallengeDie != [10]int{0, 1, 2, 1, 0, 0, 0, 0, 1, 1} {
+		t.Fatalf("ChallengeDie = %v", rep.ChallengeDie)
+	}
+	if rep.Outcomes[roll.CriticalSuccess] != 1 || rep.Outcomes[roll.PartialSuccess] != 1 {
+		t.Fatalf("Outcomes = %v", rep.Outcomes)
+	}
+	if got := rep.MatchRate(); math.Abs(got-1.0/3) > 1e-9 {
+		t.Fatalf("MatchRate = %v", got)
+	}
+}
+
+func TestComputeFairDice(t *testing.T) {
+	r := roll.NewRoller(rand.New(rand.NewSource(7)))
+
+	var entries []history.Entry
+	for i := 0; i < 6000; i++ {
+		var e history.Entry
+		e.SetAction(r.Roll(0))
+		entries = append(entries, e)
+	}
+
+	rep := Compute(entries)
+	if !rep.ActionFit.Reliable || !rep.ChallengeFit.Reliable {
+		t.Fatalf("large sample reported unreliable: %+v", rep)
+	}
+	// A fixed seed keeps this deterministic; fair dice should not be
+	// flagged at the 1% level.
+	if rep.ActionFit.PValue < 0.01 || rep.ChallengeFit.PValue < 0.01 {
+		t.Fatalf("fair dice flagged: action %+v, challenge %+v", rep.ActionFit, rep.ChallengeFit)
+	}
+	if rate := rep.MatchRate(); rate < 0.08 || rate > 0.12 {
+		t.Fatalf("match rate %.3f; want about 0.1", rate)
+	}
+}
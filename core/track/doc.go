@@ -0,0 +1,12 @@
+// Package track models Ironsworn progress tracks: vows, journeys,
+// combats and other challenges measured in progress.
+//
+// A track has ten boxes of four ticks each, 40 ticks in all. Marking
+// progress adds ticks according to the track's rank, from 12 ticks
+// (three boxes) for a troublesome challenge down to a single tick for
+// an epic one. The progress score is the number of filled boxes, and
+// fulfilling a track makes a progress roll against it.
+//
+// Tracks belong to an owner: the owner key of a character sheet (see
+// core/character), or of anything else progress is kept for.
+package track
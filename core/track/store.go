@@ -0,0 +1,118 @@
+package track
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when no track matches.
+var ErrNotFound = errors.New("track: not found")
+
+// Store persists progress tracks keyed by ID.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the track with the given ID, or ErrNotFound.
+	Get(id string) (Track, error)
+
+	// List returns every track of owner, ordered by name.
+	List(owner string) ([]Track, error)
+
+	// Put creates or replaces a track.
+	Put(t Track) error
+
+	// Delete removes a track. Deleting a missing track is not an error.
+	Delete(id string) error
+}
+
+// Find returns the track of owner whose ID or name matches ref.
+// Names are matched case-insensitively.
+func Find(s Store, owner, ref string) (Track, error) {
+	tracks, err := s.List(owner)
+	if err != nil {
+		return Track{}, err
+	}
+
+	ref = strings.TrimSpace(ref)
+	for _, t := range tracks {
+		if t.ID == ref {
+			return t, nil
+		}
+	}
+	for _, t := range tracks {
+		if strings.EqualFold(t.Name, ref) {
+			return t, nil
+		}
+	}
+	return Track{}, ErrNotFound
+}
+
+// SortByName orders tracks by name, then by ID.
+func SortByName(tracks []Track) {
+	sort.Slice(tracks, func(i, j int) bool {
+		a, b := strings.ToLower(tracks[i].Name), strings.ToLower(tracks[j].Name)
+		if a != b {
+			return a < b
+		}
+		return tracks[i].ID < tracks[j].ID
+	})
+}
+
+// MemoryStore is a Store that keeps tracks in memory.
+// Its contents are lost when the process exits.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	tracks map[string]Track
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tracks: make(map[string]Track)}
+}
+
+func (m *MemoryStore) Get(id string) (Track, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.tracks[id]
+	if !ok {
+		return Track{}, ErrNotFound
+	}
+	return t, nil
+}
+
+func (m *MemoryStore) List(owner string) ([]Track, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []Track
+	for _, t := range m.tracks {
+		if t.Owner == owner {
+			out = append(out, t)
+		}
+	}
+	SortByName(out)
+	return out, nil
+}
+
+func (m *MemoryStore) Put(t Track) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tracks[t.ID] = t
+	return nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tracks, id)
+	return nil
+}
@@ -0,0 +1,201 @@
+package track
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+// Track bounds.
+const (
+	TicksPerBox = 4
+	Boxes       = 10
+	MaxTicks    = TicksPerBox * Boxes
+)
+
+// Rank is the difficulty of a challenge.
+type Rank string
+
+const (
+	Troublesome Rank = "troublesome"
+	Dangerous   Rank = "dangerous"
+	Formidable  Rank = "formidable"
+	Extreme     Rank = "extreme"
+	Epic        Rank = "epic"
+)
+
+// AllRanks lists every rank from easiest to hardest.
+var AllRanks = []Rank{Troublesome, Dangerous, Formidable, Extreme, Epic}
+
+// ParseRank converts user input such as "Formidable" into a Rank.
+func ParseRank(raw string) (Rank, bool) {
+	r := Rank(strings.ToLower(strings.TrimSpace(raw)))
+	for _, rank := range AllRanks {
+		if r == rank {
+			return rank, true
+		}
+	}
+	return "", false
+}
+
+// Ticks returns the number of ticks one mark of progress adds
+// on a track of this rank, or 0 for an unknown rank.
+func (r Rank) Ticks() int {
+	switch r {
+	case Troublesome:
+		return 12
+	case Dangerous:
+		return 8
+	case Formidable:
+		return 4
+	case Extreme:
+		return 2
+	case Epic:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Kind is what a track measures progress towards.
+type Kind string
+
+const (
+	Vow     Kind = "vow"
+	Journey Kind = "journey"
+	Combat  Kind = "combat"
+	Other   Kind = "other"
+)
+
+// AllKinds lists every kind of track.
+var AllKinds = []Kind{Vow, Journey, Combat, Other}
+
+// ParseKind converts user input such as "Journey" into a Kind.
+func ParseKind(raw string) (Kind, bool) {
+	k := Kind(strings.ToLower(strings.TrimSpace(raw)))
+	for _, kind := range AllKinds {
+		if k == kind {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// ProgressMove returns the key of the move that rolls against a track
+// of this kind (see core/move), or "" when there is none.
+func (k Kind) ProgressMove() string {
+	switch k {
+	case Vow:
+		return "fulfill_your_vow"
+	case Journey:
+		return "reach_your_destination"
+	case Combat:
+		return "end_the_fight"
+	default:
+		return ""
+	}
+}
+
+// Track is a single progress track.
+type Track struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	Kind  Kind   `json:"kind"`
+	Name  string `json:"name"`
+	Rank  Rank   `json:"rank"`
+	Ticks int    `json:"ticks"`
+
+	// Completed is set when the track is fulfilled with a hit.
+	Completed bool `json:"completed"`
+}
+
+// New returns an empty track with a fresh random ID.
+func New(owner string, kind Kind, name string, rank Rank) (Track, error) {
+	t := Track{
+		ID:    newID(),
+		Owner: owner,
+		Kind:  kind,
+		Name:  strings.TrimSpace(name),
+		Rank:  rank,
+	}
+	if err := t.Validate(); err != nil {
+		return Track{}, err
+	}
+	return t, nil
+}
+
+// newID returns a short random track ID.
+func newID() string {
+	var b [4]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms.
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Validate checks that the track is complete and within bounds.
+func (t Track) Validate() error {
+	switch {
+	case t.ID == "":
+		return errors.New("track: id is required")
+	case t.Owner == "":
+		return errors.New("track: owner is required")
+	case t.Name == "":
+		return errors.New("track: name is required")
+	}
+	if _, ok := ParseKind(string(t.Kind)); !ok {
+		return fmt.Errorf("track: unknown kind %q", t.Kind)
+	}
+	if t.Rank.Ticks() == 0 {
+		return fmt.Errorf("track: unknown rank %q", t.Rank)
+	}
+	if t.Ticks < 0 || t.Ticks > MaxTicks {
+		return fmt.Errorf("track: ticks must be between 0 and %d", MaxTicks)
+	}
+	return nil
+}
+
+// Mark marks progress the given number of times according to the
+// track's rank. Progress stops at a full track. It returns the
+// number of ticks added.
+func (t *Track) Mark(times int) int {
+	before := t.Ticks
+	t.Ticks = min(t.Ticks+times*t.Rank.Ticks(), MaxTicks)
+	return t.Ticks - before
+}
+
+// Clear removes all progress, as when a vow is recommitted.
+func (t *Track) Clear() {
+	t.Ticks = 0
+	t.Completed = false
+}
+
+// Score returns the progress score: the number of filled boxes.
+func (t Track) Score() int {
+	return t.Ticks / TicksPerBox
+}
+
+// BoxTicks returns the number of ticks (0-4) in each of the ten boxes.
+func (t Track) BoxTicks() [Boxes]int {
+	var boxes [Boxes]int
+	for i := range boxes {
+		boxes[i] = min(max(t.Ticks-i*TicksPerBox, 0), TicksPerBox)
+	}
+	return boxes
+}
+
+// Fulfill makes a progress roll against the track's score. A strong
+// or weak hit completes the track; a miss leaves it as it was.
+func (t *Track) Fulfill(r *roll.Roller) roll.ProgressResult {
+	res := r.ProgressRoll(t.Score())
+	switch res.Outcome {
+	case roll.CriticalSuccess, roll.Success, roll.PartialSuccess:
+		t.Completed = true
+	}
+	return res
+}
@@ -0,0 +1,159 @@
+package track
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/roll"
+)
+
+func TestMarkByRank(t *testing.T) {
+	cases := []struct {
+		rank  Rank
+		times int
+		ticks int
+		score int
+	}{
+		{Troublesome, 1, 12, 3},
+		{Troublesome, 4, 40, 10}, // capped at a full track
+		{Dangerous, 2, 16, 4},
+		{Formidable, 3, 12, 3},
+		{Extreme, 3, 6, 1},
+		{Epic, 7, 7, 1},
+	}
+
+	for _, c := range cases {
+		tr, err := New("telegram:1", Vow, "Avenge my kin", c.rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr.Mark(c.times)
+		if tr.Ticks != c.ticks || tr.Score() != c.score {
+			t.Fatalf("%s x%d: ticks %d score %d; want %d, %d",
+				c.rank, c.times, tr.Ticks, tr.Score(), c.ticks, c.score)
+		}
+	}
+}
+
+func TestMarkReturnsTicksAdded(t *testing.T) {
+	tr, _ := New("telegram:1", Journey, "To the Havens", Troublesome)
+	tr.Ticks = 36
+
+	if added := tr.Mark(1); added != 4 {
+		t.Fatalf("Mark added %d ticks; want 4", added)
+	}
+	if added := tr.Mark(1); added != 0 {
+		t.Fatalf("Mark on a full track added %d ticks; want 0", added)
+	}
+}
+
+func TestNewValidates(t *testing.T) {
+	if _, err := New("telegram:1", Vow, " ", Epic); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+	if _, err := New("telegram:1", Vow, "Vow", "impossible"); err == nil {
+		t.Error("expected an error for an unknown rank")
+	}
+	if _, err := New("telegram:1", "quest", "Vow", Epic); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+	if _, err := New("", Vow, "Vow", Epic); err == nil {
+		t.Error("expected an error for a missing owner")
+	}
+}
+
+func TestFulfill(t *testing.T) {
+	// A full track can only miss on a double 10.
+	r := roll.NewRoller(rand.New(rand.NewSource(1)))
+	for i := 0; i < 20; i++ {
+		tr, _ := New("telegram:1", Vow, "Vow", Epic)
+		tr.Ticks = MaxTicks
+
+		res := tr.Fulfill(r)
+		if res.ProgressScore != 10 {
+			t.Fatalf("progress score %d; want 10", res.ProgressScore)
+		}
+		hit := res.Outcome != roll.Failure && res.Outcome != roll.CriticalFailure
+		if tr.Completed != hit {
+			t.Fatalf("Completed = %v for outcome %s", tr.Completed, res.Outcome)
+		}
+	}
+
+	// An empty track can never hit.
+	tr, _ := New("telegram:1", Vow, "Vow", Epic)
+	if res := tr.Fulfill(r); tr.Completed || res.ProgressScore != 0 {
+		t.Fatalf("empty track fulfilled: %+v", res)
+	}
+}
+
+func TestClear(t *testing.T) {
+	tr, _ := New("telegram:1", Vow, "Vow", Dangerous)
+	tr.Mark(3)
+	tr.Completed = true
+
+	tr.Clear()
+	if tr.Ticks != 0 || tr.Completed {
+		t.Fatalf("unexpected track after Clear: %+v", tr)
+	}
+}
+
+func TestParseRankAndKind(t *testing.T) {
+	if r, ok := ParseRank(" Formidable "); !ok || r != Formidable {
+		t.Fatalf("ParseRank = %q, %v", r, ok)
+	}
+	if _, ok := ParseRank("hard"); ok {
+		t.Error("expected unknown rank to be rejected")
+	}
+	if k, ok := ParseKind("JOURNEY"); !ok || k != Journey {
+		t.Fatalf("ParseKind = %q, %v", k, ok)
+	}
+}
+
+func TestMemoryStoreAndFind(t *testing.T) {
+	s := NewMemoryStore()
+
+	a, _ := New("telegram:1", Vow, "Zeal", Epic)
+	b, _ := New("telegram:1", Journey, "Ashen Road", Dangerous)
+	c, _ := New("telegram:2", Vow, "Other", Epic)
+	for _, tr := range []Track{a, b, c} {
+		if err := s.Put(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := s.List("telegram:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "Ashen Road" || list[1].Name != "Zeal" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if got, err := Find(s, "telegram:1", "ashen road"); err != nil || got.ID != b.ID {
+		t.Fatalf("Find by name = %+v, %v", got, err)
+	}
+	if got, err := Find(s, "telegram:1", a.ID); err != nil || got.ID != a.ID {
+		t.Fatalf("Find by ID = %+v, %v", got, err)
+	}
+	if _, err := Find(s, "telegram:1", "Other"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Find of another owner's track: err = %v; want ErrNotFound", err)
+	}
+
+	if err := s.Delete(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(a.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestBoxTicks(t *testing.T) {
+	tr, _ := New("telegram:1", Vow, "Vow", Epic)
+	tr.Ticks = 9
+
+	want := [Boxes]int{4, 4, 1}
+	if got := tr.BoxTicks(); got != want {
+		t.Fatalf("BoxTicks = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,401 @@
+package datasworn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/move"
+	"github.com/mtzvd/ironroll/core/oracle"
+)
+
+// Error describes malformed Datasworn data.
+type Error struct {
+	File string // File the data was read from
+	Path string // JSON path of the offending value, e.g. "oracles.place.contents.region"
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.File, e.Path, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Move is a move imported from Datasworn.
+type Move struct {
+	ID       string
+	Name     string
+	RollType string   // "action_roll", "progress_roll", "no_roll" or "special_track"
+	Trigger  string   // Trigger text
+	Stats    []string // Stats and condition meters the move can roll with
+	Text     string   // Full move text
+
+	StrongHit string // Outcome texts; empty for moves without a roll
+	WeakHit   string
+	Miss      string
+}
+
+// Playable converts the move into a core move that adapters can roll.
+// It reports false for moves without an action or progress roll, and
+// for action moves that roll with none of the standard stats or meters.
+func (m Move) Playable() (move.Move, bool) {
+	out := move.Move{
+		ID:        m.ID,
+		Key:       path.Base(m.ID),
+		Name:      m.Name,
+		StrongHit: m.StrongHit,
+		WeakHit:   m.WeakHit,
+		Miss:      m.Miss,
+	}
+
+	switch m.RollType {
+	case "progress_roll":
+		out.Progress = true
+	case "action_roll":
+		for _, raw := range m.Stats {
+			if stat, ok := move.ParseStat(raw); ok {
+				out.Stats = append(out.Stats, stat)
+			}
+		}
+		if len(out.Stats) == 0 {
+			return move.Move{}, false
+		}
+	default:
+		return move.Move{}, false
+	}
+	return out, true
+}
+
+// Asset is an asset imported from Datasworn.
+type Asset struct {
+	ID        string
+	Name      string
+	Category  string // Collection name, e.g. "Companion"
+	Abilities []Ability
+	Tracks    []Track
+}
+
+// Playable converts the asset into a core asset that characters can
+// take. Datasworn move enhancements are not imported, so its abilities
+// carry no roll bonuses.
+func (a Asset) Playable() asset.Asset {
+	out := asset.Asset{
+		ID:       a.ID,
+		Key:      path.Base(a.ID),
+		Name:     a.Name,
+		Category: asset.Category(a.Category),
+	}
+	for _, ab := range a.Abilities {
+		out.Abilities = append(out.Abilities, asset.Ability{Text: ab.Text, Enabled: ab.Enabled})
+	}
+	for _, t := range a.Tracks {
+		out.Tracks = append(out.Tracks, asset.Track{Name: t.Name, Max: t.Max})
+	}
+	return out
+}
+
+// Ability is one of the (usually three) abilities of an asset.
+type Ability struct {
+	Text    string
+	Enabled bool // Whether the ability is marked when the asset is acquired
+}
+
+// Track is a condition meter printed on an asset, such as companion health.
+type Track struct {
+	Name string
+	Max  int
+}
+
+// Registry holds Datasworn content keyed by Datasworn ID.
+//
+// A Registry is filled at startup and is not safe for concurrent
+// modification; reading it concurrently is fine.
+type Registry struct {
+	Oracles map[string]oracle.Table
+	Moves   map[string]Move
+	Assets  map[string]Asset
+
+	// Skipped lists the IDs of oracle tables that were not imported
+	// because they are not rolled with a d100.
+	Skipped []string
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		Oracles: make(map[string]oracle.Table),
+		Moves:   make(map[string]Move),
+		Assets:  make(map[string]Asset),
+	}
+}
+
+// Load reads a Datasworn file, or every *.json file in a directory
+// (in name order), into a new registry.
+func Load(path string) (*Registry, error) {
+	r := NewRegistry()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return r, r.LoadFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var errs []error
+	for _, f := range files {
+		if err := r.LoadFile(f); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return r, errors.Join(errs...)
+}
+
+// LoadFile reads a single Datasworn file into the registry.
+func (r *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.Parse(filepath.Base(path), data)
+}
+
+// Parse reads Datasworn JSON into the registry. The name is used in
+// error messages only.
+//
+// Every problem found in the data is reported, joined into one error;
+// the valid items are still added to the registry.
+func (r *Registry) Parse(name string, data []byte) error {
+	var root rawRoot
+	if err := json.Unmarshal(data, &root); err != nil {
+		return &Error{File: name, Err: err}
+	}
+
+	p := parser{file: name, reg: r}
+	for _, key := range sortedKeys(root.Oracles) {
+		p.oracleCollection("oracles."+key, root.Oracles[key])
+	}
+	for _, key := range sortedKeys(root.Moves) {
+		p.moveCollection("moves."+key, root.Moves[key])
+	}
+	for _, key := range sortedKeys(root.Assets) {
+		p.assetCollection("assets."+key, root.Assets[key])
+	}
+
+	return errors.Join(p.errs...)
+}
+
+// parser walks one file and collects its errors.
+type parser struct {
+	file string
+	reg  *Registry
+	errs []error
+}
+
+func (p *parser) fail(path, format string, args ...any) {
+	p.errs = append(p.errs, &Error{File: p.file, Path: path, Err: fmt.Errorf(format, args...)})
+}
+
+func (p *parser) oracleCollection(path string, c rawCollection[rawOracle]) {
+	for _, key := range sortedKeys(c.Contents) {
+		p.oracle(path+".contents."+key, key, c.Contents[key])
+	}
+	for _, key := range sortedKeys(c.Collections) {
+		p.oracleCollection(path+".collections."+key, c.Collections[key])
+	}
+}
+
+func (p *parser) oracle(path, key string, o rawOracle) {
+	id := o.id()
+	if id == "" {
+		p.fail(path, "missing id")
+		return
+	}
+	if o.Name == "" {
+		p.fail(path, "%s: missing name", id)
+		return
+	}
+
+	if o.Dice != "" && o.Dice != "1d100" && o.Dice != "d100" {
+		p.reg.Skipped = append(p.reg.Skipped, id)
+		return
+	}
+
+	rows := o.Table
+	rowsPath := path + ".table"
+	if rows == nil {
+		rows = o.Rows
+		rowsPath = path + ".rows"
+	}
+
+	table := oracle.Table{ID: id, Key: key, Name: o.Name}
+	for i, row := range rows {
+		rowPath := fmt.Sprintf("%s[%d]", rowsPath, i)
+
+		lo, hi, text := row.bounds()
+		if lo == nil || hi == nil {
+			// Rows without a range (e.g. headings) are not rollable.
+			continue
+		}
+		if *lo > *hi {
+			p.fail(rowPath, "min %d > max %d", *lo, *hi)
+			return
+		}
+		if text == "" {
+			p.fail(rowPath, "missing result text")
+			return
+		}
+		table.Rows = append(table.Rows, oracle.Row{Min: *lo, Max: *hi, Result: text})
+	}
+
+	if err := table.Validate(); err != nil {
+		p.fail(path, "%v", err)
+		return
+	}
+	p.reg.Oracles[id] = table
+}
+
+func (p *parser) moveCollection(path string, c rawCollection[rawMove]) {
+	for _, key := range sortedKeys(c.Contents) {
+		p.move(path+".contents."+key, c.Contents[key])
+	}
+	for _, key := range sortedKeys(c.Collections) {
+		p.moveCollection(path+".collections."+key, c.Collections[key])
+	}
+}
+
+func (p *parser) move(path string, m rawMove) {
+	id := m.id()
+	if id == "" {
+		p.fail(path, "missing id")
+		return
+	}
+	if m.Name == "" {
+		p.fail(path, "%s: missing name", id)
+		return
+	}
+
+	move := Move{
+		ID:       id,
+		Name:     m.Name,
+		RollType: m.RollType,
+		Trigger:  m.Trigger.Text,
+		Text:     m.Text,
+	}
+
+	switch m.RollType {
+	case "action_roll", "progress_roll":
+		if m.Outcomes == nil {
+			p.fail(path+".outcomes", "%s: %s requires outcomes", id, m.RollType)
+			return
+		}
+		move.StrongHit = m.Outcomes.StrongHit.Text
+		move.WeakHit = m.Outcomes.WeakHit.Text
+		move.Miss = m.Outcomes.Miss.Text
+	case "", "no_roll", "special_track":
+	default:
+		p.fail(path+".roll_type", "%s: unknown roll type %q", id, m.RollType)
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, cond := range m.Trigger.Conditions {
+		for _, opt := range cond.RollOptions {
+			stat := normalizeStat(opt.Stat)
+			if stat == "" {
+				stat = normalizeStat(opt.ConditionMeter)
+			}
+			if stat != "" && !seen[stat] {
+				seen[stat] = true
+				move.Stats = append(move.Stats, stat)
+			}
+		}
+	}
+
+	p.reg.Moves[id] = move
+}
+
+func (p *parser) assetCollection(path string, c rawCollection[rawAsset]) {
+	for _, key := range sortedKeys(c.Contents) {
+		p.asset(path+".contents."+key, c.Name, c.Contents[key])
+	}
+	for _, key := range sortedKeys(c.Collections) {
+		p.assetCollection(path+".collections."+key, c.Collections[key])
+	}
+}
+
+func (p *parser) asset(path, category string, a rawAsset) {
+	id := a.id()
+	if id == "" {
+		p.fail(path, "missing id")
+		return
+	}
+	if a.Name == "" {
+		p.fail(path, "%s: missing name", id)
+		return
+	}
+	if len(a.Abilities) == 0 {
+		p.fail(path+".abilities", "%s: asset has no abilities", id)
+		return
+	}
+
+	asset := Asset{ID: id, Name: a.Name, Category: category}
+	for i, ab := range a.Abilities {
+		if ab.Text == "" {
+			p.fail(fmt.Sprintf("%s.abilities[%d]", path, i), "%s: missing ability text", id)
+			return
+		}
+		asset.Abilities = append(asset.Abilities, Ability{Text: ab.Text, Enabled: ab.Enabled})
+	}
+
+	for _, key := range sortedKeys(a.Controls) {
+		ctl := a.Controls[key]
+		if ctl.FieldType != "condition_meter" {
+			continue
+		}
+		if ctl.Max <= 0 {
+			p.fail(path+".controls."+key, "%s: condition meter max must be positive", id)
+			return
+		}
+		name := ctl.Label
+		if name == "" {
+			name = key
+		}
+		asset.Tracks = append(asset.Tracks, Track{Name: name, Max: ctl.Max})
+	}
+
+	p.reg.Assets[id] = asset
+}
+
+// sortedKeys returns map keys in order, so that parsing (and thus
+// error reporting) is deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeStat maps Datasworn stat names onto lower-case identifiers.
+func normalizeStat(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
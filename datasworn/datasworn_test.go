@@ -0,0 +1,133 @@
+package datasworn
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	reg, err := Load(filepath.Join("testdata", "classic.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	table, ok := reg.Oracles["classic/oracles/action_and_theme/action"]
+	if !ok {
+		t.Fatal("action oracle not imported")
+	}
+	if table.Key != "action" || table.Name != "Action" || len(table.Rows) != 2 {
+		t.Fatalf("unexpected action table: %+v", table)
+	}
+	if row, _ := table.Lookup(51); row.Result != "Clash" {
+		t.Fatalf("Lookup(51) = %q, want Clash", row.Result)
+	}
+
+	if want := []string{"classic/oracles/action_and_theme/minor/mood"}; !reflect.DeepEqual(reg.Skipped, want) {
+		t.Fatalf("Skipped = %v, want %v", reg.Skipped, want)
+	}
+
+	move, ok := reg.Moves["classic/moves/adventure/face_danger"]
+	if !ok {
+		t.Fatal("face danger move not imported")
+	}
+	if move.RollType != "action_roll" || move.StrongHit == "" || move.Miss == "" {
+		t.Fatalf("unexpected move: %+v", move)
+	}
+	if want := []string{"edge", "iron"}; !reflect.DeepEqual(move.Stats, want) {
+		t.Fatalf("move stats = %v, want %v", move.Stats, want)
+	}
+
+	asset, ok := reg.Assets["classic/assets/companion/cave_lion"]
+	if !ok {
+		t.Fatal("cave lion asset not imported")
+	}
+	if asset.Category != "Companion" || len(asset.Abilities) != 3 || !asset.Abilities[0].Enabled {
+		t.Fatalf("unexpected asset: %+v", asset)
+	}
+	if want := []Track{{Name: "health", Max: 4}}; !reflect.DeepEqual(asset.Tracks, want) {
+		t.Fatalf("asset tracks = %v, want %v", asset.Tracks, want)
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	reg, err := Load(filepath.Join("testdata", "pack"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// "_id" and the newer row shape are accepted.
+	table, ok := reg.Oracles["starforged/oracles/core/focus"]
+	if !ok || len(table.Rows) != 2 || table.Rows[1].Result != "Ship" {
+		t.Fatalf("focus oracle not imported correctly: %+v", table)
+	}
+
+	move, ok := reg.Moves["delve/moves/delve/delve_the_depths"]
+	if !ok || !reflect.DeepEqual(move.Stats, []string{"edge", "shadow", "wits"}) {
+		t.Fatalf("delve move not imported correctly: %+v", move)
+	}
+}
+
+func TestMalformedDataReportsFileAndPath(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "malformed.json"))
+	if err == nil {
+		t.Fatal("expected an error for malformed data")
+	}
+
+	var dsErr *Error
+	if !errors.As(err, &dsErr) || dsErr.File != "malformed.json" {
+		t.Fatalf("expected a *datasworn.Error for malformed.json, got %v", err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"malformed.json: oracles.place.contents.region.table[1]: min 40 > max 35",
+		"malformed.json: oracles.place.contents.location: oracle: table \"classic/oracles/place/location\": rows end at 60, want 100",
+		"malformed.json: moves.adventure.contents.heal: missing id",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("error %q does not contain %q", msg, want)
+		}
+	}
+}
+
+func TestParseRejectsInvalidJSON(t *testing.T) {
+	err := NewRegistry().Parse("broken.json", []byte(`{"oracles": [`))
+
+	var dsErr *Error
+	if !errors.As(err, &dsErr) || dsErr.File != "broken.json" {
+		t.Fatalf("expected a *datasworn.Error for broken.json, got %v", err)
+	}
+}
+
+func TestPlayable(t *testing.T) {
+	reg, err := Load(filepath.Join("testdata", "classic.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	m, ok := reg.Moves["classic/moves/adventure/face_danger"].Playable()
+	if !ok {
+		t.Fatal("face danger should be playable")
+	}
+	if m.Key != "face_danger" || m.Progress || !m.Allows("edge") || !m.Allows("iron") {
+		t.Fatalf("unexpected playable move: %+v", m)
+	}
+
+	if _, ok := (Move{ID: "x/ask", Name: "Ask", RollType: "no_roll"}).Playable(); ok {
+		t.Error("moves without a roll should not be playable")
+	}
+	if _, ok := (Move{ID: "x/odd", Name: "Odd", RollType: "action_roll", Stats: []string{"companion health"}}).Playable(); ok {
+		t.Error("action moves without a standard stat should not be playable")
+	}
+
+	a := reg.Assets["classic/assets/companion/cave_lion"].Playable()
+	if a.Key != "cave_lion" || a.Category != "Companion" || len(a.Abilities) != 3 || !a.Abilities[0].Enabled {
+		t.Fatalf("unexpected playable asset: %+v", a)
+	}
+	if tr, ok := a.Track("health"); !ok || tr.Max != 4 {
+		t.Fatalf("cave lion health = %+v, %v", tr, ok)
+	}
+}
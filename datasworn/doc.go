@@ -0,0 +1,24 @@
+// Package datasworn loads oracle tables, moves and assets from the
+// community Datasworn JSON format (https://github.com/rsek/datasworn).
+//
+// A Datasworn file holds one ruleset or expansion. Its "oracles",
+// "moves" and "assets" sections are trees of collections: each
+// collection has "contents" (the items) and optional nested
+// "collections". Every item carries a Datasworn ID such as
+//
+//	classic/oracles/action_and_theme/action
+//	classic/moves/adventure/face_danger
+//	classic/assets/companion/cave_lion
+//
+// and the Registry indexes items by that ID. Both the "id" and "_id"
+// spellings are accepted, as are the two oracle row shapes
+// ({"min", "max", "result"} and {"roll": {"min", "max"}, "text"}).
+//
+// Only d100 oracle tables are imported, since that is what core/oracle
+// models; tables rolled with other dice are listed in Registry.Skipped.
+//
+// Malformed data is reported as an *Error naming the file and the
+// JSON path of the offending value, e.g.
+//
+//	classic.json: oracles.place.contents.region.table[3]: min 40 > max 35
+package datasworn
@@ -0,0 +1,102 @@
+package datasworn
+
+// The raw* types mirror the subset of the Datasworn JSON schema
+// that ironroll imports. Unknown fields are ignored.
+
+type rawRoot struct {
+	Oracles map[string]rawCollection[rawOracle] `json:"oracles"`
+	Moves   map[string]rawCollection[rawMove]   `json:"moves"`
+	Assets  map[string]rawCollection[rawAsset]  `json:"assets"`
+}
+
+type rawCollection[T any] struct {
+	Name        string                      `json:"name"`
+	Contents    map[string]T                `json:"contents"`
+	Collections map[string]rawCollection[T] `json:"collections"`
+}
+
+// rawID accepts both the "id" and "_id" spellings used by
+// different Datasworn versions.
+type rawID struct {
+	ID    string `json:"id"`
+	UndID string `json:"_id"`
+}
+
+func (r rawID) id() string {
+	if r.UndID != "" {
+		return r.UndID
+	}
+	return r.ID
+}
+
+type rawOracle struct {
+	rawID
+	Name  string   `json:"name"`
+	Dice  string   `json:"dice"`
+	Table []rawRow `json:"table"` // older schema
+	Rows  []rawRow `json:"rows"`  // newer schema
+}
+
+type rawRow struct {
+	Min    *int   `json:"min"`
+	Max    *int   `json:"max"`
+	Result string `json:"result"`
+
+	Roll *struct {
+		Min *int `json:"min"`
+		Max *int `json:"max"`
+	} `json:"roll"`
+	Text string `json:"text"`
+}
+
+// bounds returns the roll range and result text of a row
+// in either schema. The bounds are nil for unrollable rows.
+func (r rawRow) bounds() (*int, *int, string) {
+	text := r.Result
+	if text == "" {
+		text = r.Text
+	}
+	if r.Roll != nil {
+		return r.Roll.Min, r.Roll.Max, text
+	}
+	return r.Min, r.Max, text
+}
+
+type rawMove struct {
+	rawID
+	Name     string `json:"name"`
+	RollType string `json:"roll_type"`
+	Text     string `json:"text"`
+	Trigger  struct {
+		Text       string `json:"text"`
+		Conditions []struct {
+			RollOptions []struct {
+				Stat           string `json:"stat"`
+				ConditionMeter string `json:"condition_meter"`
+			} `json:"roll_options"`
+		} `json:"conditions"`
+	} `json:"trigger"`
+	Outcomes *struct {
+		StrongHit rawText `json:"strong_hit"`
+		WeakHit   rawText `json:"weak_hit"`
+		Miss      rawText `json:"miss"`
+	} `json:"outcomes"`
+}
+
+type rawText struct {
+	Text string `json:"text"`
+}
+
+type rawAsset struct {
+	rawID
+	Name      string `json:"name"`
+	Abilities []struct {
+		Text    string `json:"text"`
+		Enabled bool   `json:"enabled"`
+	} `json:"abilities"`
+	Controls map[string]struct {
+		FieldType string `json:"field_type"`
+		Label     string `json:"label"`
+		Max       int    `json:"max"`
+	} `json:"controls"`
+}
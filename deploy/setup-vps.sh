@@ -22,6 +22,8 @@ if [ ! -f /opt/ironroll/.env ]; then
 TELEGRAM_BOT_TOKEN=your_telegram_token_here
 DISCORD_BOT_TOKEN=your_discord_token_here
 PORT=8080
+STORE_PATH=/opt/ironroll/state.json
+HISTORY_PATH=/opt/ironroll/history.jsonl
 EOF
     sudo chown ironroll:ironroll /opt/ironroll/.env
     sudo chmod 600 /opt/ironroll/.env
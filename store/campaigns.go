@@ -0,0 +1,87 @@
+package store
+
+import "github.com/mtzvd/ironroll/core/campaign"
+
+// Campaigns is the campaign section of a File.
+// It implements campaign.Store.
+type Campaigns struct {
+	f *File
+}
+
+// Campaigns returns the campaign section of the file.
+func (f *File) Campaigns() *Campaigns {
+	return &Campaigns{f: f}
+}
+
+func (c *Campaigns) Get(id string) (campaign.Campaign, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	camp, ok := c.f.doc.Campaigns[id]
+	if !ok {
+		return campaign.Campaign{}, campaign.ErrNotFound
+	}
+	return camp.Clone(), nil
+}
+
+func (c *Campaigns) List() ([]campaign.Campaign, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	out := make([]campaign.Campaign, 0, len(c.f.doc.Campaigns))
+	for _, camp := range c.f.doc.Campaigns {
+		out = append(out, camp.Clone())
+	}
+	campaign.SortByName(out)
+	return out, nil
+}
+
+func (c *Campaigns) Put(camp campaign.Campaign) error {
+	if err := camp.Validate(); err != nil {
+		return err
+	}
+
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	existing := make([]campaign.Campaign, 0, len(c.f.doc.Campaigns))
+	for _, other := range c.f.doc.Campaigns {
+		existing = append(existing, other)
+	}
+	if err := campaign.CheckBindings(existing, camp); err != nil {
+		return err
+	}
+
+	old, existed := c.f.doc.Campaigns[camp.ID]
+	c.f.doc.Campaigns[camp.ID] = camp.Clone()
+
+	if err := c.f.save(); err != nil {
+		// Keep memory consistent with the file.
+		if existed {
+			c.f.doc.Campaigns[camp.ID] = old
+		} else {
+			delete(c.f.doc.Campaigns, camp.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Campaigns) Delete(id string) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	old, existed := c.f.doc.Campaigns[id]
+	if !existed {
+		return nil
+	}
+	delete(c.f.doc.Campaigns, id)
+
+	if err := c.f.save(); err != nil {
+		c.f.doc.Campaigns[id] = old
+		return err
+	}
+	return nil
+}
+
+var _ campaign.Store = (*Campaigns)(nil)
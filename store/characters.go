@@ -0,0 +1,67 @@
+package store
+
+import "github.com/mtzvd/ironroll/core/character"
+
+// Characters is the character sheet section of a File.
+// It implements character.Store.
+type Characters struct {
+	f *File
+}
+
+// Characters returns the character sheet section of the file.
+func (f *File) Characters() *Characters {
+	return &Characters{f: f}
+}
+
+func (c *Characters) Get(owner string) (character.Sheet, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	s, ok := c.f.doc.Characters[owner]
+	if !ok {
+		return character.Sheet{}, character.ErrNotFound
+	}
+	return s.Clone(), nil
+}
+
+func (c *Characters) Put(s character.Sheet) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	old, existed := c.f.doc.Characters[s.Owner]
+	c.f.doc.Characters[s.Owner] = s.Clone()
+
+	if err := c.f.save(); err != nil {
+		// Keep memory consistent with the file.
+		if existed {
+			c.f.doc.Characters[s.Owner] = old
+		} else {
+			delete(c.f.doc.Characters, s.Owner)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Characters) Delete(owner string) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	old, existed := c.f.doc.Characters[owner]
+	if !existed {
+		return nil
+	}
+	delete(c.f.doc.Characters, owner)
+
+	if err := c.f.save(); err != nil {
+		c.f.doc.Characters[owner] = old
+		return err
+	}
+	return nil
+}
+
+var _ character.Store = (*Characters)(nil)
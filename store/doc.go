@@ -0,0 +1,16 @@
+// Package store persists bot state in a local JSON file.
+//
+// A File holds one JSON document with a section per kind of state.
+// Every change rewrites the document through a temporary file and an
+// atomic rename, so a crash never leaves a half-written file behind.
+// The whole document is kept in memory; it is meant for the modest
+// amount of state a single bot instance accumulates.
+//
+// Each section is exposed as a view implementing the matching core
+// interface: File.Characters implements character.Store,
+// File.Tracks implements track.Store and File.Campaigns implements
+// campaign.Store.
+//
+// Roll history grows without bound, so it is kept apart in a History
+// file of JSON lines that is only ever appended to.
+package store
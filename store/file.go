@@ -0,0 +1,90 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+// File is a JSON file holding all persistent bot state.
+//
+// A File is safe for concurrent use within one process. Two processes
+// must not share the same file.
+type File struct {
+	mu   sync.Mutex
+	path string
+	doc  document
+}
+
+// document is the on-disk layout of a File.
+type document struct {
+	Characters map[string]character.Sheet   `json:"characters,omitempty"`
+	Tracks     map[string]track.Track       `json:"tracks,omitempty"`
+	Campaigns  map[string]campaign.Campaign `json:"campaigns,omitempty"`
+}
+
+// Open loads the state file at path. A missing file is not an error:
+// it is created on the first change.
+func Open(path string) (*File, error) {
+	f := &File{path: path}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("store: %w", err)
+	default:
+		if err := json.Unmarshal(data, &f.doc); err != nil {
+			return nil, fmt.Errorf("store: %s: %w", path, err)
+		}
+	}
+
+	if f.doc.Characters == nil {
+		f.doc.Characters = make(map[string]character.Sheet)
+	}
+	if f.doc.Tracks == nil {
+		f.doc.Tracks = make(map[string]track.Track)
+	}
+	if f.doc.Campaigns == nil {
+		f.doc.Campaigns = make(map[string]campaign.Campaign)
+	}
+	return f, nil
+}
+
+// Path returns the path of the state file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// save writes the document to disk. The caller must hold f.mu.
+func (f *File) save() error {
+	data, err := json.MarshalIndent(f.doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	return nil
+}
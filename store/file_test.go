@@ -0,0 +1,193 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mtzvd/ironroll/core/asset"
+	"github.com/mtzvd/ironroll/core/campaign"
+	"github.com/mtzvd/ironroll/core/character"
+	"github.com/mtzvd/ironroll/core/track"
+)
+
+func TestCharactersPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	s := character.New("discord:g:u", "Kira")
+	s.Wits = 3
+	s.Mark(character.Wounded, true)
+	if err := f.Characters().Put(s); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got, err := reopened.Characters().Get("discord:g:u")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Name != "Kira" || got.Wits != 3 || !got.Marked(character.Wounded) {
+		t.Fatalf("unexpected sheet after reopening: %+v", got)
+	}
+
+	if err := reopened.Characters().Delete("discord:g:u"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	again, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if _, err := again.Characters().Get("discord:g:u"); !errors.Is(err, character.ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestCharactersAreCopied(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	s := character.New("telegram:1", "Kira")
+	s.Assets = []asset.Card{{Asset: "hound", Abilities: []bool{true, false}, Tracks: map[string]int{"health": 4}}}
+	s.Legacies = map[character.Legacy]character.LegacyTrack{character.Bonds: {Ticks: 4}}
+	if err := f.Characters().Put(s); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	s.Assets[0].Tracks["health"] = 2
+
+	got, err := f.Characters().Get("telegram:1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got.Assets[0].Abilities[1] = true
+	got.Assets[0].Tracks["health"] = 1
+	got.Legacies[character.Bonds] = character.LegacyTrack{Ticks: 39}
+
+	again, _ := f.Characters().Get("telegram:1")
+	if card := again.Assets[0]; card.Abilities[1] || card.Tracks["health"] != 4 {
+		t.Fatalf("stored card was modified through a copy: %+v", card)
+	}
+	if again.Legacies[character.Bonds].Ticks != 4 {
+		t.Fatalf("stored legacies were modified through a copy: %v", again.Legacies)
+	}
+}
+
+func TestCharactersRejectInvalidSheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	s := character.New("telegram:1", "Kira")
+	s.Heart = 7
+	if err := f.Characters().Put(s); err == nil {
+		t.Fatal("expected Put to reject an invalid sheet")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no file to be written, stat err = %v", err)
+	}
+}
+
+func TestOpenMalformedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected an error for a malformed file")
+	}
+}
+
+func TestTracksPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	vow, err := track.New("telegram:1", track.Vow, "Avenge my kin", track.Dangerous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vow.Mark(2)
+	if err := f.Tracks().Put(vow); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	list, err := reopened.Tracks().List("telegram:1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0] != vow {
+		t.Fatalf("unexpected tracks after reopening: %+v", list)
+	}
+
+	if err := reopened.Tracks().Delete(vow.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := reopened.Tracks().Get(vow.ID); !errors.Is(err, track.ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestCampaignsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	c, err := campaign.New("The Ironlands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Bind(campaign.DiscordChannel("g", "1"))
+	c.AddMember(c.CharacterOwner("discord:42"))
+	if err := f.Campaigns().Put(c); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	other, _ := campaign.New("Other")
+	other.Bind(campaign.DiscordChannel("g", "1"))
+	if err := f.Campaigns().Put(other); !errors.Is(err, campaign.ErrBound) {
+		t.Fatalf("Put with a taken binding: err = %v; want ErrBound", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got, err := campaign.Resolve(reopened.Campaigns(), campaign.DiscordChannel("g", "1"))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if got.ID != c.ID || !got.HasMember(c.CharacterOwner("discord:42")) {
+		t.Fatalf("unexpected campaign after reopening: %+v", got)
+	}
+
+	if err := reopened.Campaigns().Delete(c.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if list, _ := reopened.Campaigns().List(); len(list) != 0 {
+		t.Fatalf("expected no campaigns after Delete, got %+v", list)
+	}
+}
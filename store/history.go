@@ -0,0 +1,136 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/mtzvd/ironroll/core/history"
+)
+
+// History is a history.Store backed by an append-only file of JSON
+// lines, one entry per line. Unlike File it is never rewritten, so
+// recording a roll costs a single small write however long the
+// history grows.
+//
+// Queries read the file from the start; they are meant for the
+// occasional lookup, not for serving heavy traffic. Lines that cannot
+// be decoded, such as an entry damaged by a crash, are logged and
+// skipped.
+type History struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenHistory opens the history file at path, creating it if needed.
+// A partly written last line, left by a crash during Add, is cut off
+// so that new entries start on a line of their own.
+func OpenHistory(path string) (*History, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	if err := cutPartialLine(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("store: %s: %w", path, err)
+	}
+	return &History{path: path, file: f}, nil
+}
+
+// cutPartialLine truncates f after its last newline.
+func cutPartialLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	end := info.Size()
+	buf := make([]byte, 4096)
+	for pos := end; pos > 0; {
+		n := min(int64(len(buf)), pos)
+		pos -= n
+		if _, err := f.ReadAt(buf[:n], pos); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = pos + int64(i) + 1
+			break
+		}
+		if pos == 0 {
+			end = 0
+		}
+	}
+	if end == info.Size() {
+		return nil
+	}
+	slog.Warn("history file ends with a partly written entry; cutting it off", "path", f.Name(), "bytes", info.Size()-end)
+	return f.Truncate(end)
+}
+
+// Path returns the path of the history file.
+func (h *History) Path() string {
+	return h.path
+}
+
+// Close closes the history file.
+func (h *History) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.file.Close()
+}
+
+func (h *History) Add(e history.Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, err := h.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	return nil
+}
+
+func (h *History) Query(f history.Filter) ([]history.Entry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, err := h.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+
+	var matches []history.Entry
+	sc := bufio.NewScanner(h.file)
+	for line := 1; sc.Scan(); line++ {
+		var e history.Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			slog.Warn("skipping malformed history entry", "path", h.path, "line", line, "err", err)
+			continue
+		}
+		if f.Match(e) {
+			matches = append(matches, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+
+	// Entries are stored oldest first.
+	out := make([]history.Entry, 0, len(matches))
+	for i := len(matches) - 1; i >= 0; i-- {
+		out = append(out, matches[i])
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtzvd/ironroll/core/history"
+)
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	h, err := OpenHistory(path)
+	if err != nil {
+		t.Fatalf("OpenHistory: %v", err)
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		e := history.Entry{
+			Time:     start.Add(time.Duration(i) * time.Minute),
+			Platform: history.Discord,
+			Campaign: "c1",
+			Total:    i,
+		}
+		if i%2 == 1 {
+			e.Campaign = "c2"
+		}
+		if err := h.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := OpenHistory(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.Query(history.Filter{Campaign: "c1"})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 2 || got[0].Total != 2 || got[1].Total != 0 || !got[0].Time.Equal(start.Add(2*time.Minute)) {
+		t.Fatalf("unexpected entries after reopening: %+v", got)
+	}
+
+	// Adding after a query appends at the end of the file.
+	if err := reopened.Add(history.Entry{Platform: history.HTTP, Total: 9}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	got, _ = reopened.Query(history.Filter{Limit: 2})
+	if len(got) != 2 || got[0].Total != 9 || got[1].Total != 3 {
+		t.Fatalf("Query(limit 2) = %+v; want totals 9, 3", got)
+	}
+}
+
+func TestHistoryMalformedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	lines := `{"total":1}` + "\n{not json\n" + `{"total":2}` + "\n"
+	if err := os.WriteFile(path, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := OpenHistory(path)
+	if err != nil {
+		t.Fatalf("OpenHistory: %v", err)
+	}
+	defer h.Close()
+
+	got, err := h.Query(history.Filter{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 2 || got[0].Total != 2 || got[1].Total != 1 {
+		t.Fatalf("Query = %+v; want totals 2, 1 around the malformed line", got)
+	}
+}
+
+func TestHistoryPartialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"after an entry", `{"total":1}` + "\n" + `{"tot`, `{"total":1}` + "\n"},
+		{"only line", `{"tot`, ""},
+		{"complete", `{"total":1}` + "\n", `{"total":1}` + "\n"},
+	}
+
+	for _, c := range cases {
+		if err := os.WriteFile(path, []byte(c.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		h, err := OpenHistory(path)
+		if err != nil {
+			t.Fatalf("%s: OpenHistory: %v", c.name, err)
+		}
+		if err := h.Add(history.Entry{Total: 3}); err != nil {
+			t.Fatalf("%s: Add: %v", c.name, err)
+		}
+		h.Close()
+
+		data, _ := os.ReadFile(path)
+		if want := c.want + `{"time":`; !strings.HasPrefix(string(data), want) {
+			t.Fatalf("%s: file = %q; want it to start with %q", c.name, data, want)
+		}
+	}
+}
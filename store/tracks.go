@@ -0,0 +1,81 @@
+package store
+
+import "github.com/mtzvd/ironroll/core/track"
+
+// Tracks is the progress track section of a File.
+// It implements track.Store.
+type Tracks struct {
+	f *File
+}
+
+// Tracks returns the progress track section of the file.
+func (f *File) Tracks() *Tracks {
+	return &Tracks{f: f}
+}
+
+func (t *Tracks) Get(id string) (track.Track, error) {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+
+	tr, ok := t.f.doc.Tracks[id]
+	if !ok {
+		return track.Track{}, track.ErrNotFound
+	}
+	return tr, nil
+}
+
+func (t *Tracks) List(owner string) ([]track.Track, error) {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+
+	var out []track.Track
+	for _, tr := range t.f.doc.Tracks {
+		if tr.Owner == owner {
+			out = append(out, tr)
+		}
+	}
+	track.SortByName(out)
+	return out, nil
+}
+
+func (t *Tracks) Put(tr track.Track) error {
+	if err := tr.Validate(); err != nil {
+		return err
+	}
+
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+
+	old, existed := t.f.doc.Tracks[tr.ID]
+	t.f.doc.Tracks[tr.ID] = tr
+
+	if err := t.f.save(); err != nil {
+		// Keep memory consistent with the file.
+		if existed {
+			t.f.doc.Tracks[tr.ID] = old
+		} else {
+			delete(t.f.doc.Tracks, tr.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *Tracks) Delete(id string) error {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+
+	old, existed := t.f.doc.Tracks[id]
+	if !existed {
+		return nil
+	}
+	delete(t.f.doc.Tracks, id)
+
+	if err := t.f.save(); err != nil {
+		t.f.doc.Tracks[id] = old
+		return err
+	}
+	return nil
+}
+
+var _ track.Store = (*Tracks)(nil)
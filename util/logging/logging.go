@@ -7,6 +7,9 @@
 // The LOG_FORMAT environment variable controls the output format:
 //   - "json": structured JSON output (production)
 //   - default: colorized text output (development)
+//
+// The LOG_LEVEL environment variable sets the minimum level
+// ("debug", "info", "warn", "error"; defaults to "info").
 package logging
 
 import (
@@ -15,6 +18,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
@@ -38,33 +42,55 @@ const (
 func Setup() {
 	var handler slog.Handler
 
+	level := parseLevel(os.Getenv("LOG_LEVEL"))
+
 	if os.Getenv("LOG_FORMAT") == "json" {
-		handler = slog.NewJSONHandler(os.Stderr, nil)
+		handler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})
 	} else {
-		handler = NewColorHandler(os.Stderr)
+		h := NewColorHandler(os.Stderr)
+		h.level = level
+		handler = h
 	}
 
 	slog.SetDefault(slog.New(handler))
 }
 
+// parseLevel converts a LOG_LEVEL value into a slog.Level.
+// Unknown or empty values fall back to Info.
+func parseLevel(raw string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
 // ColorHandler outputs logs with a colored level badge followed by gray text.
 type ColorHandler struct {
 	writer io.Writer
+	level  slog.Level
 	attrs  []slog.Attr
 	group  string
 	mu     sync.Mutex
 }
 
-// NewColorHandler creates a handler that outputs colorized text logs.
+// NewColorHandler creates a handler that outputs colorized text logs
+// at Info level and above.
 func NewColorHandler(w io.Writer) *ColorHandler {
 	return &ColorHandler{
 		writer: w,
+		level:  slog.LevelInfo,
 	}
 }
 
 // Enabled reports whether the handler handles records at the given level.
-func (h *ColorHandler) Enabled(_ context.Context, _ slog.Level) bool {
-	return true
+func (h *ColorHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
 }
 
 // Handle formats and writes the log record with colored level badge.
@@ -115,6 +141,7 @@ func (h *ColorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	newAttrs = append(newAttrs, attrs...)
 	return &ColorHandler{
 		writer: h.writer,
+		level:  h.level,
 		attrs:  newAttrs,
 		group:  h.group,
 	}
@@ -128,6 +155,7 @@ func (h *ColorHandler) WithGroup(name string) slog.Handler {
 	}
 	return &ColorHandler{
 		writer: h.writer,
+		level:  h.level,
 		attrs:  h.attrs,
 		group:  newGroup,
 	}